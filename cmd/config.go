@@ -0,0 +1,227 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go.yaml.in/yaml/v3"
+)
+
+const (
+	// defaultContextName is the name of the context created by login when no context is given or current
+	defaultContextName = "default"
+
+	// tokenReuseMargin is how long before its expiry a cached token is no longer reused
+	tokenReuseMargin = 2 * time.Minute
+)
+
+// contextConfig is a named context of the config file: the region and credentials of a tenant, and its cached token
+type contextConfig struct {
+	Region      string `yaml:"region,omitempty"`
+	Host        string `yaml:"host,omitempty"`
+	GraphQLPath string `yaml:"graphql-path,omitempty"`
+	AuthPath    string `yaml:"auth-path,omitempty"`
+
+	// ClientID and AccessKey are the credentials of the API key, unless they are read from the environment variables
+	// named by ClientIDEnv and AccessKeyEnv
+	ClientID     string `yaml:"client-id,omitempty"`
+	AccessKey    string `yaml:"access-key,omitempty"`
+	ClientIDEnv  string `yaml:"client-id-env,omitempty"`
+	AccessKeyEnv string `yaml:"access-key-env,omitempty"`
+
+	// Token is the last token issued for the credentials, reused by commands until TokenExpiry
+	Token       string    `yaml:"token,omitempty"`
+	TokenExpiry time.Time `yaml:"token-expiry,omitempty"`
+}
+
+// option returns the value of the context for the flag of an endpoint option
+func (c *contextConfig) option(flag string) string {
+	switch flag {
+	case "region":
+		return c.Region
+	case "host":
+		return c.Host
+	case "graphql-path":
+		return c.GraphQLPath
+	case "auth-path":
+		return c.AuthPath
+	}
+
+	return ""
+}
+
+// credentials returns the client ID and access key of the context, from the config file or the environment
+func (c *contextConfig) credentials() (string, string) {
+	clientID, accessKey := c.ClientID, c.AccessKey
+	if c.ClientIDEnv != "" {
+		clientID = os.Getenv(c.ClientIDEnv)
+	}
+
+	if c.AccessKeyEnv != "" {
+		accessKey = os.Getenv(c.AccessKeyEnv)
+	}
+
+	return clientID, accessKey
+}
+
+// credentialsHint describes where the credentials of the context are expected
+func (c *contextConfig) credentialsHint() string {
+	var envs []string
+	for _, env := range []string{c.ClientIDEnv, c.AccessKeyEnv} {
+		if env != "" {
+			envs = append(envs, env)
+		}
+	}
+
+	if len(envs) == 0 {
+		return "its client-id and access-key"
+	}
+
+	return strings.Join(envs, " and ")
+}
+
+// credentialsSource describes where the credentials of the context are read from
+func (c *contextConfig) credentialsSource() string {
+	if c.ClientIDEnv != "" || c.AccessKeyEnv != "" {
+		return "env"
+	}
+
+	if c.ClientID != "" || c.AccessKey != "" {
+		return "config"
+	}
+
+	return "none"
+}
+
+// cachedToken returns the cached token of the context if it is still valid, or an empty string
+func (c *contextConfig) cachedToken() string {
+	if c.Token == "" || (!c.TokenExpiry.IsZero() && time.Now().Add(tokenReuseMargin).After(c.TokenExpiry)) {
+		return ""
+	}
+
+	return c.Token
+}
+
+// configFile is the config file of the CLI, its other settings are kept as they are when the contexts are saved
+type configFile struct {
+	path     string
+	settings map[string]any
+
+	CurrentContext string                    `yaml:"current-context"`
+	Contexts       map[string]*contextConfig `yaml:"contexts"`
+}
+
+// configFilePath returns the path of the config file, which doesn't have to exist
+func configFilePath() (string, error) {
+	if cfgFile != "" {
+		return cfgFile, nil
+	}
+
+	if used := viper.ConfigFileUsed(); used != "" {
+		return used, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(home, ".inext.yaml"), nil
+}
+
+// loadConfigFile reads the config file, a missing file is an empty config
+func loadConfigFile() (*configFile, error) {
+	path, err := configFilePath()
+	if err != nil {
+		return nil, err
+	}
+
+	f := &configFile{path: path, settings: map[string]any{}, Contexts: map[string]*contextConfig{}}
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return f, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := yaml.Unmarshal(content, &f.settings); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	if err := yaml.Unmarshal(content, f); err != nil {
+		return nil, fmt.Errorf("failed to parse contexts of config file %s: %w", path, err)
+	}
+
+	if f.settings == nil {
+		f.settings = map[string]any{}
+	}
+
+	if f.Contexts == nil {
+		f.Contexts = map[string]*contextConfig{}
+	}
+
+	return f, nil
+}
+
+// save writes the contexts to the config file, which is only readable by the user since it holds credentials and tokens
+func (f *configFile) save() error {
+	f.settings["current-context"] = f.CurrentContext
+	f.settings["contexts"] = f.Contexts
+	content, err := yaml.Marshal(f.settings)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(f.path), 0o700); err != nil {
+		return err
+	}
+
+	// writing an existing file keeps its permissions, so a new file is written and replaces it
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), "."+filepath.Base(f.path)+".*")
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tmp.Name())
+	if err := tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), f.path)
+}
+
+// context returns the context with the --context flag as its name, or the current context if the flag is not set
+// returns a nil context if no context is used
+func (f *configFile) context() (string, *contextConfig, error) {
+	name := contextName
+	if name == "" {
+		name = f.CurrentContext
+	}
+
+	if name == "" {
+		return "", nil, nil
+	}
+
+	c, ok := f.Contexts[name]
+	if !ok {
+		return "", nil, fmt.Errorf("context %q not found in %s, run inext login --context %s to create it", name, f.path, name)
+	}
+
+	return name, c, nil
+}
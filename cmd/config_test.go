@@ -0,0 +1,184 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConfigFileSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".inext.yaml")
+	cfgFile = path
+	t.Cleanup(func() { cfgFile = "" })
+
+	content := `output: json
+profile-types:
+  - Kubernetes
+custom:
+  nested: value
+current-context: old
+contexts:
+  old:
+    region: eu
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := loadConfigFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expiry := time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC)
+	file.Contexts["new"] = &contextConfig{Region: "us", ClientID: "client-id", AccessKeyEnv: "ACCESS_KEY", Token: "token", TokenExpiry: expiry}
+	file.CurrentContext = "new"
+	if err := file.save(); err != nil {
+		t.Fatal(err)
+	}
+
+	// the permissions of an existing file are replaced, since it now holds credentials and tokens
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0o600 {
+		t.Fatalf("unexpected permissions of the config file: %v, %v", info.Mode(), err)
+	}
+
+	if entries, _ := os.ReadDir(filepath.Dir(path)); len(entries) != 1 {
+		t.Fatalf("temporary files were left next to the config file: %v", entries)
+	}
+
+	saved, err := loadConfigFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if saved.CurrentContext != "new" || !reflect.DeepEqual(saved.Contexts, map[string]*contextConfig{"old": {Region: "eu"}, "new": file.Contexts["new"]}) {
+		t.Fatalf("unexpected contexts after save: %+v", saved.Contexts)
+	}
+
+	wantSettings := map[string]any{
+		"output":        "json",
+		"profile-types": []any{"Kubernetes"},
+		"custom":        map[string]any{"nested": "value"},
+	}
+
+	for key, want := range wantSettings {
+		if got := saved.settings[key]; !reflect.DeepEqual(got, want) {
+			t.Errorf("setting %s = %#v after save, want %#v", key, got, want)
+		}
+	}
+}
+
+func TestCachedToken(t *testing.T) {
+	tests := []struct {
+		name   string
+		expiry time.Time
+		want   string
+	}{
+		{name: "no expiry", want: "token"},
+		{name: "valid", expiry: time.Now().Add(time.Hour), want: "token"},
+		{name: "about to expire", expiry: time.Now().Add(tokenReuseMargin / 2), want: ""},
+		{name: "expired", expiry: time.Now().Add(-time.Minute), want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &contextConfig{Token: "token", TokenExpiry: tt.expiry}
+			if got := c.cachedToken(); got != tt.want {
+				t.Errorf("cachedToken() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if got := (&contextConfig{}).cachedToken(); got != "" {
+		t.Errorf("cachedToken() of a context without a token = %q", got)
+	}
+}
+
+func TestNewClientContext(t *testing.T) {
+	server, flags := newTestServer(t)
+	path := flags[1]
+	t.Setenv("TEST_INEXT_ACCESS_KEY", server.AccessKey)
+	content := `current-context: test
+contexts:
+  test:
+    host: ` + server.URL + `
+    client-id: ` + server.ClientID + `
+    access-key-env: TEST_INEXT_ACCESS_KEY
+`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	discard := func(args ...string) error {
+		t.Helper()
+		_, err := runCommand(t, append([]string{"discard", "--config", path}, args...)...)
+		return err
+	}
+
+	// savedContext returns the context saved in the config file, after applying update to it if it is not nil
+	savedContext := func(update func(c *contextConfig)) *contextConfig {
+		t.Helper()
+		cfgFile = path
+		defer func() { cfgFile = "" }()
+		file, err := loadConfigFile()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if update != nil {
+			update(file.Contexts["test"])
+			if err := file.save(); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		return file.Contexts["test"]
+	}
+
+	// the current context is used and its token is cached, then reused
+	if err := discard(); err != nil {
+		t.Fatalf("discard with the current context failed: %v", err)
+	}
+
+	token := savedContext(nil).Token
+	if token == "" {
+		t.Fatal("the token was not cached in the context")
+	}
+
+	if err := discard(); err != nil || savedContext(nil).Token != token {
+		t.Fatalf("the cached token was not reused: %v", err)
+	}
+
+	// flags take precedence over the context, the access key of the flag is used with the client ID of the context,
+	// and explicit credentials neither use nor replace the cached token
+	if err := discard("--access-key", "wrong"); exitCode(err) != exitCodeAuth || savedContext(nil).Token != token {
+		t.Fatalf("expected the access key of the flag to be used, got %v", err)
+	}
+
+	if err := discard("--access-key", server.AccessKey); err != nil {
+		t.Fatalf("the access key of the flag was not used with the client ID of the context: %v", err)
+	}
+
+	savedContext(func(c *contextConfig) { c.Host = "http://127.0.0.1:1" })
+	if err := discard("--host", server.URL); err != nil {
+		t.Fatalf("expected the host of the flag to be used, got %v", err)
+	}
+
+	// an expired token is refreshed with the credentials of the context
+	savedContext(func(c *contextConfig) { c.Host, c.TokenExpiry = server.URL, time.Now().Add(-time.Minute) })
+	if err := discard(); err != nil || savedContext(nil).Token == token || savedContext(nil).TokenExpiry.Before(time.Now()) {
+		t.Fatalf("the expired token was not refreshed: %v", err)
+	}
+
+	if err := discard("--context", "missing"); err == nil || !strings.Contains(err.Error(), `context "missing" not found`) {
+		t.Fatalf("expected a missing context error, got %v", err)
+	}
+
+	t.Setenv("TEST_INEXT_ACCESS_KEY", "")
+	if err := discard(); err == nil || !strings.Contains(err.Error(), "set TEST_INEXT_ACCESS_KEY") {
+		t.Fatalf("expected a missing credentials error, got %v", err)
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// contextCmd represents the context command
+var contextCmd = &cobra.Command{
+	Use:   "context",
+	Short: "Manage the contexts of the config file",
+	Long: `A context holds the region and credentials of a tenant and its cached token, it is created by inext login.
+Commands use the current context, or the context of --context, for the options that are not set by flags or environment variables`,
+}
+
+// contextListCmd represents the context list command
+var contextListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the contexts of the config file",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res := newCommandResult(cmd)
+		file, err := loadConfigFile()
+		if err != nil {
+			return writeResult(cmd, res, err)
+		}
+
+		names := make([]string, 0, len(file.Contexts))
+		for name := range file.Contexts {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+		for _, name := range names {
+			c := file.Contexts[name]
+			contextRes := contextResult{
+				Name:        name,
+				Current:     name == file.CurrentContext,
+				Region:      c.Region,
+				Host:        c.Host,
+				ClientID:    c.ClientID,
+				Credentials: c.credentialsSource(),
+				TokenValid:  c.cachedToken() != "",
+			}
+
+			if c.ClientIDEnv != "" {
+				contextRes.ClientID = "$" + c.ClientIDEnv
+			}
+
+			if !c.TokenExpiry.IsZero() {
+				contextRes.TokenExpiry = c.TokenExpiry.Format(time.RFC3339)
+			}
+
+			res.Contexts = append(res.Contexts, contextRes)
+		}
+
+		if len(names) == 0 {
+			res.message = "No contexts, run inext login to create one"
+		}
+
+		return writeResult(cmd, res, nil)
+	},
+}
+
+// contextUseCmd represents the context use command
+var contextUseCmd = &cobra.Command{
+	Use:   "use <name>",
+	Short: "Set the current context of the config file",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res := newCommandResult(cmd)
+		file, err := loadConfigFile()
+		if err != nil {
+			return writeResult(cmd, res, err)
+		}
+
+		if _, ok := file.Contexts[args[0]]; !ok {
+			return writeResult(cmd, res, fmt.Errorf("context %q not found in %s", args[0], file.path))
+		}
+
+		file.CurrentContext = args[0]
+		if err := file.save(); err != nil {
+			return writeResult(cmd, res, err)
+		}
+
+		res.Context = args[0]
+		res.message = fmt.Sprintf("Switched to context %q", args[0])
+		return writeResult(cmd, res, nil)
+	},
+}
+
+func init() {
+	contextCmd.AddCommand(contextListCmd)
+	contextCmd.AddCommand(contextUseCmd)
+	rootCmd.AddCommand(contextCmd)
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEnforce(t *testing.T) {
+	server, flags := newTestServer(t)
+	profileID := server.AddObject("KubernetesProfile", map[string]any{"name": "profile"})
+
+	out, err := runCommand(t, append([]string{"enforce"}, flags...)...)
+	if err != nil || !strings.Contains(out, "Enforce policy task") {
+		t.Fatalf("enforce failed: %v, output: %s", err, out)
+	}
+
+	// the profiles to enforce are listed only if none are given
+	if operations := strings.Join(server.Operations(), ","); operations != "getProfiles,enforcePolicy,getTask" {
+		t.Fatalf("unexpected operations: %s", operations)
+	}
+
+	before := len(server.Operations())
+	if _, err := runCommand(t, append([]string{"enforce", "--profile-ids", profileID}, flags...)...); err != nil {
+		t.Fatalf("enforce of a profile failed: %v", err)
+	}
+
+	if operations := strings.Join(server.Operations()[before:], ","); operations != "enforcePolicy,getTask" {
+		t.Fatalf("unexpected operations: %s", operations)
+	}
+}
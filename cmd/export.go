@@ -0,0 +1,95 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/cobra"
+)
+
+const exportFormatHCL = "hcl"
+
+var (
+	exportFormat string
+	exportDir    string
+	exportForce  bool
+)
+
+// exportCmd represents the export command
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the objects of a tenant as Terraform configuration",
+	Long: `Export the assets, profiles, practices, behaviors and triggers of a tenant as Terraform resources.
+References between objects are written as references between their resources, and imports.tf holds an import
+block for each resource, so a single terraform plan (Terraform 1.5 or later) adopts all of them.
+The .tf files are written to --dir, existing files are only overwritten with --force`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := commandContext(cmd)
+		defer cancel()
+
+		res := newCommandResult(cmd)
+		if exportFormat != exportFormatHCL {
+			return writeResult(cmd, res, fmt.Errorf("invalid format %q, must be %s", exportFormat, exportFormatHCL))
+		}
+
+		client, err := newClient(ctx)
+		if err != nil {
+			return writeResult(cmd, res, err)
+		}
+
+		result, err := client.Export(ctx)
+		if err != nil {
+			return writeResult(cmd, res, err)
+		}
+
+		files := make([]string, 0, len(result.Files))
+		for name := range result.Files {
+			files = append(files, name)
+		}
+
+		sort.Strings(files)
+		if !exportForce {
+			for _, name := range files {
+				path := filepath.Join(exportDir, name)
+				if _, err := os.Stat(path); err == nil {
+					return writeResult(cmd, res, fmt.Errorf("%s already exists, use --force to overwrite it", path))
+				} else if !errors.Is(err, os.ErrNotExist) {
+					return writeResult(cmd, res, err)
+				}
+			}
+		}
+
+		if err := os.MkdirAll(exportDir, 0o755); err != nil {
+			return writeResult(cmd, res, err)
+		}
+
+		for _, name := range files {
+			if err := os.WriteFile(filepath.Join(exportDir, name), result.Files[name], 0o644); err != nil {
+				return writeResult(cmd, res, err)
+			}
+		}
+
+		for _, resource := range result.Resources {
+			res.Resources = append(res.Resources, exportedResourceResult{
+				Address:    resource.Address(),
+				ID:         resource.ID,
+				ObjectName: resource.ObjectName,
+				File:       filepath.Join(exportDir, resource.File),
+			})
+		}
+
+		res.Skipped = result.Skipped
+		res.message = fmt.Sprintf("Exported %d resources to %s, run terraform plan to import them", len(result.Resources), exportDir)
+		return writeResult(cmd, res, nil)
+	},
+}
+
+func init() {
+	exportCmd.Flags().StringVar(&exportFormat, "format", exportFormatHCL, "Format of the exported configuration, only hcl is supported")
+	exportCmd.Flags().StringVarP(&exportDir, "dir", "d", ".", "Directory to write the .tf files to")
+	exportCmd.Flags().BoolVar(&exportForce, "force", false, "Overwrite existing files")
+	rootCmd.AddCommand(exportCmd)
+}
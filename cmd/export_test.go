@@ -0,0 +1,50 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExport(t *testing.T) {
+	server, flags := newTestServer(t)
+	triggerID := server.AddObject("LogTrigger", map[string]any{"name": "trigger"})
+	dir := t.TempDir()
+	args := append([]string{"export", "--dir", dir}, flags...)
+
+	if _, err := runCommand(t, args...); err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+
+	imports := filepath.Join(dir, "imports.tf")
+	content, err := os.ReadFile(imports)
+	if err != nil || !strings.Contains(string(content), triggerID) {
+		t.Fatalf("imports.tf has no import block of the trigger: %v: %s", err, content)
+	}
+
+	// existing files are only overwritten with --force
+	if err := os.WriteFile(imports, []byte("# edited\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := runCommand(t, args...); err == nil || !strings.Contains(err.Error(), "already exists, use --force to overwrite it") {
+		t.Fatalf("expected export to refuse to overwrite files, got %v", err)
+	}
+
+	if content, _ := os.ReadFile(imports); string(content) != "# edited\n" {
+		t.Fatalf("imports.tf was overwritten without --force: %s", content)
+	}
+
+	if _, err := runCommand(t, append(args, "--force")...); err != nil {
+		t.Fatalf("export with --force failed: %v", err)
+	}
+
+	if content, _ := os.ReadFile(imports); !strings.Contains(string(content), triggerID) {
+		t.Fatalf("imports.tf was not overwritten with --force: %s", content)
+	}
+
+	if _, err := runCommand(t, append(args, "--format", "json")...); err == nil || !strings.Contains(err.Error(), `invalid format "json"`) {
+		t.Fatalf("expected an invalid format error, got %v", err)
+	}
+}
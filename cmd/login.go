@@ -0,0 +1,117 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/CheckPointSW/terraform-provider-infinity-next/pkg/inext"
+	"github.com/spf13/cobra"
+)
+
+var (
+	loginClientIDEnv  string
+	loginAccessKeyEnv string
+)
+
+// loginCmd represents the login command
+var loginCmd = &cobra.Command{
+	Use:   "login",
+	Short: "Authenticate and save the credentials and token of a context",
+	Long: `Authenticate with the credentials of the flags, the environment or the context, and save them with the issued token
+in the context of --context (default the current context, or "default" if there is none), which becomes the current context.
+Commands that use the context reuse its token until it expires, and then refresh it with its credentials.
+With --client-id-env and --access-key-env the context reads the credentials from these environment variables instead of saving them`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := commandContext(cmd)
+		defer cancel()
+
+		res := newCommandResult(cmd)
+		if explicitFlags["token"] {
+			return writeResult(cmd, res, errors.New("login requires a client ID and access key, not a token"))
+		}
+
+		file, err := loadConfigFile()
+		if err != nil {
+			return writeResult(cmd, res, err)
+		}
+
+		name := contextName
+		if name == "" {
+			name = file.CurrentContext
+		}
+
+		if name == "" {
+			name = defaultContextName
+		}
+
+		c := file.Contexts[name]
+		if c == nil {
+			c = &contextConfig{}
+		}
+
+		for flag, value := range map[string]*string{"region": &c.Region, "host": &c.Host, "graphql-path": &c.GraphQLPath, "auth-path": &c.AuthPath} {
+			if explicitFlags[flag] || *value == "" {
+				*value = flagValue(cmd, flag)
+			}
+		}
+
+		setCredential(&c.ClientID, &c.ClientIDEnv, clientID, loginClientIDEnv, explicitFlags["client-id"])
+		setCredential(&c.AccessKey, &c.AccessKeyEnv, accessKey, loginAccessKeyEnv, explicitFlags["access-key"])
+		loginClientID, loginAccessKey := c.credentials()
+		client, err := inext.NewClient(ctx, inext.Config{
+			Region:      c.Region,
+			ClientID:    loginClientID,
+			AccessKey:   loginAccessKey,
+			Host:        c.Host,
+			GraphQLPath: c.GraphQLPath,
+			AuthPath:    c.AuthPath,
+		})
+
+		if err != nil {
+			return writeResult(cmd, res, err)
+		}
+
+		c.Token, c.TokenExpiry = client.Token()
+		file.Contexts[name] = c
+		file.CurrentContext = name
+		if err := file.save(); err != nil {
+			return writeResult(cmd, res, fmt.Errorf("failed to save context %q: %w", name, err))
+		}
+
+		res.Context = name
+		res.message = fmt.Sprintf("Logged in to context %q", name)
+		if !c.TokenExpiry.IsZero() {
+			res.TokenExpiry = c.TokenExpiry.Format(time.RFC3339)
+			res.message += ", the token is valid until " + res.TokenExpiry
+		}
+
+		return writeResult(cmd, res, nil)
+	},
+}
+
+// flagValue returns the value of a string flag of the command
+func flagValue(cmd *cobra.Command, name string) string {
+	if f := cmd.Flags().Lookup(name); f != nil {
+		return f.Value.String()
+	}
+
+	return ""
+}
+
+// setCredential sets a credential of a context to the environment variable it is read from, or to its value if it was
+// given explicitly or the context has no source for it yet
+func setCredential(value, env *string, flagValue, envFlagValue string, explicit bool) {
+	switch {
+	case envFlagValue != "":
+		*value, *env = "", envFlagValue
+	case explicit || (*value == "" && *env == ""):
+		*value, *env = flagValue, ""
+	}
+}
+
+func init() {
+	loginCmd.Flags().StringVar(&loginClientIDEnv, "client-id-env", "", "Environment variable the context reads the client ID from, instead of saving it")
+	loginCmd.Flags().StringVar(&loginAccessKeyEnv, "access-key-env", "", "Environment variable the context reads the access key from, instead of saving it")
+	rootCmd.AddCommand(loginCmd)
+}
@@ -0,0 +1,300 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/CheckPointSW/terraform-provider-infinity-next/pkg/inext"
+	"github.com/spf13/cobra"
+)
+
+// Output formats of the --output flag
+const (
+	outputText  = "text"
+	outputJSON  = "json"
+	outputJUnit = "junit"
+)
+
+var outputFormats = []string{outputText, outputJSON, outputJUnit}
+
+// Exit codes of the CLI, so pipelines can tell why a command failed
+const (
+	exitCodeSuccess    = 0
+	exitCodeError      = 1
+	exitCodeAuth       = 2
+	exitCodeValidation = 3
+	exitCodeTask       = 4
+	exitCodeTimeout    = 5
+)
+
+const (
+	resultStatusSucceeded = "Succeeded"
+	resultStatusFailed    = "Failed"
+)
+
+var output string
+
+// commandResult is the result of a command, printed in the format of the --output flag
+type commandResult struct {
+	Command    string `json:"command"`
+	Status     string `json:"status"`
+	ExitCode   int    `json:"exitCode"`
+	Error      string `json:"error,omitempty"`
+	TaskID     string `json:"taskId,omitempty"`
+	TaskStatus string `json:"taskStatus,omitempty"`
+
+	// Errors and Warnings are the messages of the publish validation
+	Errors   []string `json:"errors"`
+	Warnings []string `json:"warnings"`
+
+	ProfileIDs []string `json:"profileIds,omitempty"`
+
+	// Resources are the resources written by export, Skipped are the objects it didn't export
+	Resources []exportedResourceResult `json:"resources,omitempty"`
+	Skipped   []string                 `json:"skipped,omitempty"`
+
+	// Context is the context that login saved or use switched to, TokenExpiry is the expiry of the token of login
+	Context     string          `json:"context,omitempty"`
+	TokenExpiry string          `json:"tokenExpiry,omitempty"`
+	Contexts    []contextResult `json:"contexts,omitempty"`
+
+	// message is printed with the text output
+	message string
+}
+
+// exportedResourceResult is an exported object in the result of export
+type exportedResourceResult struct {
+	Address    string `json:"address"`
+	ID         string `json:"id"`
+	ObjectName string `json:"objectName"`
+	File       string `json:"file"`
+}
+
+// contextResult is a context of the config file in the result of context list
+type contextResult struct {
+	Name        string `json:"name"`
+	Current     bool   `json:"current"`
+	Region      string `json:"region"`
+	Host        string `json:"host,omitempty"`
+	ClientID    string `json:"clientId"`
+	Credentials string `json:"credentials"`
+	TokenExpiry string `json:"tokenExpiry,omitempty"`
+	TokenValid  bool   `json:"tokenValid"`
+}
+
+// newCommandResult returns the result of a command, named by its path without the root command, e.g. context list
+func newCommandResult(cmd *cobra.Command) *commandResult {
+	return &commandResult{Command: strings.TrimPrefix(cmd.CommandPath(), rootCmd.Name()+" "), Errors: []string{}, Warnings: []string{}}
+}
+
+// setTaskResult sets the task and the validation messages of a publish task
+func (r *commandResult) setTaskResult(result *inext.TaskResult) {
+	r.TaskID, r.TaskStatus = result.ID, result.Status
+	r.Errors = append(r.Errors, result.PublishValidationErrors()...)
+	r.Warnings = append(r.Warnings, result.PublishValidationWarnings()...)
+}
+
+// writeResult prints the result of a command that ended with err in the format of the --output flag and returns err
+// with the text output the error itself is printed by cobra
+func writeResult(cmd *cobra.Command, r *commandResult, err error) error {
+	r.Status, r.ExitCode = resultStatusSucceeded, exitCode(err)
+	if err != nil {
+		r.Status, r.Error = resultStatusFailed, err.Error()
+	}
+
+	var validationErr *inext.ValidationError
+	if errors.As(err, &validationErr) {
+		r.TaskID = validationErr.TaskID
+		r.Errors = validationErr.Errors
+		r.Warnings = validationErr.Warnings
+	}
+
+	var taskErr *inext.TaskError
+	if errors.As(err, &taskErr) {
+		r.TaskID, r.TaskStatus = taskErr.TaskID, taskErr.Status
+	}
+
+	var writeErr error
+	switch output {
+	case outputJSON:
+		writeErr = writeJSON(cmd.OutOrStdout(), r)
+	case outputJUnit:
+		writeErr = writeJUnit(cmd.OutOrStdout(), r)
+	default:
+		writeText(cmd.OutOrStdout(), r)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return writeErr
+}
+
+func writeText(w io.Writer, r *commandResult) {
+	if len(r.Warnings) > 0 {
+		fmt.Fprintf(w, "validation warnings: %s\n", strings.Join(r.Warnings, ", "))
+	}
+
+	if len(r.Contexts) > 0 {
+		writeContexts(w, r.Contexts)
+	}
+
+	for _, skipped := range r.Skipped {
+		fmt.Fprintf(w, "skipped %s: the provider has no resource for its type\n", skipped)
+	}
+
+	if r.message != "" && r.ExitCode == exitCodeSuccess {
+		fmt.Fprintln(w, r.message)
+	}
+}
+
+// writeContexts prints a table of the contexts, the current context is marked with *
+func writeContexts(w io.Writer, contexts []contextResult) {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "CURRENT\tNAME\tREGION\tCLIENT ID\tCREDENTIALS\tTOKEN")
+	for _, c := range contexts {
+		current, tokenStatus := "", "none"
+		if c.Current {
+			current = "*"
+		}
+
+		switch {
+		case c.TokenValid && c.TokenExpiry != "":
+			tokenStatus = "valid until " + c.TokenExpiry
+		case c.TokenValid:
+			tokenStatus = "valid"
+		case c.TokenExpiry != "":
+			tokenStatus = "expired"
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", current, c.Name, c.Region, c.ClientID, c.Credentials, tokenStatus)
+	}
+
+	tw.Flush()
+}
+
+func writeJSON(w io.Writer, r *commandResult) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// writeJUnit prints the result as a JUnit report with a test case per validation message
+// a validation error is a failed test case and a warning is a passed one, other errors of the command are an errored test case
+func writeJUnit(w io.Writer, r *commandResult) error {
+	suite := junitTestSuite{Name: "inext " + r.Command}
+	className := "inext." + r.Command
+	for _, message := range r.Errors {
+		suite.TestCases = append(suite.TestCases, junitTestCase{
+			Name:      message,
+			ClassName: className + ".errors",
+			Failure:   &junitMessage{Message: message, Type: "ValidationError", Text: message},
+		})
+		suite.Failures++
+	}
+
+	for _, message := range r.Warnings {
+		suite.TestCases = append(suite.TestCases, junitTestCase{Name: message, ClassName: className + ".warnings", SystemOut: message})
+	}
+
+	// a validation failure is reported by the test cases of its errors
+	if r.ExitCode != exitCodeSuccess && (r.ExitCode != exitCodeValidation || len(r.Errors) == 0) {
+		suite.TestCases = append(suite.TestCases, junitTestCase{
+			Name:      r.Command,
+			ClassName: className,
+			Error:     &junitMessage{Message: r.Error, Type: exitCodeName(r.ExitCode), Text: r.Error},
+		})
+		suite.Errors++
+	} else if len(suite.TestCases) == 0 {
+		suite.TestCases = append(suite.TestCases, junitTestCase{Name: r.Command, ClassName: className, SystemOut: r.message})
+	}
+
+	suite.Tests = len(suite.TestCases)
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(junitTestSuites{Suites: []junitTestSuite{suite}}); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// exitCode returns the exit code of a command that ended with err
+func exitCode(err error) int {
+	var (
+		authErr         *inext.AuthError
+		unauthorizedErr *inext.UnauthorizedError
+		validationErr   *inext.ValidationError
+		taskErr         *inext.TaskError
+	)
+
+	switch {
+	case err == nil:
+		return exitCodeSuccess
+	case errors.As(err, &authErr), errors.As(err, &unauthorizedErr), errors.Is(err, inext.ErrTokenExpired):
+		return exitCodeAuth
+	case errors.As(err, &validationErr):
+		return exitCodeValidation
+	case errors.Is(err, context.DeadlineExceeded):
+		return exitCodeTimeout
+	case errors.As(err, &taskErr):
+		return exitCodeTask
+	default:
+		return exitCodeError
+	}
+}
+
+// exitCodeName returns the kind of failure of an exit code, used as the type of the error of a JUnit test case
+func exitCodeName(code int) string {
+	switch code {
+	case exitCodeAuth:
+		return "AuthError"
+	case exitCodeValidation:
+		return "ValidationError"
+	case exitCodeTimeout:
+		return "Timeout"
+	case exitCodeTask:
+		return "TaskError"
+	default:
+		return "Error"
+	}
+}
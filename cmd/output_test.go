@@ -0,0 +1,160 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/CheckPointSW/terraform-provider-infinity-next/pkg/inext"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "success", err: nil, want: exitCodeSuccess},
+		{name: "other error", err: errors.New("invalid flag"), want: exitCodeError},
+		{name: "authentication", err: &inext.AuthError{Err: errors.New("invalid credentials")}, want: exitCodeAuth},
+		{name: "expired token", err: fmt.Errorf("request failed: %w", inext.ErrTokenExpired), want: exitCodeAuth},
+		{name: "validation", err: fmt.Errorf("publish failed: %w", &inext.ValidationError{Errors: []string{"invalid policy"}}), want: exitCodeValidation},
+		{name: "task", err: &inext.TaskError{Operation: "publish", TaskID: "task-id", Status: "Failed"}, want: exitCodeTask},
+		{name: "timeout", err: fmt.Errorf("task did not finish: %w", context.DeadlineExceeded), want: exitCodeTimeout},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := exitCode(tt.err); got != tt.want {
+				t.Errorf("exitCode(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommandExitCodes(t *testing.T) {
+	server, flags := newTestServer(t)
+
+	// the exit code of a command is in its JSON result
+	run := func(args ...string) commandResult {
+		t.Helper()
+
+		out, err := runCommand(t, append(args, "--output", "json")...)
+		var res commandResult
+		if jsonErr := json.Unmarshal([]byte(out), &res); jsonErr != nil {
+			t.Fatalf("invalid JSON output: %v: %s", jsonErr, out)
+		}
+
+		if res.ExitCode != exitCode(err) {
+			t.Fatalf("the exit code of the result is %d, the command exits with %d", res.ExitCode, exitCode(err))
+		}
+
+		return res
+	}
+
+	if res := run(append([]string{"publish"}, flags...)...); res.ExitCode != exitCodeSuccess {
+		t.Fatalf("publish exited with %d: %s", res.ExitCode, res.Error)
+	}
+
+	if res := run(append(append([]string{"publish"}, flags[:4]...), "--client-id", server.ClientID, "--access-key", "wrong")...); res.ExitCode != exitCodeAuth {
+		t.Fatalf("publish with invalid credentials exited with %d: %s", res.ExitCode, res.Error)
+	}
+
+	server.FailNextPublish("invalid policy")
+	if res := run(append([]string{"publish"}, flags...)...); res.ExitCode != exitCodeValidation || res.Errors[0] != "invalid policy" {
+		t.Fatalf("publish of invalid changes exited with %d, errors %v", res.ExitCode, res.Errors)
+	}
+
+	server.TaskPolls = 1000
+	if res := run(append([]string{"publish", "--timeout", "200ms", "--poll-interval", "10ms"}, flags...)...); res.ExitCode != exitCodeTimeout {
+		t.Fatalf("publish that didn't finish in time exited with %d: %s", res.ExitCode, res.Error)
+	}
+}
+
+func TestJSONOutput(t *testing.T) {
+	server, flags := newTestServer(t)
+	server.WarnNextPublish("unused trigger")
+
+	out, err := runCommand(t, append([]string{"publish", "-o", "json"}, flags...)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var res map[string]any
+	if err := json.Unmarshal([]byte(out), &res); err != nil {
+		t.Fatalf("invalid JSON output: %v: %s", err, out)
+	}
+
+	want := map[string]any{
+		"command":    "publish",
+		"status":     resultStatusSucceeded,
+		"exitCode":   float64(exitCodeSuccess),
+		"taskId":     res["taskId"],
+		"taskStatus": "Succeeded",
+		"errors":     []any{},
+		"warnings":   []any{"unused trigger"},
+	}
+
+	if res["taskId"] == "" || fmt.Sprint(res) != fmt.Sprint(want) {
+		t.Fatalf("unexpected JSON result:\n%s", out)
+	}
+}
+
+func TestWriteJUnit(t *testing.T) {
+	decode := func(t *testing.T, r *commandResult) junitTestSuite {
+		t.Helper()
+
+		var out strings.Builder
+		if err := writeJUnit(&out, r); err != nil {
+			t.Fatal(err)
+		}
+
+		var suites junitTestSuites
+		if err := xml.Unmarshal([]byte(out.String()), &suites); err != nil || len(suites.Suites) != 1 {
+			t.Fatalf("invalid JUnit report: %v: %s", err, out.String())
+		}
+
+		return suites.Suites[0]
+	}
+
+	t.Run("validation messages", func(t *testing.T) {
+		suite := decode(t, &commandResult{
+			Command:  "publish",
+			ExitCode: exitCodeValidation,
+			Errors:   []string{"missing profile", "invalid url"},
+			Warnings: []string{"unused trigger"},
+		})
+
+		if suite.Tests != 3 || suite.Failures != 2 || suite.Errors != 0 || len(suite.TestCases) != 3 {
+			t.Fatalf("expected a test case per validation message, got %+v", suite)
+		}
+
+		for i, message := range []string{"missing profile", "invalid url"} {
+			if tc := suite.TestCases[i]; tc.Name != message || tc.Failure == nil || tc.Failure.Message != message {
+				t.Errorf("the validation error %q is not a failed test case: %+v", message, tc)
+			}
+		}
+
+		if tc := suite.TestCases[2]; tc.Name != "unused trigger" || tc.Failure != nil || tc.Error != nil {
+			t.Errorf("the validation warning is not a passed test case: %+v", tc)
+		}
+	})
+
+	t.Run("other error", func(t *testing.T) {
+		suite := decode(t, &commandResult{Command: "enforce", ExitCode: exitCodeTimeout, Error: "context deadline exceeded"})
+		if suite.Tests != 1 || suite.Errors != 1 || suite.TestCases[0].Error == nil || suite.TestCases[0].Error.Type != "Timeout" {
+			t.Fatalf("expected an errored test case of the command, got %+v", suite)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		suite := decode(t, &commandResult{Command: "discard", message: "Successfully discarded changes"})
+		if suite.Tests != 1 || suite.Failures != 0 || suite.Errors != 0 || suite.TestCases[0].SystemOut != "Successfully discarded changes" {
+			t.Fatalf("expected a passed test case of the command, got %+v", suite)
+		}
+	})
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPublish(t *testing.T) {
+	server, flags := newTestServer(t)
+	server.TaskPolls = 1
+	triggerID := server.AddObject("LogTrigger", map[string]any{"name": "trigger"})
+	server.WarnNextPublish("unused trigger")
+
+	args := append([]string{"publish", "--profile-types", "Kubernetes,Embedded", "--skip-nginx-validation", "--poll-interval", "10ms"}, flags...)
+	out, err := runCommand(t, args...)
+	if err != nil {
+		t.Fatalf("publish failed: %v", err)
+	}
+
+	if !strings.Contains(out, "validation warnings: unused trigger") {
+		t.Fatalf("the validation warnings were not printed: %s", out)
+	}
+
+	if server.PublishedObject(triggerID) == nil {
+		t.Fatal("trigger was not published")
+	}
+
+	if operations := strings.Join(server.Operations(), ","); operations != "asyncPublishChanges,getTask,getTask" {
+		t.Fatalf("the publish task was not waited for, operations: %s", operations)
+	}
+
+	server.FailNextPublish("invalid policy")
+	if _, err := runCommand(t, append([]string{"publish"}, flags...)...); err == nil || !strings.Contains(err.Error(), "invalid policy") {
+		t.Fatalf("expected a validation error, got %v", err)
+	}
+}
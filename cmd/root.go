@@ -1,78 +1,43 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"os"
+	"slices"
 	"strings"
+	"time"
 
+	"github.com/CheckPointSW/terraform-provider-infinity-next/pkg/inext"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/pkg/regions"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	viperpkg "github.com/spf13/viper"
 )
 
-const (
-	EUCIURL     = "https://cloudinfra-gw.portal.checkpoint.com"
-	USCIURL     = "https://cloudinfra-gw-us.portal.checkpoint.com"
-	AUCIURL     = "https://cloudinfra-gw.ap.portal.checkpoint.com"
-	INCIURL     = "https://cloudinfra-gw.in.portal.checkpoint.com"
-	AECIURL     = "https://cloudinfra-gw.ae.portal.checkpoint.com"
-	CACIURL     = "https://cloudinfra-gw.ca.portal.checkpoint.com"
-	DevCIURL    = "https://dev-cloudinfra-gw.kube1.iaas.checkpoint.com"
-	CIAuthPath  = "/auth/external"
-	DevCIAPIV1  = "/app/infinity2gem/graphql/V1"
-	appIDClaim  = "appId"
-	wafAppID    = "64488de9-f813-42a7-93e7-f3fe25dd9011"
-	policyAppID = "f47b536c-a990-42fb-9ab2-ec38f8c2dcff"
-	wafPath     = "/app/waf/graphql/V1"
-	policyPath  = "/app/i2/graphql/V1"
-)
-
 var (
-	clientID  string
-	accessKey string
-	region    string
-	token     string
-	cfgFile   string
+	clientID    string
+	accessKey   string
+	region      string
+	token       string
+	host        string
+	graphqlPath string
+	authPath    string
+	timeout     time.Duration
+	cfgFile     string
+	contextName string
+
+	// explicitFlags are the flags that were set on the command line or by environment variables, rather than by the
+	// config file or their defaults
+	explicitFlags = map[string]bool{}
 )
 
-type graphqlRequest struct {
-	Query     string                 `json:"query"`
-	Variables map[string]interface{} `json:"variables"`
-}
-
-type graphqlResponse[T any] struct {
-	Data T `json:"data"`
-}
-
-type externalAuthResponseData struct {
-	Token     string `json:"token"`
-	CSRF      string `json:"csrf"`
-	Expires   string `json:"expires"`
-	ExpiresIn int    `json:"expiresIn"`
-}
-
-type externalAuthResponse struct {
-	Success bool                     `json:"success"`
-	Data    externalAuthResponseData `json:"data"`
-}
-
 type lowerCaseStringEnvKeyReplacer struct{}
 
 func (r *lowerCaseStringEnvKeyReplacer) Replace(key string) string {
 	return strings.ReplaceAll(key, "-", "_")
 }
 
-type getTaskResponseGetTask struct {
-	ID     string `json:"id"`
-	Status string `json:"status"`
-}
-
-type getTaskResponseData struct {
-	GetTask getTaskResponseGetTask `json:"getTask"`
-}
-
-type getTaskResponse struct {
-	Data getTaskResponseData `json:"data"`
-}
-
 // rootCmd represents the base command when called without any subcommands
 var (
 	rootCmd = &cobra.Command{
@@ -81,7 +46,22 @@ var (
 		Long: `Infinity Next API Command Line Interface
 For example:
 inext publish && inext enforce
+
+Exit codes: 0 success, 1 error, 2 authentication failure, 3 validation failure, 4 task failure, 5 timeout
 `,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := bindFlags(cmd); err != nil {
+				return err
+			}
+
+			if !slices.Contains(outputFormats, output) {
+				return fmt.Errorf("invalid output %q, must be one of: %s", output, strings.Join(outputFormats, ", "))
+			}
+
+			// the usage is printed for invalid flags, not for errors of the command itself
+			cmd.SilenceUsage = true
+			return nil
+		},
 	}
 
 	viper = viperpkg.NewWithOptions(viperpkg.EnvKeyReplacer(&lowerCaseStringEnvKeyReplacer{}))
@@ -92,18 +72,24 @@ inext publish && inext enforce
 func main() {
 	err := rootCmd.Execute()
 	if err != nil {
-		os.Exit(1)
+		os.Exit(exitCode(err))
 	}
 }
 
 func init() {
 	cobra.OnInitialize(initConfig)
 
-	// Here you will define your flags and configuration settings.
-	// Cobra supports persistent flags, which, if defined here,
-	// will be global for your application.
-
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.inext.yaml)")
+	rootCmd.PersistentFlags().StringVar(&contextName, "context", "", "Context of the config file to use, instead of its current context")
+	rootCmd.PersistentFlags().StringVarP(&clientID, "client-id", "c", "", "Client ID of the API key")
+	rootCmd.PersistentFlags().StringVarP(&accessKey, "access-key", "k", "", "Access key of the API key")
+	rootCmd.PersistentFlags().StringVarP(&region, "region", "r", regions.DefaultRegion, "Region of Infinity Next API")
+	rootCmd.PersistentFlags().StringVarP(&token, "token", "t", "", "Authorization token of the API key, instead of the client ID and access key")
+	rootCmd.PersistentFlags().StringVar(&host, "host", "", "Base URL of the API, overrides the host of the region")
+	rootCmd.PersistentFlags().StringVar(&graphqlPath, "graphql-path", "", "Path of the GraphQL API under the host, overrides the path of the region")
+	rootCmd.PersistentFlags().StringVar(&authPath, "auth-path", "", "Path of the authentication API under the host, overrides the path of the region")
+	rootCmd.PersistentFlags().DurationVar(&timeout, "timeout", inext.DefaultTimeout, "Timeout of the command, including waiting for its tasks")
+	rootCmd.PersistentFlags().StringVarP(&output, "output", "o", outputText, "Output format: text, json or junit")
 }
 
 // initConfig reads in config file and ENV variables if set.
@@ -128,3 +114,111 @@ func initConfig() {
 	// If a config file is found, read it in.
 	viper.ReadInConfig()
 }
+
+// bindFlags sets the flags that were not given on the command line from the environment variables and the config file
+func bindFlags(cmd *cobra.Command) error {
+	if err := viper.BindPFlags(cmd.Flags()); err != nil {
+		return err
+	}
+
+	var err error
+	cmd.Flags().VisitAll(func(f *pflag.Flag) {
+		if _, ok := os.LookupEnv(envName(f.Name)); f.Changed || ok {
+			explicitFlags[f.Name] = true
+		}
+
+		if f.Changed || !viper.IsSet(f.Name) {
+			return
+		}
+
+		value := viper.GetString(f.Name)
+		if f.Value.Type() == "stringSlice" {
+			value = strings.Join(viper.GetStringSlice(f.Name), ",")
+		}
+
+		if value != "" && err == nil {
+			err = cmd.Flags().Set(f.Name, value)
+		}
+	})
+
+	return err
+}
+
+// envName returns the environment variable of a flag, e.g. INEXT_CLIENT_ID for client-id
+func envName(flag string) string {
+	return "INEXT_" + strings.ToUpper(strings.ReplaceAll(flag, "-", "_"))
+}
+
+// newClient returns a client of the API that is authenticated with the credentials of the flags
+// when a context is used, the options that were not set by flags or environment variables are taken from the context,
+// and its cached token is reused until it expires
+func newClient(ctx context.Context) (*inext.Client, error) {
+	cfg := inext.Config{
+		Region:      region,
+		ClientID:    clientID,
+		AccessKey:   accessKey,
+		Token:       token,
+		Host:        host,
+		GraphQLPath: graphqlPath,
+		AuthPath:    authPath,
+	}
+
+	file, err := loadConfigFile()
+	if err != nil {
+		return nil, err
+	}
+
+	name, c, err := file.context()
+	if err != nil {
+		return nil, err
+	}
+
+	if c == nil || explicitFlags["token"] {
+		return inext.NewClient(ctx, cfg)
+	}
+
+	for flag, value := range map[string]*string{"region": &cfg.Region, "host": &cfg.Host, "graphql-path": &cfg.GraphQLPath, "auth-path": &cfg.AuthPath} {
+		if contextValue := c.option(flag); contextValue != "" && !explicitFlags[flag] {
+			*value = contextValue
+		}
+	}
+
+	contextClientID, contextAccessKey := c.credentials()
+	if !explicitFlags["client-id"] {
+		cfg.ClientID = contextClientID
+	}
+
+	if !explicitFlags["access-key"] {
+		cfg.AccessKey = contextAccessKey
+	}
+
+	if cfg.ClientID == "" || cfg.AccessKey == "" {
+		return nil, fmt.Errorf("context %q has no credentials, set %s or run inext login --context %s", name, c.credentialsHint(), name)
+	}
+
+	// the cached token was issued for the credentials of the context
+	cfg.Token = ""
+	if explicitFlags["client-id"] || explicitFlags["access-key"] {
+		return inext.NewClient(ctx, cfg)
+	}
+
+	cfg.CachedToken = c.cachedToken()
+	client, err := inext.NewClient(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if cachedToken, expiry := client.Token(); cachedToken != c.Token {
+		c.Token, c.TokenExpiry = cachedToken, expiry
+		if err := file.save(); err != nil {
+			fmt.Fprintf(os.Stderr, "warning: failed to cache the token of context %q: %v\n", name, err)
+		}
+	}
+
+	return client, nil
+}
+
+// commandContext returns the context of a command, which is canceled after the timeout flag
+func commandContext(cmd *cobra.Command) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(cmd.Context(), timeout)
+}
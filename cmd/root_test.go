@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/CheckPointSW/terraform-provider-infinity-next/pkg/inext/inexttest"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	viperpkg "github.com/spf13/viper"
+)
+
+// newTestServer starts a fake API and points the CLI at it, with a config file in a temporary home directory
+// returns the flags of the endpoint and credentials of the fake
+func newTestServer(t *testing.T) (*inexttest.Server, []string) {
+	t.Helper()
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	server := inexttest.NewServer(t)
+	return server, []string{
+		"--config", filepath.Join(home, ".inext.yaml"),
+		"--host", server.URL,
+		"--client-id", server.ClientID,
+		"--access-key", server.AccessKey,
+	}
+}
+
+// runCommand runs the CLI with the given arguments and returns what it printed to stdout
+// the flags and the config of the previous run are reset, as each run of the CLI is a new process
+func runCommand(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+
+	resetFlags(rootCmd)
+	explicitFlags = map[string]bool{}
+	viper = viperpkg.NewWithOptions(viperpkg.EnvKeyReplacer(&lowerCaseStringEnvKeyReplacer{}))
+
+	var stdout, stderr bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetErr(&stderr)
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	return stdout.String(), err
+}
+
+// resetFlags sets the flags of a command and its subcommands back to their defaults
+func resetFlags(cmd *cobra.Command) {
+	for _, flags := range []*pflag.FlagSet{cmd.Flags(), cmd.PersistentFlags()} {
+		flags.VisitAll(func(f *pflag.Flag) {
+			if slice, ok := f.Value.(pflag.SliceValue); ok {
+				slice.Replace(nil)
+			} else {
+				f.Value.Set(f.DefValue)
+			}
+
+			f.Changed = false
+		})
+	}
+
+	for _, sub := range cmd.Commands() {
+		resetFlags(sub)
+	}
+}
+
+func TestTokenFlag(t *testing.T) {
+	server, flags := newTestServer(t)
+	token := server.Client(t).GetToken()
+
+	// the token authenticates without the credentials of the API key
+	flags = append(flags[:4], "--token", token)
+	server.AddObject("LogTrigger", map[string]any{"name": "trigger"})
+	if out, err := runCommand(t, append([]string{"discard"}, flags...)...); err != nil || !strings.Contains(out, "Successfully discarded changes") {
+		t.Fatalf("discard with a token failed: %v, output: %s", err, out)
+	}
+
+	if len(server.Objects()) != 0 {
+		t.Fatal("changes were not discarded")
+	}
+
+	if operations := strings.Join(server.Operations(), ","); operations != "discardChanges" {
+		t.Fatalf("the token was not used as is, operations: %s", operations)
+	}
+}
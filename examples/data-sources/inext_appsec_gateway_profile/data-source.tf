@@ -0,0 +1,19 @@
+terraform {
+  required_providers {
+    inext = {
+      source  = "CheckPointSW/infinity-next"
+      version = "~>1.5.3"
+    }
+  }
+}
+
+provider "inext" {
+  region = "eu"
+  # client_id  = ""  // can be set with env var INEXT_CLIENT_ID
+  # access_key = "" // can be set with env var INEXT_ACCESS_KEY
+}
+
+# look up an existing object by name or by id
+data "inext_appsec_gateway_profile" "my-appsec-gateway-profile" {
+  name = "my-appsec-gateway-profile"
+}
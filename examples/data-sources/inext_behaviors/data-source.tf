@@ -0,0 +1,24 @@
+terraform {
+  required_providers {
+    inext = {
+      source  = "CheckPointSW/infinity-next"
+      version = "~>1.5.3"
+    }
+  }
+}
+
+provider "inext" {
+  region = "eu"
+  # client_id  = ""  // can be set with env var INEXT_CLIENT_ID
+  # access_key = "" // can be set with env var INEXT_ACCESS_KEY
+}
+
+# list all exceptions whose name starts with "allow-"
+data "inext_behaviors" "allow-exceptions" {
+  name_search   = "allow-"
+  behavior_type = ["Exception"]
+}
+
+output "allow_exception_ids" {
+  value = data.inext_behaviors.allow-exceptions.ids
+}
@@ -0,0 +1,24 @@
+terraform {
+  required_providers {
+    inext = {
+      source  = "CheckPointSW/infinity-next"
+      version = "~>1.5.3"
+    }
+  }
+}
+
+provider "inext" {
+  region = "eu"
+  # client_id  = ""  // can be set with env var INEXT_CLIENT_ID
+  # access_key = "" // can be set with env var INEXT_ACCESS_KEY
+}
+
+# list all shared web application practices
+data "inext_practices" "shared-web-app-practices" {
+  practice_type = ["WebApplication"]
+  visibility    = ["Shared"]
+}
+
+output "shared_web_app_practice_names" {
+  value = data.inext_practices.shared-web-app-practices.practices[*].name
+}
@@ -0,0 +1,24 @@
+terraform {
+  required_providers {
+    inext = {
+      source  = "CheckPointSW/infinity-next"
+      version = "~>1.5.3"
+    }
+  }
+}
+
+provider "inext" {
+  region = "eu"
+  # client_id  = ""  // can be set with env var INEXT_CLIENT_ID
+  # access_key = "" // can be set with env var INEXT_ACCESS_KEY
+}
+
+# list all AWS gateway profiles
+data "inext_profiles" "aws-gateways" {
+  profile_type     = ["CloudGuardAppSecGateway"]
+  profile_sub_type = ["Aws"]
+}
+
+output "aws_gateway_profile_ids" {
+  value = data.inext_profiles.aws-gateways.ids
+}
@@ -0,0 +1,23 @@
+terraform {
+  required_providers {
+    inext = {
+      source  = "CheckPointSW/infinity-next"
+      version = "~>1.5.3"
+    }
+  }
+}
+
+provider "inext" {
+  region = "eu"
+  # client_id  = ""  // can be set with env var INEXT_CLIENT_ID
+  # access_key = "" // can be set with env var INEXT_ACCESS_KEY
+}
+
+# look up an existing object by name or by id
+data "inext_web_app_asset" "my-webapp-asset" {
+  name = "my-webapp-asset"
+}
+
+output "web_app_asset_urls" {
+  value = data.inext_web_app_asset.my-webapp-asset.urls
+}
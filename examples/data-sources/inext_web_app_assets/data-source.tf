@@ -0,0 +1,27 @@
+terraform {
+  required_providers {
+    inext = {
+      source  = "CheckPointSW/infinity-next"
+      version = "~>1.5.3"
+    }
+  }
+}
+
+provider "inext" {
+  region = "eu"
+  # client_id  = ""  // can be set with env var INEXT_CLIENT_ID
+  # access_key = "" // can be set with env var INEXT_ACCESS_KEY
+}
+
+# list all active web application assets of the production environment
+data "inext_web_app_assets" "production" {
+  name_regex = "^prod-"
+  state      = ["Active"]
+  tags = {
+    env = "production"
+  }
+}
+
+output "production_asset_urls" {
+  value = { for asset in data.inext_web_app_assets.production.assets : asset.name => asset.upstream_url }
+}
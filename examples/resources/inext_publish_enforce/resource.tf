@@ -47,6 +47,13 @@ resource "inext_publish_enforce" "publish-and-enforce" {
   # If empty or not provided, all profiles will be enforced
   # profile_ids = ["profile-id-1", "profile-id-2"]
 
+  # Optional: run publish/enforce only when the triggers change, so plans are empty otherwise
+  # mode = "on_change"
+  # triggers = {
+  #   asset   = sha1(jsonencode(inext_web_app_asset.my-webapp-asset))
+  #   profile = sha1(jsonencode(inext_appsec_gateway_profile.my-appsec-gateway-profile))
+  # }
+
   # IMPORTANT: depends_on MUST include ALL other resources to ensure
   # publish/enforce runs last and avoids conflicts
   depends_on = [
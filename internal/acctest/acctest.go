@@ -2,10 +2,18 @@ package acctest
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"sync"
 	"testing"
 
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/api"
 	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/provider"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/utils"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -13,6 +21,9 @@ import (
 
 const (
 	ProviderName = "inext"
+
+	// CassettesDir is the directory of the cassettes of each test, relative to the package of the test
+	CassettesDir = "testdata/cassettes"
 )
 
 // ProviderFactories is a static map containing only the main provider instance
@@ -55,7 +66,26 @@ func init() {
 //
 // These verifications and configuration are preferred at this level to prevent
 // provider developers from experiencing less clear errors for every test.
+//
+// When INEXT_VCR_MODE is set, the API traffic of the test is recorded to or replayed from
+// testdata/cassettes/<test name>.json. Replaying needs no credentials
 func PreCheck(t *testing.T) {
+	if mode := api.VCRMode(); mode != "" {
+		cassette := filepath.Join(CassettesDir, strings.ReplaceAll(t.Name(), "/", "_")+".json")
+		t.Setenv(api.VCRCassetteEnvVar, cassette)
+		if _, err := os.Stat(cassette); mode == api.VCRModeReplay && err != nil {
+			t.Fatalf("no cassette to replay %s from, record it against a tenant with make testacc-record: %v", t.Name(), err)
+		}
+
+		if mode == api.VCRModeReplay && os.Getenv("INEXT_TOKEN") == "" {
+			for _, envVar := range []string{"INEXT_CLIENT_ID", "INEXT_ACCESS_KEY"} {
+				if os.Getenv(envVar) == "" {
+					t.Setenv(envVar, "replay")
+				}
+			}
+		}
+	}
+
 	// Since we are outside the scope of the Terraform configuration we must
 	// call Configure() to properly initialize the provider configuration.
 	testAccProviderConfigure.Do(func() {
@@ -65,6 +95,55 @@ func PreCheck(t *testing.T) {
 	})
 }
 
+// Replaying reports whether API traffic is replayed from cassettes instead of sent to the API
+// tests can skip waits that are only needed against a real tenant
+func Replaying() bool {
+	return api.VCRMode() == api.VCRModeReplay
+}
+
+// CheckCassette returns an error if the cassette at path holds a token or the value of a sensitive field,
+// i.e. if it was not sanitized when it was recorded
+func CheckCassette(path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	for _, re := range utils.SecretRegexes {
+		if match := re.Find(content); match != nil {
+			return fmt.Errorf("cassette %s holds a secret matching %s", path, re)
+		}
+	}
+
+	var cassette struct {
+		Interactions []struct {
+			Operation string         `json:"operation"`
+			Variables any            `json:"variables"`
+			Response  map[string]any `json:"response"`
+		} `json:"interactions"`
+	}
+
+	if err := json.Unmarshal(content, &cassette); err != nil {
+		return fmt.Errorf("failed to parse cassette %s: %w", path, err)
+	}
+
+	for i, interaction := range cassette.Interactions {
+		// the data of a response holds the response itself, only its fields are redacted
+		values := map[string]any{"variables": interaction.Variables}
+		for k, v := range interaction.Response {
+			values["response "+k] = v
+		}
+
+		for name, v := range values {
+			if !reflect.DeepEqual(v, utils.RedactForLog(v)) {
+				return fmt.Errorf("cassette %s holds a sensitive value in the %s of interaction %d (%s)", path, name, i, interaction.Operation)
+			}
+		}
+	}
+
+	return nil
+}
+
 func ComposeTestCheckResourceAttrsFromMap(resourceName string, attributesWithValues map[string]string) []resource.TestCheckFunc {
 	funcs := make([]resource.TestCheckFunc, 0, len(attributesWithValues))
 	for attributeName, attributeValue := range attributesWithValues {
@@ -4,14 +4,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"hash/fnv"
 	"os"
+	"runtime"
 	"strings"
+	"sync"
 
 	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/api"
 	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
@@ -21,8 +24,52 @@ const (
 
 var resourceNameChartSet = acctest.CharSetAlpha + strings.ToUpper(acctest.CharSetAlpha)
 
+// generatedNames counts the names generated by each test when API traffic is recorded or replayed
+var generatedNames = struct {
+	sync.Mutex
+	count map[string]int
+}{count: map[string]int{}}
+
+// GenerateResourceName returns a random name for a test resource
+// when API traffic is recorded or replayed the name is derived from the name of the calling test instead,
+// so the requests of a replay match the recorded ones
 func GenerateResourceName() string {
-	return acctest.RandStringFromCharSet(resourceNameLength, resourceNameChartSet)
+	if api.VCRMode() == "" {
+		return acctest.RandStringFromCharSet(resourceNameLength, resourceNameChartSet)
+	}
+
+	testName := callerTestName()
+	generatedNames.Lock()
+	defer generatedNames.Unlock()
+	generatedNames.count[testName]++
+
+	hash := fnv.New64a()
+	fmt.Fprintf(hash, "%s/%d", testName, generatedNames.count[testName])
+	sum := hash.Sum64()
+	name := make([]byte, resourceNameLength)
+	for i := range name {
+		name[i] = resourceNameChartSet[sum%uint64(len(resourceNameChartSet))]
+		sum /= uint64(len(resourceNameChartSet))
+	}
+
+	return string(name)
+}
+
+// callerTestName returns the name of the test function in the call stack, e.g. TestAccLogTriggerBasic
+func callerTestName() string {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	for {
+		frame, more := frames.Next()
+		name := frame.Function[strings.LastIndex(frame.Function, ".")+1:]
+		if strings.HasPrefix(name, "Test") {
+			return name
+		}
+
+		if !more {
+			return ""
+		}
+	}
 }
 
 func MustReadFile(path string) string {
@@ -43,16 +90,23 @@ func CheckResourceDestroyed(resourcesNames []string) resource.TestCheckFunc {
 			}
 
 			if rs, ok := s.RootModule().Resources[resourceName]; ok {
-				rd := schema.ResourceData{}
-				rd.SetId(rs.Primary.ID)
+				r := Provider.ResourcesMap[resourceType]
+				rd := r.Data(&terraform.InstanceState{ID: rs.Primary.ID})
 				ctx := context.WithValue(context.Background(), utils.ExpectResourceNotFound, true)
-				diags := Provider.ResourcesMap[resourceType].ReadContext(ctx, &rd, Provider.Meta())
+				diags := r.ReadContext(ctx, rd, Provider.Meta())
 				if diags.HasError() {
 					for _, d := range diags {
-						if !strings.Contains(d.Summary, api.ErrorNotFound.Error()) {
+						if d.Severity == diag.Error && !strings.Contains(d.Summary, api.ErrorNotFound.Error()) {
 							return errors.New(d.Summary)
 						}
 					}
+
+					continue
+				}
+
+				// Read clears the ID of a resource whose object no longer exists
+				if rd.Id() != "" {
+					return fmt.Errorf("%s still exists", resourceName)
 				}
 			}
 		}
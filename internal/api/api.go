@@ -2,26 +2,70 @@ package api
 
 import (
 	"errors"
+	"net/http"
+	"sync"
 	"time"
+
+	"github.com/CheckPointSW/terraform-provider-infinity-next/pkg/regions"
 )
 
 type Client struct {
 	token    string
 	host     string
 	endpoint string
+	authPath string
+
+	// endpointPinned keeps the endpoint when authenticating, instead of switching it by the application of the token
+	endpointPinned bool
+
+	// clientID and accessKey are kept to re-authenticate when the token expires
+	clientID    string
+	accessKey   string
+	tokenExpiry time.Time
+
+	retryPolicy RetryPolicy
+
+	// autoPublisher publishes the changes of all resources when auto publish is enabled, nil otherwise
+	autoPublisher *autoPublisher
+
+	// sessionGuard checks for unpublished changes of other users when a session conflict policy is set, nil otherwise
+	sessionGuard *sessionGuard
+
+	// discardOnFailure makes DiscardChanges discard all the changes in the session instead of rolling back the failed operation
+	discardOnFailure bool
+
+	// httpClient is shared by all requests so connections are kept alive between them
+	httpClient *http.Client
+
+	// authLock guards the token, its expiry and the endpoint, which is switched when a token is applied
+	// the client is shared by all resources
+	// and terraform runs their operations concurrently
+	authLock sync.Mutex
 }
 
 var (
-	ErrorNotFound   error         = errors.New("not found")
-	clientTimeout   time.Duration = 60
-	maxNumOfRetries int           = 4
+	ErrorNotFound error = errors.New("not found")
+
+	// ErrTokenExpired is returned when a token configured without credentials has expired
+	ErrTokenExpired error = errors.New("token expired")
+
+	// tokenRefreshMargin is how long before its expiry the token is refreshed
+	tokenRefreshMargin = 2 * time.Minute
 )
 
 func NewClient() *Client {
-	return &Client{}
+	// the default options are always valid
+	httpClient, _ := NewHTTPClient(HTTPClientOptions{})
+	return &Client{
+		authPath:    regions.DefaultAuthPath,
+		retryPolicy: DefaultRetryPolicy(),
+		httpClient:  httpClient,
+	}
 }
 
 func (c *Client) SetToken(token string) {
+	c.authLock.Lock()
+	defer c.authLock.Unlock()
 	c.token = token
 }
 
@@ -30,10 +74,43 @@ func (c *Client) SetHost(host string) {
 }
 
 func (c *Client) SetEndpoint(endpoint string) {
+	c.authLock.Lock()
+	defer c.authLock.Unlock()
+	c.endpoint = endpoint
+}
+
+// PinEndpoint sets the endpoint and keeps it when authenticating
+// by default the endpoint is switched to the API of the application the token was issued for
+func (c *Client) PinEndpoint(endpoint string) {
+	c.authLock.Lock()
+	defer c.authLock.Unlock()
 	c.endpoint = endpoint
+	c.endpointPinned = true
+}
+
+// SetAuthPath sets the path of the authentication API under the host
+func (c *Client) SetAuthPath(authPath string) {
+	c.authPath = authPath
+}
+
+// SetRetryPolicy sets the policy used to retry failed requests
+// a policy with less than one attempt makes a single attempt
+func (c *Client) SetRetryPolicy(policy RetryPolicy) {
+	if policy.MaxAttempts < 1 {
+		policy.MaxAttempts = 1
+	}
+
+	c.retryPolicy = policy
+}
+
+// SetHTTPClient sets the HTTP client used for all requests, see NewHTTPClient
+func (c *Client) SetHTTPClient(httpClient *http.Client) {
+	c.httpClient = httpClient
 }
 
 func (c *Client) GetToken() string {
+	c.authLock.Lock()
+	defer c.authLock.Unlock()
 	return c.token
 }
 
@@ -42,5 +119,35 @@ func (c *Client) GetHost() string {
 }
 
 func (c *Client) GetEndpoint() string {
+	c.authLock.Lock()
+	defer c.authLock.Unlock()
 	return c.endpoint
 }
+
+func (c *Client) GetAuthPath() string {
+	return c.authPath
+}
+
+// GetTokenExpiry returns the expiration time of the current token
+// the zero time is returned if the token has no expiration time
+func (c *Client) GetTokenExpiry() time.Time {
+	c.authLock.Lock()
+	defer c.authLock.Unlock()
+	return c.tokenExpiry
+}
+
+// identity returns the client ID the client authenticated with, which the API reports as the modifiedBy of its changes
+// an empty string is returned if the client was created with a token only
+func (c *Client) identity() string {
+	c.authLock.Lock()
+	defer c.authLock.Unlock()
+	return c.clientID
+}
+
+func (c *Client) GetRetryPolicy() RetryPolicy {
+	return c.retryPolicy
+}
+
+func (c *Client) GetHTTPClient() *http.Client {
+	return c.httpClient
+}
@@ -0,0 +1,208 @@
+package api
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/utils"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// DefaultAutoPublishQuietPeriod is how long no write must be sent before the changes are published
+// terraform starts the operations of independent resources together, so they are published in a single cycle
+const DefaultAutoPublishQuietPeriod = 2 * time.Second
+
+// sessionOperations are the mutations that act on the session itself and don't change objects in it
+var sessionOperations = map[string]struct{}{
+	"asyncPublishChanges": {},
+	"publishChanges":      {},
+	"enforcePolicy":       {},
+	"discardChanges":      {},
+}
+
+// AutoPublishOptions configures publishing of the changes of all resources after the last write, see EnableAutoPublish
+type AutoPublishOptions struct {
+	// Publish publishes the session and waits for the publish to finish, returning its validation errors
+	Publish func(ctx context.Context, c *Client) error
+
+	// Enforce enforces the published policy and waits for it to finish, nil to only publish
+	Enforce func(ctx context.Context, c *Client) error
+
+	// QuietPeriod is how long after the last write the changes are published, DefaultAutoPublishQuietPeriod if zero
+	QuietPeriod time.Duration
+}
+
+// autoPublisher tracks the writes of all resources and publishes them in cycles
+// a cycle starts when a resource operation waits for its changes to be published, and publishes once
+// no write was sent for the quiet period. All the operations that wait on a cycle get its result
+//
+// An operation returns only after its cycle, and terraform starts the operations of dependent resources only after
+// the resources they depend on were applied. So an apply publishes once for each level of its dependency graph
+// that has changes: resources that don't depend on each other are published together, and a resource that
+// depends on a changed resource is published in a later cycle
+type autoPublisher struct {
+	opts AutoPublishOptions
+
+	// writeLock is held for reading by writes in flight and for writing by a publish,
+	// so objects are not changed while the session is published
+	writeLock sync.RWMutex
+
+	mu        sync.Mutex
+	inFlight  int
+	dirty     bool
+	lastWrite time.Time
+	cycle     *publishCycle
+}
+
+type publishCycle struct {
+	done chan struct{}
+	err  error
+
+	// ctx carries the values of the operation that started the cycle, e.g. its logger, without its cancellation
+	ctx context.Context
+
+	// deadline is the latest deadline of the operations waiting on the cycle, the publish must finish before it
+	// unbounded is set when one of them has no deadline
+	deadline  time.Time
+	unbounded bool
+}
+
+// join adds the deadline of an operation that waits on the cycle
+// the caller must hold the lock of the auto publisher
+func (cycle *publishCycle) join(ctx context.Context) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		cycle.unbounded = true
+		return
+	}
+
+	if deadline.After(cycle.deadline) {
+		cycle.deadline = deadline
+	}
+}
+
+// publishContext returns the context of the publish of the cycle, with the latest deadline of its operations
+// the caller must hold the lock of the auto publisher, after the cycle stopped accepting operations
+func (cycle *publishCycle) publishContext() (context.Context, context.CancelFunc) {
+	if cycle.unbounded {
+		return context.WithCancel(cycle.ctx)
+	}
+
+	return context.WithDeadline(cycle.ctx, cycle.deadline)
+}
+
+// EnableAutoPublish makes PublishChanges publish the changes of all resources, instead of leaving them in the session
+// for the inext_publish_enforce resource or the CLI to publish
+func (c *Client) EnableAutoPublish(opts AutoPublishOptions) {
+	if opts.QuietPeriod <= 0 {
+		opts.QuietPeriod = DefaultAutoPublishQuietPeriod
+	}
+
+	c.autoPublisher = &autoPublisher{opts: opts}
+}
+
+// AutoPublishEnabled reports whether PublishChanges publishes the changes of all resources
+func (c *Client) AutoPublishEnabled() bool {
+	return c.autoPublisher != nil
+}
+
+// beginWrite marks the session as changed by a GraphQL request and returns a function to call once the request is done
+// requests that don't change objects return a no-op
+func (p *autoPublisher) beginWrite(gql, responseKey string) func() {
+	if !isWrite(gql, responseKey) {
+		return func() {}
+	}
+
+	p.writeLock.RLock()
+	p.mu.Lock()
+	p.inFlight++
+	p.dirty = true
+	p.mu.Unlock()
+
+	return func() {
+		p.mu.Lock()
+		p.inFlight--
+		p.lastWrite = time.Now()
+		p.mu.Unlock()
+		p.writeLock.RUnlock()
+	}
+}
+
+// discarded marks the session as unchanged after its changes were discarded
+func (p *autoPublisher) discarded() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.dirty = false
+}
+
+// wait joins the pending publish cycle, starting one if there is none, and returns its result
+// it returns early when ctx is done, the cycle still publishes in the background, but nothing publishes it
+// when the provider stops first, so the changes of a canceled operation can be left unpublished in the session
+func (p *autoPublisher) wait(ctx context.Context, c *Client) error {
+	p.mu.Lock()
+	cycle := p.cycle
+	if cycle == nil {
+		cycle = &publishCycle{done: make(chan struct{}), ctx: context.WithoutCancel(ctx)}
+		p.cycle = cycle
+		go p.run(c, cycle)
+	}
+
+	cycle.join(ctx)
+	p.mu.Unlock()
+
+	select {
+	case <-cycle.done:
+		return cycle.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run waits for the writes to settle and publishes them, then completes the cycle
+func (p *autoPublisher) run(c *Client, cycle *publishCycle) {
+	defer close(cycle.done)
+
+	for {
+		p.mu.Lock()
+		wait := p.opts.QuietPeriod - time.Since(p.lastWrite)
+		if p.inFlight > 0 && wait <= 0 {
+			wait = p.opts.QuietPeriod
+		}
+
+		p.mu.Unlock()
+
+		if wait <= 0 {
+			break
+		}
+
+		time.Sleep(wait)
+	}
+
+	p.writeLock.Lock()
+	defer p.writeLock.Unlock()
+
+	// operations that wait from now on start a new cycle, for the writes that come after this publish
+	p.mu.Lock()
+	p.cycle = nil
+	dirty := p.dirty
+	p.dirty = false
+	ctx, cancel := cycle.publishContext()
+	p.mu.Unlock()
+	defer cancel()
+
+	if !dirty {
+		return
+	}
+
+	ctx = utils.NewLogContext(ctx)
+	tflog.SubsystemInfo(ctx, logSubsystem, "Publishing the changes of all resources")
+	if cycle.err = p.opts.Publish(ctx, c); cycle.err != nil {
+		return
+	}
+
+	if p.opts.Enforce != nil {
+		tflog.SubsystemInfo(ctx, logSubsystem, "Enforcing the published policy")
+		cycle.err = p.opts.Enforce(ctx, c)
+	}
+}
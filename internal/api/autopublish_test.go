@@ -0,0 +1,149 @@
+package api_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/api"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/apitest"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources"
+	publishenforce "github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources/publish-enforce"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAutoPublish(t *testing.T) {
+	server := apitest.NewServer(t)
+	c := server.Client(t)
+	c.EnableAutoPublish(api.AutoPublishOptions{
+		Publish: func(ctx context.Context, c *api.Client) error {
+			_, err := publishenforce.ExecutePublish(ctx, c, nil)
+			return err
+		},
+		QuietPeriod: 100 * time.Millisecond,
+	})
+
+	// resources written together are published in a single cycle
+	r := resources.ResourceLogTrigger()
+	var wg sync.WaitGroup
+	states := make([]*terraform.InstanceState, 2)
+	errs := make([]error, 2)
+	for i := range states {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			states[i], errs[i] = apitest.TryApply(r, nil, map[string]any{"name": fmt.Sprintf("trigger%d", i)}, c)
+		}(i)
+	}
+
+	wg.Wait()
+	for i, state := range states {
+		if errs[i] != nil {
+			t.Fatal(errs[i])
+		}
+
+		if server.PublishedObject(state.ID) == nil {
+			t.Fatalf("trigger %d was not published", i)
+		}
+	}
+
+	if publishes := strings.Count(strings.Join(server.Operations(), ","), "asyncPublishChanges"); publishes != 1 {
+		t.Fatalf("expected a single publish, got %d", publishes)
+	}
+
+	// publish validation errors are reported on the written resource
+	server.FailNextPublish("invalid trigger")
+	if _, err := apitest.TryApply(r, states[0], map[string]any{"name": "trigger0", "verbosity": "Extended"}, c); err == nil || !strings.Contains(err.Error(), "invalid trigger") {
+		t.Fatalf("expected a publish validation error, got %v", err)
+	}
+}
+
+func TestAutoPublishDependencyLevels(t *testing.T) {
+	server := apitest.NewServer(t)
+	c := server.Client(t)
+	c.EnableAutoPublish(api.AutoPublishOptions{
+		Publish: func(ctx context.Context, c *api.Client) error {
+			_, err := publishenforce.ExecutePublish(ctx, c, nil)
+			return err
+		},
+		QuietPeriod: 50 * time.Millisecond,
+	})
+
+	// the trigger and the practice don't depend on each other, and are published together
+	var wg sync.WaitGroup
+	var trigger, practice *terraform.InstanceState
+	var triggerErr, practiceErr error
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		trigger, triggerErr = apitest.TryApply(resources.ResourceLogTrigger(), nil, map[string]any{"name": "trigger"}, c)
+	}()
+	go func() {
+		defer wg.Done()
+		practice, practiceErr = apitest.TryApply(resources.ResourceWebAppPractice(), nil, map[string]any{"name": "practice"}, c)
+	}()
+	wg.Wait()
+	if triggerErr != nil || practiceErr != nil {
+		t.Fatal(triggerErr, practiceErr)
+	}
+
+	// the asset is applied after the resources it depends on, so it is published in another cycle
+	apitest.Apply(t, resources.ResourceWebAppAsset(), nil, map[string]any{
+		"name": "asset",
+		"urls": []any{"http://host/path"},
+		"practice": []any{map[string]any{
+			"id":        practice.ID,
+			"main_mode": "Prevent",
+			"triggers":  []any{trigger.ID},
+		}},
+	}, c)
+
+	if publishes := strings.Count(strings.Join(server.Operations(), ","), "asyncPublishChanges"); publishes != 2 {
+		t.Fatalf("expected a publish for each of the 2 dependency levels, got %d", publishes)
+	}
+}
+
+func TestAutoPublishDeadline(t *testing.T) {
+	server := apitest.NewServer(t)
+	c := server.Client(t)
+	published := make(chan time.Time, 1)
+	release := make(chan struct{})
+	c.EnableAutoPublish(api.AutoPublishOptions{
+		Publish: func(ctx context.Context, c *api.Client) error {
+			deadline, _ := ctx.Deadline()
+			published <- deadline
+			<-release
+			return nil
+		},
+		QuietPeriod: 50 * time.Millisecond,
+	})
+
+	// the publish is bounded by the latest deadline of the operations that wait for it
+	r := resources.ResourceLogTrigger()
+	start := time.Now()
+	errs := make(chan error, 2)
+	for i, timeout := range []string{"200ms", "10m"} {
+		go func() {
+			_, err := apitest.TryApply(r, nil, map[string]any{"name": fmt.Sprintf("trigger%d", i), "timeouts": map[string]any{"create": timeout}}, c)
+			errs <- err
+		}()
+	}
+
+	deadline := <-published
+	if deadline.Before(start.Add(9*time.Minute)) || deadline.After(time.Now().Add(10*time.Minute)) {
+		t.Fatalf("expected the publish deadline in 10m, got %v", deadline.Sub(start))
+	}
+
+	// the operation whose timeout passed stops waiting, while the publish goes on for the other
+	if err := <-errs; err == nil || !strings.Contains(err.Error(), "context deadline exceeded") {
+		t.Fatalf("expected the first operation to time out, got %v", err)
+	}
+
+	close(release)
+	if err := <-errs; err != nil {
+		t.Fatal(err)
+	}
+}
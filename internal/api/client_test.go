@@ -0,0 +1,41 @@
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/api"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/apitest"
+	logtrigger "github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources/log-trigger"
+)
+
+func TestClientRecovery(t *testing.T) {
+	server := apitest.NewServer(t)
+	c := server.Client(t)
+	triggerID := server.AddObject("LogTrigger", map[string]any{"name": "trigger"})
+
+	server.FailNext(2, http.StatusServiceUnavailable)
+	if _, err := logtrigger.GetLogTrigger(context.Background(), c, triggerID); err != nil {
+		t.Fatalf("request was not retried: %v", err)
+	}
+
+	server.RevokeTokens()
+	if _, err := logtrigger.GetLogTrigger(context.Background(), c, triggerID); err != nil {
+		t.Fatalf("client did not re-authenticate: %v", err)
+	}
+
+	// invalid credentials are not retried
+	badClient := api.NewClient()
+	badClient.SetHost(server.URL)
+	start := time.Now()
+	if err := badClient.InfinityPortalAuthentication(context.Background(), server.ClientID, "wrong"); err == nil || !strings.Contains(err.Error(), "401") {
+		t.Fatalf("expected an authentication failure, got %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("authentication with invalid credentials was retried for %v", elapsed)
+	}
+}
@@ -0,0 +1,233 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Error codes returned in GraphError.Extensions.Code
+const (
+	ErrorCodeNotFound          = "NOT_FOUND"
+	ErrorCodeDependencyMissing = "DEPENDENCY_MISSING"
+	ErrorCodeObjectInUse       = "OBJECT_IN_USE"
+	ErrorCodeValidationFailed  = "VALIDATION_FAILED"
+	ErrorCodeUnauthorized      = "UNAUTHORIZED"
+	ErrorCodeSessionConflict   = "SESSION_CONFLICT"
+	ErrorCodeAlreadyExists     = "ALREADY_EXISTS"
+)
+
+// Kinds of objects that a DependencyMissingError may refer to
+const (
+	DependencyKindProfile  = "Profile"
+	DependencyKindTrigger  = "Trigger"
+	DependencyKindPractice = "Practice"
+	DependencyKindBehavior = "Behavior"
+)
+
+// errorCodeAliases maps other codes the API and the GraphQL server are known to return to the codes above
+var errorCodeAliases = map[string]string{
+	"OBJECT_NOT_FOUND":          ErrorCodeNotFound,
+	"DEPENDENCY_NOT_FOUND":      ErrorCodeDependencyMissing,
+	"OBJECT_IS_POINTED":         ErrorCodeObjectInUse,
+	"GRAPHQL_PARSE_FAILED":      ErrorCodeValidationFailed,
+	"GRAPHQL_VALIDATION_FAILED": ErrorCodeValidationFailed,
+	"BAD_USER_INPUT":            ErrorCodeValidationFailed,
+	"VALIDATION_ERROR":          ErrorCodeValidationFailed,
+	"UNAUTHENTICATED":           ErrorCodeUnauthorized,
+	"TOKEN_EXPIRED":             ErrorCodeUnauthorized,
+	"FORBIDDEN":                 ErrorCodeUnauthorized,
+	"SESSION_LOCKED":            ErrorCodeSessionConflict,
+}
+
+// dependencyIDParams maps the message params of a dependency error to the kind of the missing object
+var dependencyIDParams = map[string]string{
+	"profileId":   DependencyKindProfile,
+	"triggerId":   DependencyKindTrigger,
+	"practiceId":  DependencyKindPractice,
+	"parameterId": DependencyKindBehavior,
+}
+
+// APIError is an error returned in the errors of a GraphQL response
+// it is embedded in each of the typed errors below, use errors.As to check for a specific error:
+//
+//	var inUseErr *api.ObjectInUseError
+//	if errors.As(err, &inUseErr) { ... }
+type APIError struct {
+	Code          string
+	Message       string
+	ReferenceID   string
+	MessageParams map[string]any
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("GraphQL response contains errors: %s, ReferenceID: %s", e.Message, e.ReferenceID)
+}
+
+// NotFoundError is returned when the requested object does not exist
+// it matches ErrorNotFound with errors.Is
+type NotFoundError struct {
+	*APIError
+}
+
+func (e *NotFoundError) Is(target error) bool {
+	return target == ErrorNotFound
+}
+
+// DependencyMissingError is returned when an object refers to another object that does not exist
+type DependencyMissingError struct {
+	*APIError
+
+	// Kind is the kind of the missing object, one of the DependencyKind constants
+	Kind string
+
+	// ID is the ID of the missing object
+	ID string
+}
+
+// ObjectInUseError is returned when deleting an object that other objects refer to
+type ObjectInUseError struct {
+	*APIError
+}
+
+// ValidationFailedError is returned when the request or its input is invalid
+type ValidationFailedError struct {
+	*APIError
+}
+
+// UnauthorizedError is returned when the token is expired, invalid or lacks permissions
+type UnauthorizedError struct {
+	*APIError
+}
+
+// SessionConflictError is returned when the changes conflict with the session of another user
+type SessionConflictError struct {
+	*APIError
+}
+
+// AlreadyExistsError is returned when creating an object whose name is already used by another object
+type AlreadyExistsError struct {
+	*APIError
+}
+
+// AuthError is returned when authentication with the API fails, e.g. for invalid credentials or a malformed token
+type AuthError struct {
+	Err error
+}
+
+func (e *AuthError) Error() string {
+	return fmt.Sprintf("failed authenticating to Infinity Next: %v", e.Err)
+}
+
+func (e *AuthError) Unwrap() error {
+	return e.Err
+}
+
+// newGraphError converts an error of a GraphQL response to its typed error
+// the type is taken from the error code, errors without a known code are classified by their message
+// as the API does not set a code on all of its errors
+func newGraphError(graphError GraphError, headers http.Header) error {
+	apiErr := &APIError{
+		Message:     graphError.Message,
+		ReferenceID: getReferenceIDFromHeaders(headers),
+	}
+
+	if graphError.Extensions != nil {
+		apiErr.Code = strings.ToUpper(graphError.Extensions.Code)
+		if graphError.Extensions.ReferenceID != "" {
+			apiErr.ReferenceID = graphError.Extensions.ReferenceID
+		}
+
+		if params, ok := graphError.Extensions.MessageParams.(map[string]any); ok {
+			apiErr.MessageParams = params
+		}
+	}
+
+	code := apiErr.Code
+	if alias, ok := errorCodeAliases[code]; ok {
+		code = alias
+	}
+
+	if code == "" || !isKnownErrorCode(code) {
+		code = errorCodeFromMessage(apiErr.Message)
+	}
+
+	switch code {
+	case ErrorCodeNotFound:
+		return &NotFoundError{apiErr}
+	case ErrorCodeDependencyMissing:
+		kind, id := dependencyFromAPIError(apiErr)
+		return &DependencyMissingError{APIError: apiErr, Kind: kind, ID: id}
+	case ErrorCodeObjectInUse:
+		return &ObjectInUseError{apiErr}
+	case ErrorCodeValidationFailed:
+		return &ValidationFailedError{apiErr}
+	case ErrorCodeUnauthorized:
+		return &UnauthorizedError{apiErr}
+	case ErrorCodeSessionConflict:
+		return &SessionConflictError{apiErr}
+	case ErrorCodeAlreadyExists:
+		return &AlreadyExistsError{apiErr}
+	}
+
+	return apiErr
+}
+
+func isKnownErrorCode(code string) bool {
+	switch code {
+	case ErrorCodeNotFound, ErrorCodeDependencyMissing, ErrorCodeObjectInUse,
+		ErrorCodeValidationFailed, ErrorCodeUnauthorized, ErrorCodeSessionConflict, ErrorCodeAlreadyExists:
+		return true
+	}
+
+	return false
+}
+
+// errorCodeFromMessage classifies an error without a known code by its message
+func errorCodeFromMessage(message string) string {
+	lowerMessage := strings.ToLower(message)
+	switch {
+	// Profile: %(profileType)sProfile with ID %(profileId)s does not exist
+	// Trigger: Trigger with ID %(triggerId)s does not exist
+	// Practice: Practice with ID %(practiceId)s does not exist OR Practice with ID %(practiceId)s is not connected to this Asset/Zone
+	// Behavior: %(parameterType)sParameter with ID %(parameterId)s does not exist
+	case strings.Contains(message, "does not exist") || strings.Contains(message, "is not connected to this Asset/Zone"):
+		return ErrorCodeDependencyMissing
+	case strings.Contains(message, "can't be deleted since it is pointed from other objects"):
+		return ErrorCodeObjectInUse
+	case strings.Contains(lowerMessage, "already exists"):
+		return ErrorCodeAlreadyExists
+	case strings.Contains(lowerMessage, "jwt expired") || strings.Contains(lowerMessage, "token expired"):
+		return ErrorCodeUnauthorized
+	}
+
+	return ""
+}
+
+// dependencyFromAPIError returns the kind and ID of the missing object of a dependency error
+// from its message params, or from its message if it has no params
+func dependencyFromAPIError(apiErr *APIError) (string, string) {
+	for param, kind := range dependencyIDParams {
+		if id, ok := apiErr.MessageParams[param].(string); ok && id != "" {
+			return kind, id
+		}
+	}
+
+	id := ""
+	if _, afterID, found := strings.Cut(apiErr.Message, "ID "); found {
+		id, _, _ = strings.Cut(afterID, " ")
+	}
+
+	switch {
+	case strings.Contains(apiErr.Message, "Profile"):
+		return DependencyKindProfile, id
+	case strings.Contains(apiErr.Message, "Trigger"):
+		return DependencyKindTrigger, id
+	case strings.Contains(apiErr.Message, "Practice"):
+		return DependencyKindPractice, id
+	case strings.Contains(apiErr.Message, "Parameter"):
+		return DependencyKindBehavior, id
+	}
+
+	return "", id
+}
@@ -0,0 +1,181 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestNewGraphError(t *testing.T) {
+	headers := http.Header{"Logger-Token": {"header-reference"}}
+	for _, tc := range []struct {
+		name          string
+		graphError    GraphError
+		wantType      error
+		wantCode      string
+		wantReference string
+	}{
+		{
+			name:          "known code",
+			graphError:    GraphError{Message: "gone", Extensions: &GraphErrorExtension{Code: "NOT_FOUND"}},
+			wantType:      &NotFoundError{},
+			wantCode:      ErrorCodeNotFound,
+			wantReference: "header-reference",
+		},
+		{
+			name:          "lower case alias",
+			graphError:    GraphError{Message: "in use", Extensions: &GraphErrorExtension{Code: "object_is_pointed", ReferenceID: "extension-reference"}},
+			wantType:      &ObjectInUseError{},
+			wantCode:      "OBJECT_IS_POINTED",
+			wantReference: "extension-reference",
+		},
+		{
+			name:          "graphql validation",
+			graphError:    GraphError{Message: "Cannot query field", Extensions: &GraphErrorExtension{Code: "GRAPHQL_VALIDATION_FAILED"}},
+			wantType:      &ValidationFailedError{},
+			wantCode:      "GRAPHQL_VALIDATION_FAILED",
+			wantReference: "header-reference",
+		},
+		{
+			name:          "session locked",
+			graphError:    GraphError{Message: "locked", Extensions: &GraphErrorExtension{Code: "SESSION_LOCKED"}},
+			wantType:      &SessionConflictError{},
+			wantCode:      "SESSION_LOCKED",
+			wantReference: "header-reference",
+		},
+		{
+			name:          "dependency message without code",
+			graphError:    GraphError{Message: "Trigger with ID 1234 does not exist"},
+			wantType:      &DependencyMissingError{},
+			wantReference: "header-reference",
+		},
+		{
+			name:          "in use message with unknown code",
+			graphError:    GraphError{Message: "Object can't be deleted since it is pointed from other objects", Extensions: &GraphErrorExtension{Code: "SOMETHING_ELSE"}},
+			wantType:      &ObjectInUseError{},
+			wantCode:      "SOMETHING_ELSE",
+			wantReference: "header-reference",
+		},
+		{
+			name:          "already exists message",
+			graphError:    GraphError{Message: "Asset with name my-asset already exists"},
+			wantType:      &AlreadyExistsError{},
+			wantReference: "header-reference",
+		},
+		{
+			name:          "expired token message",
+			graphError:    GraphError{Message: "JWT expired"},
+			wantType:      &UnauthorizedError{},
+			wantReference: "header-reference",
+		},
+		{
+			name:          "unknown",
+			graphError:    GraphError{Message: "something went wrong", Extensions: &GraphErrorExtension{Code: "INTERNAL_SERVER_ERROR"}},
+			wantType:      &APIError{},
+			wantCode:      "INTERNAL_SERVER_ERROR",
+			wantReference: "header-reference",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := newGraphError(tc.graphError, headers)
+			if reflect.TypeOf(err) != reflect.TypeOf(tc.wantType) {
+				t.Fatalf("newGraphError() = %T, want %T", err, tc.wantType)
+			}
+
+			apiErr := apiErrorOf(err)
+			if apiErr.Message != tc.graphError.Message || apiErr.Code != tc.wantCode || apiErr.ReferenceID != tc.wantReference {
+				t.Errorf("newGraphError() = %+v, want message %q, code %q and reference %q",
+					apiErr, tc.graphError.Message, tc.wantCode, tc.wantReference)
+			}
+		})
+	}
+}
+
+func TestNewGraphErrorNotFound(t *testing.T) {
+	err := newGraphError(GraphError{Message: "gone", Extensions: &GraphErrorExtension{Code: "OBJECT_NOT_FOUND"}}, nil)
+	if !errors.Is(err, ErrorNotFound) {
+		t.Fatalf("%v does not match ErrorNotFound", err)
+	}
+}
+
+func TestDependencyFromAPIError(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		apiErr   *APIError
+		wantKind string
+		wantID   string
+	}{
+		{
+			name:     "profile param",
+			apiErr:   &APIError{Message: "does not exist", MessageParams: map[string]any{"profileId": "p1"}},
+			wantKind: DependencyKindProfile,
+			wantID:   "p1",
+		},
+		{
+			name:     "behavior param",
+			apiErr:   &APIError{Message: "does not exist", MessageParams: map[string]any{"parameterType": "Exception", "parameterId": "b1"}},
+			wantKind: DependencyKindBehavior,
+			wantID:   "b1",
+		},
+		{
+			name:     "empty param falls back to the message",
+			apiErr:   &APIError{Message: "Trigger with ID t1 does not exist", MessageParams: map[string]any{"triggerId": ""}},
+			wantKind: DependencyKindTrigger,
+			wantID:   "t1",
+		},
+		{
+			name:     "profile message",
+			apiErr:   &APIError{Message: "KubernetesProfile with ID p2 does not exist"},
+			wantKind: DependencyKindProfile,
+			wantID:   "p2",
+		},
+		{
+			name:     "practice message",
+			apiErr:   &APIError{Message: "Practice with ID pr1 is not connected to this Asset/Zone"},
+			wantKind: DependencyKindPractice,
+			wantID:   "pr1",
+		},
+		{
+			name:     "behavior message",
+			apiErr:   &APIError{Message: "TrustedSourceParameter with ID b2 does not exist"},
+			wantKind: DependencyKindBehavior,
+			wantID:   "b2",
+		},
+		{
+			name:   "unknown message",
+			apiErr: &APIError{Message: "something does not exist"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			kind, id := dependencyFromAPIError(tc.apiErr)
+			if kind != tc.wantKind || id != tc.wantID {
+				t.Errorf("dependencyFromAPIError() = %q, %q, want %q, %q", kind, id, tc.wantKind, tc.wantID)
+			}
+		})
+	}
+}
+
+// apiErrorOf returns the APIError of a typed error
+func apiErrorOf(err error) *APIError {
+	switch e := err.(type) {
+	case *APIError:
+		return e
+	case *NotFoundError:
+		return e.APIError
+	case *DependencyMissingError:
+		return e.APIError
+	case *ObjectInUseError:
+		return e.APIError
+	case *ValidationFailedError:
+		return e.APIError
+	case *UnauthorizedError:
+		return e.APIError
+	case *SessionConflictError:
+		return e.APIError
+	case *AlreadyExistsError:
+		return e.APIError
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package api
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	DefaultRequestTimeout = 60 * time.Second
+
+	// maxIdleConnsPerHost matches the default parallelism of terraform, so concurrent
+	// operations on resources reuse their connections instead of opening new ones
+	maxIdleConnsPerHost = 10
+)
+
+// HTTPClientOptions configures the HTTP client used for all requests to the API
+type HTTPClientOptions struct {
+	// Timeout is the timeout of a single request, DefaultRequestTimeout if zero
+	Timeout time.Duration
+
+	// ProxyURL is the URL of the proxy to send requests through
+	// if empty, the proxy is taken from the HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables
+	ProxyURL string
+
+	// CACertPEM holds PEM encoded certificates to trust in addition to the system certificates
+	// e.g. the CA of a TLS-intercepting proxy
+	CACertPEM []byte
+
+	// InsecureSkipVerify disables verification of the server certificate, for lab use only
+	InsecureSkipVerify bool
+}
+
+// NewHTTPClient returns an HTTP client with a pooled transport configured by opts
+// if INEXT_VCR_MODE is set, the traffic of the client is recorded or replayed, see VCRModeEnvVar
+func NewHTTPClient(opts HTTPClientOptions) (*http.Client, error) {
+	transport := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		MaxIdleConnsPerHost:   maxIdleConnsPerHost,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+		TLSClientConfig: &tls.Config{
+			MinVersion: tls.VersionTLS12,
+		},
+	}
+
+	if opts.ProxyURL != "" {
+		proxyURL, err := url.Parse(opts.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL %q: %w", opts.ProxyURL, err)
+		}
+
+		if proxyURL.Scheme == "" || proxyURL.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %q: must be of the form scheme://host[:port]", opts.ProxyURL)
+		}
+
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if len(opts.CACertPEM) > 0 {
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil || rootCAs == nil {
+			rootCAs = x509.NewCertPool()
+		}
+
+		if !rootCAs.AppendCertsFromPEM(opts.CACertPEM) {
+			return nil, fmt.Errorf("failed to parse CA certificates: no PEM encoded certificates found")
+		}
+
+		transport.TLSClientConfig.RootCAs = rootCAs
+	}
+
+	transport.TLSClientConfig.InsecureSkipVerify = opts.InsecureSkipVerify
+
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = DefaultRequestTimeout
+	}
+
+	var roundTripper http.RoundTripper = transport
+	if mode := VCRMode(); mode != "" {
+		roundTripper = newVCRTransport(mode, transport)
+	}
+
+	return &http.Client{
+		Transport: roundTripper,
+		Timeout:   timeout,
+	}, nil
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -12,15 +13,17 @@ import (
 	"time"
 
 	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/utils"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/pkg/regions"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 const (
 	appIDClaim  = "appId"
 	wafAppID    = "64488de9-f813-42a7-93e7-f3fe25dd9011"
 	policyAppID = "f47b536c-a990-42fb-9ab2-ec38f8c2dcff"
-	wafPath     = "/app/waf/graphql/V1"
-	policyPath  = "/app/i2/graphql/V1"
+	wafPath     = regions.WAFGraphQLPath
+	policyPath  = regions.PolicyGraphQLPath
 )
 
 type GraphQLRequest struct {
@@ -51,143 +54,283 @@ type RetryErrorResponse struct {
 	ReqDidTimeout any `json:"reqDidTimeout"`
 }
 
-type DependencyErrorResponse struct {
-	ProfileID  string
-	TriggerID  string
-	PracticeID string
-	BehaviorID string
+// InfinityPortalAuthentication authenticates with the given credentials and keeps them
+// so the client can re-authenticate when the token is about to expire or was rejected
+func (c *Client) InfinityPortalAuthentication(ctx context.Context, clientId string, accessKey string) error {
+	c.authLock.Lock()
+	defer c.authLock.Unlock()
+
+	c.clientID = clientId
+	c.accessKey = accessKey
+
+	return c.authenticate(utils.NewLogContext(ctx))
 }
 
-func CheckDependencyErrorResponse(err error) *DependencyErrorResponse {
-	if err == nil {
-		return nil
+// authenticate fetches a new token using the stored credentials
+// the caller must hold authLock
+func (c *Client) authenticate(ctx context.Context) error {
+	formData := url.Values{
+		"clientId":  {c.clientID},
+		"accessKey": {c.accessKey},
 	}
 
-	errMessage := err.Error()
-	// Check if the error message contains a specific pattern indicating a dependency error
-	// The patterns are:
-	// Profile: %(profileType)sProfile with ID %(profileId)s does not exist
-	// Trigger: Trigger with ID %(triggerId)s does not exist
-	// Practice: Practice with ID %(practiceId)s does not exist OR Practice with ID %(practiceId)s is not connected to this Asset/Zone
-	// Behavior: %(parameterType)sParameter with ID %(parameterId)s does not exist
-	if !strings.Contains(errMessage, "does not exist") && !strings.Contains(errMessage, "is not connected to this Asset/Zone") {
-		return nil
+	policy := c.GetRetryPolicy()
+	for attempt := 1; ; attempt++ {
+		start := time.Now()
+		err := c.requestToken(ctx, formData)
+		if err == nil {
+			tflog.SubsystemDebug(ctx, logSubsystem, "Authenticated with the Infinity Portal", map[string]any{
+				"host":         c.host,
+				"endpoint":     c.endpoint,
+				"duration_ms":  time.Since(start).Milliseconds(),
+				"retry_count":  attempt - 1,
+				"token_expiry": c.tokenExpiry.String(),
+			})
+
+			return nil
+		}
+
+		var statusErr *authStatusError
+		if attempt >= policy.MaxAttempts || !isTransientRequestError(ctx, err) || (errors.As(err, &statusErr) && !isTransientStatusCode(statusErr.statusCode)) {
+			tflog.SubsystemError(ctx, logSubsystem, "Authentication with the Infinity Portal failed", map[string]any{
+				"host":        c.host,
+				"retry_count": attempt - 1,
+				"error":       err.Error(),
+			})
+
+			return err
+		}
+
+		tflog.SubsystemWarn(ctx, logSubsystem, "Authentication with the Infinity Portal failed, retrying", map[string]any{
+			"host":  c.host,
+			"error": err.Error(),
+		})
+
+		if waitErr := policy.waitBeforeRetry(ctx, attempt, 0); waitErr != nil {
+			return fmt.Errorf("authentication canceled while waiting to retry: %w. Last error: %w", waitErr, err)
+		}
 	}
+}
+
+// authStatusError is returned when the authentication API responds with an error status, e.g. for invalid credentials
+type authStatusError struct {
+	statusCode int
+	status     string
+}
 
-	// Extract the profile ID, trigger ID, practice ID, and behavior ID from the error message based on the start of the message
-	var profileID, triggerID, practiceID, behaviorID string
-	if strings.Contains(errMessage, "Profile") {
-		profileID = strings.Split(strings.Split(errMessage, "ID ")[1], " ")[0]
+func (e *authStatusError) Error() string {
+	return fmt.Sprintf("authentication failed with status %s", e.status)
+}
+
+// requestToken makes a single authentication request and stores the returned token and its expiry
+// the caller must hold authLock
+func (c *Client) requestToken(ctx context.Context, formData url.Values) error {
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, c.host+c.authPath, strings.NewReader(formData.Encode()))
+	if err != nil {
+		return err
 	}
 
-	if strings.Contains(errMessage, "Trigger") {
-		triggerID = strings.Split(strings.Split(errMessage, "ID ")[1], " ")[0]
+	httpRequest.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := c.httpClient.Do(httpRequest)
+	if err != nil {
+		return err
 	}
 
-	if strings.Contains(errMessage, "Practice") {
-		practiceID = strings.Split(strings.Split(errMessage, "ID ")[1], " ")[0]
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return &authStatusError{statusCode: resp.StatusCode, status: resp.Status}
 	}
 
-	if strings.Contains(errMessage, "Parameter") {
-		behaviorID = strings.Split(strings.Split(errMessage, "ID ")[1], " ")[0]
+	var result map[string]any
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return err
 	}
 
-	return &DependencyErrorResponse{
-		ProfileID:  profileID,
-		TriggerID:  triggerID,
-		PracticeID: practiceID,
-		BehaviorID: behaviorID,
+	data, err := json.Marshal(result["data"])
+	if err != nil {
+		return err
+	}
+
+	var datamap map[string]any
+	if err := json.Unmarshal(data, &datamap); err != nil {
+		return err
+	}
+
+	tokenInterface, ok := datamap["token"]
+	if !ok {
+		return &AuthError{Err: fmt.Errorf("missing token in response %#v", result)}
 	}
+
+	token, ok := tokenInterface.(string)
+	if !ok {
+		return &AuthError{Err: fmt.Errorf("token in response is a %T, not a string", tokenInterface)}
+	}
+
+	return c.applyToken(token)
 }
 
-func (c *Client) InfinityPortalAuthentication(clientId string, accessKey string) error {
-	timeout := clientTimeout * time.Second
-	client := http.Client{
-		Timeout: timeout,
+// TokenAuthentication authenticates with a token issued outside of the provider
+// the client can't re-authenticate with a token, so requests fail with ErrTokenExpired once it expires
+func (c *Client) TokenAuthentication(ctx context.Context, token string) error {
+	c.authLock.Lock()
+	defer c.authLock.Unlock()
+
+	if err := c.applyToken(token); err != nil {
+		return err
 	}
 
-	formData := url.Values{
-		"clientId":  {clientId},
-		"accessKey": {accessKey},
+	if err := c.checkTokenExpiry(); err != nil {
+		return err
 	}
 
-	for retryCount := 1; retryCount <= maxNumOfRetries; retryCount++ {
-		resp, err := client.PostForm(c.host+"/auth/external", formData)
-		if err != nil {
-			if retryCount == maxNumOfRetries {
-				return err
-			}
+	tflog.SubsystemDebug(utils.NewLogContext(ctx), logSubsystem, "Authenticated with a pre-issued token", map[string]any{
+		"host":         c.host,
+		"endpoint":     c.endpoint,
+		"token_expiry": c.tokenExpiry.String(),
+	})
 
-			time.Sleep(2 * time.Second * time.Duration(retryCount))
-			continue
-		}
+	return nil
+}
 
-		defer resp.Body.Close()
+// CachedTokenAuthentication authenticates with a token that was issued for the given credentials earlier, e.g. by a
+// previous run of the CLI, and keeps the credentials so the client can re-authenticate like InfinityPortalAuthentication
+// a new token is fetched right away if the cached token can't be parsed or is about to expire
+func (c *Client) CachedTokenAuthentication(ctx context.Context, token, clientId, accessKey string) error {
+	c.authLock.Lock()
+	defer c.authLock.Unlock()
 
-		var result map[string]any
-		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-			if retryCount == maxNumOfRetries {
-				return err
-			}
+	c.clientID = clientId
+	c.accessKey = accessKey
 
-			time.Sleep(2 * time.Second * time.Duration(retryCount))
-			continue
-		}
+	ctx = utils.NewLogContext(ctx)
+	if err := c.applyToken(token); err == nil && (c.tokenExpiry.IsZero() || time.Now().Add(tokenRefreshMargin).Before(c.tokenExpiry)) {
+		tflog.SubsystemDebug(ctx, logSubsystem, "Authenticated with a cached token", map[string]any{
+			"host":         c.host,
+			"endpoint":     c.endpoint,
+			"token_expiry": c.tokenExpiry.String(),
+		})
 
-		data, err := json.Marshal(result["data"])
-		if err != nil {
-			if retryCount == maxNumOfRetries {
-				return err
-			}
+		return nil
+	}
 
-			time.Sleep(2 * time.Second * time.Duration(retryCount))
-			continue
-		}
+	return c.authenticate(ctx)
+}
 
-		jsonStr := string(data)
-		var datamap map[string]any
-		if err := json.Unmarshal([]byte(jsonStr), &datamap); err != nil {
-			if retryCount == maxNumOfRetries {
-				return err
-			}
+// applyToken stores the token and its expiry, and switches the endpoint to the API of the application
+// the token was issued for, unless the endpoint is pinned
+// the caller must hold authLock
+func (c *Client) applyToken(tokenString string) error {
+	token, _, err := jwt.NewParser().ParseUnverified(tokenString, jwt.MapClaims{})
+	if err != nil {
+		return fmt.Errorf("failed to parse token: %w", err)
+	}
 
-			time.Sleep(2 * time.Second * time.Duration(retryCount))
-			continue
+	c.token = tokenString
+	tokenMapClaims := token.Claims.(jwt.MapClaims)
+	if appID, ok := tokenMapClaims[appIDClaim]; ok && !c.endpointPinned {
+		switch appID.(string) {
+		case wafAppID:
+			c.endpoint = wafPath
+		case policyAppID:
+			c.endpoint = policyPath
 		}
+	}
 
-		tokenInterface, ok := datamap["token"]
-		if !ok {
-			if retryCount == maxNumOfRetries {
-				return fmt.Errorf("missing token in response %#v", result)
-			}
+	c.tokenExpiry = time.Time{}
+	if exp, err := tokenMapClaims.GetExpirationTime(); err == nil && exp != nil {
+		c.tokenExpiry = exp.Time
+	}
 
-			time.Sleep(2 * time.Second * time.Duration(retryCount))
-			continue
+	return nil
+}
+
+// checkTokenExpiry returns ErrTokenExpired if the token has expired
+// the caller must hold authLock
+func (c *Client) checkTokenExpiry() error {
+	if !c.tokenExpiry.IsZero() && time.Now().After(c.tokenExpiry) {
+		return fmt.Errorf("%w at %s, issue a new token or configure client_id and access_key instead",
+			ErrTokenExpired, c.tokenExpiry.Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+// validToken returns a token to send with a request and the URL to send it to, refreshing the current token first
+// if it expires within tokenRefreshMargin
+// the URL is read with the token, since applying a new token may switch the endpoint
+func (c *Client) validToken(ctx context.Context) (string, string, error) {
+	c.authLock.Lock()
+	defer c.authLock.Unlock()
+
+	if !c.canReauthenticate() {
+		if err := c.checkTokenExpiry(); err != nil {
+			return "", "", err
 		}
 
-		c.token = tokenInterface.(string)
-		token, _, err := jwt.NewParser().ParseUnverified(c.token, jwt.MapClaims{})
-		if err != nil {
-			return fmt.Errorf("failed to parse token: %w", err)
+		return c.token, c.host + c.endpoint, nil
+	}
+
+	if !c.tokenExpiry.IsZero() && time.Now().Add(tokenRefreshMargin).After(c.tokenExpiry) {
+		tflog.SubsystemInfo(ctx, logSubsystem, "Token is about to expire, refreshing it", map[string]any{"token_expiry": c.tokenExpiry.String()})
+		if err := c.authenticate(ctx); err != nil {
+			return "", "", fmt.Errorf("failed to refresh token: %w", err)
 		}
+	}
 
-		tokenMapClaims := token.Claims.(jwt.MapClaims)
-		if appID, ok := tokenMapClaims[appIDClaim]; ok {
-			switch appID.(string) {
-			case wafAppID:
-				c.SetEndpoint(wafPath)
-			case policyAppID:
-				c.SetEndpoint(policyPath)
-			}
+	return c.token, c.host + c.endpoint, nil
+}
+
+// reauthenticate fetches a new token after rejectedToken was rejected by the server
+// if another request already replaced rejectedToken, the current token is used as is
+func (c *Client) reauthenticate(ctx context.Context, rejectedToken string) error {
+	c.authLock.Lock()
+	defer c.authLock.Unlock()
+
+	if !c.canReauthenticate() {
+		if err := c.checkTokenExpiry(); err != nil {
+			return err
 		}
 
-		break
+		return fmt.Errorf("token was rejected and no credentials are configured to re-authenticate")
+	}
+
+	if c.token != rejectedToken {
+		return nil
+	}
 
+	if err := c.authenticate(ctx); err != nil {
+		return fmt.Errorf("failed to re-authenticate: %w", err)
 	}
 
 	return nil
 }
 
+// canReauthenticate reports whether the client has credentials to fetch a new token
+// the caller must hold authLock
+func (c *Client) canReauthenticate() bool {
+	return c.clientID != "" && c.accessKey != ""
+}
+
+// attemptError is the error of a single attempt of a GraphQL request, classified for the retry loop
+type attemptError struct {
+	err          error
+	transient    bool
+	unauthorized bool
+	retryAfter   time.Duration
+}
+
+func (e *attemptError) Error() string {
+	return e.err.Error()
+}
+
+func (e *attemptError) Unwrap() error {
+	return e.err
+}
+
+// MakeGraphQLRequest sends a GraphQL request and returns the field responseKey of the response data
+// transient failures are retried according to the retry policy of the client, and a request that was rejected
+// due to an expired token is replayed once after re-authenticating
 func (c *Client) MakeGraphQLRequest(ctx context.Context, gql, responseKey string, vars ...map[string]any) (any, error) {
 	variables := make(map[string]any)
 	for _, varMap := range vars {
@@ -206,125 +349,190 @@ func (c *Client) MakeGraphQLRequest(ctx context.Context, gql, responseKey string
 		return nil, fmt.Errorf("failed to marshal GraphQL request. Error: %+v", err)
 	}
 
-	timeout := clientTimeout * time.Second
-	client := http.Client{
-		Timeout: timeout,
+	ctx = utils.NewLogContext(ctx)
+	ctx = tflog.SubsystemSetField(ctx, logSubsystem, "operation", operationName(gql))
+	if c.sessionGuard != nil {
+		if err := c.sessionGuard.beforeWrite(ctx, c, gql, responseKey); err != nil {
+			return nil, err
+		}
+	}
+
+	recordUndo := c.prepareUndo(ctx, gql, responseKey, variables)
+	if c.autoPublisher != nil {
+		defer c.autoPublisher.beginWrite(gql, responseKey)()
 	}
 
-	var res *http.Response
-	for retryCount := 1; retryCount <= maxNumOfRetries; retryCount++ {
-		httpRequest, err := http.NewRequest(http.MethodPost, c.host+c.endpoint, bytes.NewReader(graphQlRequestBytes))
+	tflog.SubsystemTrace(ctx, logSubsystem, "GraphQL request variables", map[string]any{"variables": utils.RedactForLog(variables)})
+
+	policy := c.GetRetryPolicy()
+	reauthenticated := false
+	start := time.Now()
+	for attempt := 1; ; attempt++ {
+		token, requestURL, err := c.validToken(ctx)
 		if err != nil {
 			return nil, err
 		}
 
-		bearer := "Bearer " + c.token
-		httpRequest.Header.Set("Content-Type", "application/json")
-		httpRequest.Header.Set("Authorization", bearer)
+		attemptCtx := tflog.SubsystemSetField(ctx, logSubsystem, "retry_count", attempt-1)
+		ret, err := c.doGraphQLRequest(attemptCtx, requestURL, graphQlRequestBytes, token, responseKey)
+		if err == nil {
+			tflog.SubsystemDebug(attemptCtx, logSubsystem, "GraphQL request succeeded", map[string]any{
+				"total_duration_ms": time.Since(start).Milliseconds(),
+			})
 
-		res, err = client.Do(httpRequest)
-		if err != nil {
-			if retryCount == maxNumOfRetries {
-				return nil, err
+			if c.sessionGuard != nil {
+				c.sessionGuard.afterWrite(gql, responseKey, variables, ret)
 			}
 
-			res.Body.Close()
-			fmt.Println("[WARN] GraphQL request failed with error " + err.Error() + ", retrying...")
-			time.Sleep(time.Second * 2 * time.Duration(retryCount))
-			continue
+			recordUndo(ret)
+			return ret, nil
+		}
+
+		var attemptErr *attemptError
+		if !errors.As(err, &attemptErr) {
+			tflog.SubsystemError(attemptCtx, logSubsystem, "GraphQL request failed", map[string]any{"error": err.Error()})
+			return nil, err
 		}
 
-		switch res.StatusCode {
-		case http.StatusOK:
-			defer res.Body.Close()
-			graphResponse, err := parseGraphQLResponse(res)
-			if err != nil {
+		if attemptErr.unauthorized && !reauthenticated {
+			tflog.SubsystemInfo(attemptCtx, logSubsystem, "GraphQL request was rejected as unauthorized, re-authenticating and replaying it")
+			if err := c.reauthenticate(ctx, token); err != nil {
 				return nil, err
 			}
 
-			if len(graphResponse.Errors) > 0 {
-				if retryCount == maxNumOfRetries {
-					return nil, fmt.Errorf("GraphQL response contains errors: %s, ReferenceID: %s. Body: %+v", graphResponse.Errors[0].Message, graphResponse.Errors[0].Extensions.ReferenceID, graphResponse.Data)
-				}
+			// replay the request once with the new token, the replay does not count as an attempt
+			reauthenticated = true
+			attempt--
+			continue
+		}
 
-				res.Body.Close()
-				fmt.Printf("[WARN] GraphQL request failed with status %s and errors %+v, retrying...\n", res.Status, graphResponse.Errors)
-				time.Sleep(time.Second * 2 * time.Duration(retryCount))
-				continue
-			}
+		if !attemptErr.transient || attempt >= policy.MaxAttempts {
+			tflog.SubsystemError(attemptCtx, logSubsystem, "GraphQL request failed", map[string]any{
+				"error":             attemptErr.err.Error(),
+				"transient":         attemptErr.transient,
+				"total_duration_ms": time.Since(start).Milliseconds(),
+			})
 
-			graphResponseMap, ok := graphResponse.Data.(map[string]any)
-			if !ok {
-				err := fmt.Errorf("invalid response, should be of type map[string]any but got %#v", graphResponse.Data)
-				if retryCount == maxNumOfRetries {
-					return nil, err
-				}
-
-				res.Body.Close()
-				fmt.Printf("[WARN] GraphQL request failed with error %v, retrying...\n", err)
-				time.Sleep(time.Second * 2 * time.Duration(retryCount))
-				continue
-			}
+			return nil, attemptErr.err
+		}
 
-			ret, ok := graphResponseMap[responseKey]
-			if !ok {
-				err := fmt.Errorf("invalid response field: %s. Full response: %+v", responseKey, graphResponseMap)
-				if retryCount == maxNumOfRetries {
-					return nil, err
-				}
+		tflog.SubsystemWarn(attemptCtx, logSubsystem, "GraphQL request failed, retrying", map[string]any{
+			"error":       attemptErr.err.Error(),
+			"retry_after": attemptErr.retryAfter.String(),
+		})
 
-				res.Body.Close()
-				fmt.Printf("[WARN] GraphQL request failed with error %v, retrying...\n", err)
-				time.Sleep(time.Second * 2 * time.Duration(retryCount))
-				continue
+		if waitErr := policy.waitBeforeRetry(ctx, attempt, attemptErr.retryAfter); waitErr != nil {
+			return nil, fmt.Errorf("GraphQL request canceled while waiting to retry: %w. Last error: %w", waitErr, attemptErr.err)
+		}
+	}
+}
 
-			}
+// doGraphQLRequest makes a single attempt of a GraphQL request
+// errors that may be worth retrying are returned as *attemptError
+func (c *Client) doGraphQLRequest(ctx context.Context, requestURL string, body []byte, token, responseKey string) (any, error) {
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, requestURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
 
-			if ret == nil {
-				// We need to retry only if it's expected to find the resource
-				// This is only used for test, because we ensure a resource is destroyed after a test using Read.
-				if v := ctx.Value(utils.ExpectResourceNotFound); v != nil && !v.(bool) {
-					err := fmt.Errorf("%s - ReferenceID: %s", ErrorNotFound.Error(), getReferenceIDFromHeaders(res.Header))
-					if retryCount == maxNumOfRetries {
-						return nil, err
-					}
-
-					res.Body.Close()
-					fmt.Printf("[WARN] GraphQL request failed with error %v, retrying...\n", err)
-					time.Sleep(time.Second * 2 * time.Duration(retryCount))
-					continue
-				}
-			}
+	bearer := "Bearer " + token
+	httpRequest.Header.Set("Content-Type", "application/json")
+	httpRequest.Header.Set("Authorization", bearer)
 
-			return ret, nil
-		case http.StatusTooManyRequests, http.StatusGatewayTimeout, http.StatusBadGateway, http.StatusRequestTimeout:
-			res.Body.Close()
-			fmt.Println("[WARN] GraphQL request failed with status " + res.Status + ", retrying...")
-			time.Sleep(time.Second * 2 * time.Duration(retryCount))
-			continue
-		default:
-			defer res.Body.Close()
-			graphResponse, err := parseGraphQLResponse(res)
-			if err != nil {
-				return nil, err
-			}
+	start := time.Now()
+	res, err := c.httpClient.Do(httpRequest)
+	if err != nil {
+		tflog.SubsystemDebug(ctx, logSubsystem, "GraphQL request could not be sent", map[string]any{
+			"duration_ms": time.Since(start).Milliseconds(),
+			"error":       err.Error(),
+		})
 
-			if len(graphResponse.Errors) > 0 {
-				if retryCount == maxNumOfRetries {
-					return nil, fmt.Errorf("GraphQL response contains errors: %s, ReferenceID: %s. Body: %+v", graphResponse.Errors[0].Message, graphResponse.Errors[0].Extensions.ReferenceID, graphResponse.Data)
-				}
+		return nil, &attemptError{err: err, transient: isTransientRequestError(ctx, err)}
+	}
 
-				res.Body.Close()
-				fmt.Printf("[WARN] GraphQL request failed with status %s and errors %+v, retrying...\n", res.Status, graphResponse.Errors)
-				time.Sleep(time.Second * 2 * time.Duration(retryCount))
-				continue
-			}
+	defer res.Body.Close()
+
+	tflog.SubsystemDebug(ctx, logSubsystem, "GraphQL response received", map[string]any{
+		"status_code":  res.StatusCode,
+		"duration_ms":  time.Since(start).Milliseconds(),
+		"reference_id": getReferenceIDFromHeaders(res.Header),
+	})
+
+	if res.StatusCode == http.StatusUnauthorized {
+		return nil, &attemptError{
+			err: &UnauthorizedError{&APIError{
+				Code:        ErrorCodeUnauthorized,
+				Message:     "GraphQL request failed with status " + res.Status,
+				ReferenceID: getReferenceIDFromHeaders(res.Header),
+			}},
+			unauthorized: true,
+		}
+	}
+
+	if isTransientStatusCode(res.StatusCode) {
+		return nil, &attemptError{
+			err:        fmt.Errorf("GraphQL request failed with status %s. ReferenceID: %s", res.Status, getReferenceIDFromHeaders(res.Header)),
+			transient:  true,
+			retryAfter: parseRetryAfter(res.Header),
+		}
+	}
+
+	graphResponse, err := parseGraphQLResponse(res)
+	if err != nil {
+		return nil, err
+	}
+
+	tflog.SubsystemTrace(ctx, logSubsystem, "GraphQL response body", map[string]any{
+		"data":   utils.RedactForLog(graphResponse.Data),
+		"errors": utils.RedactForLog(graphResponse.Errors),
+	})
+
+	if len(graphResponse.Errors) > 0 {
+		graphErr := newGraphError(graphResponse.Errors[0], res.Header)
+		var unauthorizedErr *UnauthorizedError
+		return nil, &attemptError{
+			err:          graphErr,
+			transient:    isTransientGraphError(graphErr),
+			unauthorized: errors.As(graphErr, &unauthorizedErr),
+		}
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Non-OK http code (%d) - Body: %+v", res.StatusCode, graphResponse.Data)
+	}
+
+	graphResponseMap, ok := graphResponse.Data.(map[string]any)
+	if !ok {
+		return nil, &attemptError{
+			err:       fmt.Errorf("invalid response, should be of type map[string]any but got %#v", graphResponse.Data),
+			transient: true,
+		}
+	}
+
+	ret, ok := graphResponseMap[responseKey]
+	if !ok {
+		return nil, &attemptError{
+			err:       fmt.Errorf("invalid response field: %s. Full response: %+v", responseKey, graphResponseMap),
+			transient: true,
+		}
+	}
 
-			return nil, fmt.Errorf("Non-OK http code (%d) - Body: %+v", res.StatusCode, graphResponse.Data)
+	if ret == nil {
+		// We need to retry only if it's expected to find the resource
+		// This is only used for test, because we ensure a resource is destroyed after a test using Read.
+		if v := ctx.Value(utils.ExpectResourceNotFound); v != nil && !v.(bool) {
+			return nil, &attemptError{
+				err: &NotFoundError{&APIError{
+					Code:        ErrorCodeNotFound,
+					Message:     ErrorNotFound.Error(),
+					ReferenceID: getReferenceIDFromHeaders(res.Header),
+				}},
+				transient: true,
+			}
 		}
 	}
 
-	return nil, nil
+	return ret, nil
 }
 
 func getReferenceIDFromHeaders(headers http.Header) string {
@@ -354,12 +562,50 @@ func parseGraphQLResponse(response *http.Response) (*GraphResponse, error) {
 	return graphResponsePointer, nil
 }
 
-func (c *Client) PublishChanges() (bool, error) {
+// PublishChanges waits for the changes made so far to be published when auto publish is enabled
+// and returns whether they were published. Otherwise the changes are left in the session and it returns true
+// the publish is bounded by the deadline of ctx, and the deadlines of the other operations waiting for it
+func (c *Client) PublishChanges(ctx context.Context) (bool, error) {
+	if c.autoPublisher == nil {
+		return true, nil
+	}
+
+	if err := c.autoPublisher.wait(ctx, c); err != nil {
+		return false, fmt.Errorf("failed to publish changes: %w", err)
+	}
+
 	return true, nil
 }
 
-func (c *Client) DiscardChanges() (bool, error) {
-	discardChanges, err := c.MakeGraphQLRequest(context.Background(), `
+// DiscardChanges undoes the changes of a failed operation and returns whether all of them were undone
+// the writes made with a context of WithRollbackJournal are rolled back in reverse order, so the changes of other
+// resources and users are left in the session. When discard on failure is set, all changes in the session are discarded
+func (c *Client) DiscardChanges(ctx context.Context) (bool, error) {
+	// the operation may have failed because its timeout passed, its changes are undone regardless
+	ctx = context.WithoutCancel(ctx)
+	if !c.discardOnFailure {
+		return c.rollback(ctx)
+	}
+
+	return c.discardSession(ctx)
+}
+
+// discardSession discards all changes in the session
+// when a session conflict policy is set, changes are not discarded if the session has changes of other users
+func (c *Client) discardSession(ctx context.Context) (bool, error) {
+	if c.sessionGuard != nil {
+		conflicts, err := c.sessionGuard.conflicts(utils.NewLogContext(ctx), c)
+		if err != nil {
+			return false, fmt.Errorf("failed discarding changes: %w", err)
+		}
+
+		if len(conflicts) > 0 {
+			return false, fmt.Errorf("changes were not discarded, the session has unpublished changes of other users that would be discarded with them: %s",
+				changesString(conflicts))
+		}
+	}
+
+	discardChanges, err := c.MakeGraphQLRequest(ctx, `
 	mutation discardChanges{
 		discardChanges
 	}`, "discardChanges")
@@ -373,5 +619,9 @@ func (c *Client) DiscardChanges() (bool, error) {
 		return false, fmt.Errorf("failed discarding changes: got invalid response %#v", discardChanges)
 	}
 
+	if isDiscarded && c.autoPublisher != nil {
+		c.autoPublisher.discarded()
+	}
+
 	return isDiscarded, err
 }
@@ -0,0 +1,185 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// testTokenCount makes each test token unique, even when tokens with the same expiry are issued in the same second
+var testTokenCount atomic.Int32
+
+// newTestToken returns a token that expires at the given time, or never if expiry is zero
+func newTestToken(t *testing.T, expiry time.Time) string {
+	t.Helper()
+
+	claims := jwt.MapClaims{appIDClaim: policyAppID, "jti": testTokenCount.Add(1)}
+	if !expiry.IsZero() {
+		claims["exp"] = expiry.Unix()
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("test"))
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+
+	return token
+}
+
+// newAuthServer returns a server that issues a token that expires in an hour for every authentication request,
+// and the number of authentication requests it served
+func newAuthServer(t *testing.T) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var count atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"success": true, "data": {"token": "` + newTestToken(t, time.Now().Add(time.Hour)) + `"}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &count
+}
+
+func TestValidTokenRefresh(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		expiresIn   time.Duration
+		credentials bool
+		wantRefresh bool
+		wantErr     error
+	}{
+		{name: "valid", expiresIn: time.Hour, credentials: true},
+		{name: "no expiry", credentials: true},
+		{name: "just outside the margin", expiresIn: tokenRefreshMargin + time.Minute, credentials: true},
+		{name: "within the margin", expiresIn: tokenRefreshMargin - time.Minute, credentials: true, wantRefresh: true},
+		{name: "expired", expiresIn: -time.Minute, credentials: true, wantRefresh: true},
+		{name: "within the margin without credentials", expiresIn: tokenRefreshMargin - time.Minute},
+		{name: "expired without credentials", expiresIn: -time.Minute, wantErr: ErrTokenExpired},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			server, authCount := newAuthServer(t)
+			c := NewClient()
+			c.SetHost(server.URL)
+			if tc.credentials {
+				c.clientID, c.accessKey = "client-id", "access-key"
+			}
+
+			var expiry time.Time
+			if tc.expiresIn != 0 {
+				expiry = time.Now().Add(tc.expiresIn)
+			}
+
+			token := newTestToken(t, expiry)
+			if err := c.applyToken(token); err != nil {
+				t.Fatalf("failed to apply token: %v", err)
+			}
+
+			got, _, err := c.validToken(context.Background())
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("validToken() error = %v, want %v", err, tc.wantErr)
+			}
+
+			if tc.wantErr != nil {
+				return
+			}
+
+			if refreshed := got != token; refreshed != tc.wantRefresh {
+				t.Errorf("token refreshed = %t, want %t", refreshed, tc.wantRefresh)
+			}
+
+			wantAuthCount := int32(0)
+			if tc.wantRefresh {
+				wantAuthCount = 1
+			}
+
+			if authCount.Load() != wantAuthCount {
+				t.Errorf("authenticated %d times, want %d", authCount.Load(), wantAuthCount)
+			}
+		})
+	}
+}
+
+func TestReauthenticateOnce(t *testing.T) {
+	server, authCount := newAuthServer(t)
+	c := NewClient()
+	c.SetHost(server.URL)
+	c.clientID, c.accessKey = "client-id", "access-key"
+	rejected := newTestToken(t, time.Now().Add(time.Hour))
+	if err := c.applyToken(rejected); err != nil {
+		t.Fatalf("failed to apply token: %v", err)
+	}
+
+	// concurrent requests that were rejected with the same token re-authenticate once
+	for i := 0; i < 3; i++ {
+		if err := c.reauthenticate(context.Background(), rejected); err != nil {
+			t.Fatalf("reauthenticate() error = %v", err)
+		}
+	}
+
+	if authCount.Load() != 1 || c.GetToken() == rejected {
+		t.Fatalf("expected a single re-authentication, got %d and token replaced = %t", authCount.Load(), c.GetToken() != rejected)
+	}
+}
+
+func TestRequestTokenNotString(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"success": true, "data": {"token": 42}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	c := NewClient()
+	c.SetHost(server.URL)
+	var authErr *AuthError
+	if err := c.InfinityPortalAuthentication(context.Background(), "client-id", "access-key"); !errors.As(err, &authErr) {
+		t.Fatalf("expected an AuthError for a token that is not a string, got %v", err)
+	}
+}
+
+func TestRefreshWithConcurrentRequests(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == policyPath {
+			requests.Add(1)
+			w.Write([]byte(`{"data": {"getTask": {"id": "task-id"}}}`))
+			return
+		}
+
+		// every token expires within the refresh margin, so each request refreshes it and switches the endpoint
+		w.Write([]byte(`{"success": true, "data": {"token": "` + newTestToken(t, time.Now().Add(tokenRefreshMargin/2)) + `"}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	c := NewClient()
+	c.SetHost(server.URL)
+	if err := c.InfinityPortalAuthentication(context.Background(), "client-id", "access-key"); err != nil {
+		t.Fatal(err)
+	}
+
+	errs := make(chan error, 10)
+	for i := 0; i < cap(errs); i++ {
+		go func() {
+			_, err := c.MakeGraphQLRequest(context.Background(), `{ getTask(id: "task-id") { id } }`, "getTask")
+			errs <- err
+		}()
+	}
+
+	for i := 0; i < cap(errs); i++ {
+		if err := <-errs; err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+	}
+
+	if requests.Load() != int32(cap(errs)) || c.GetEndpoint() != policyPath {
+		t.Fatalf("expected %d requests to the endpoint of the token, got %d and endpoint %s", cap(errs), requests.Load(), c.GetEndpoint())
+	}
+}
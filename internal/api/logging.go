@@ -0,0 +1,22 @@
+package api
+
+import (
+	"regexp"
+
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/utils"
+)
+
+// logSubsystem is the tflog subsystem of all API traffic
+const logSubsystem = utils.LogSubsystem
+
+// operationNameRegex extracts the operation name from a GraphQL query, e.g. getWebApplicationAsset
+var operationNameRegex = regexp.MustCompile(`^\s*(?:query|mutation)\s+(\w+)`)
+
+// operationName returns the name of the operation of a GraphQL query, or unknown if it has no name
+func operationName(gql string) string {
+	if match := operationNameRegex.FindStringSubmatch(gql); match != nil {
+		return match[1]
+	}
+
+	return "unknown"
+}
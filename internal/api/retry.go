@@ -0,0 +1,149 @@
+package api
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultRetryMaxAttempts = 4
+	DefaultRetryBaseBackoff = 2 * time.Second
+	DefaultRetryMaxBackoff  = 30 * time.Second
+)
+
+// RetryPolicy controls how failed requests to the API are retried
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts of a request, including the first one
+	MaxAttempts int
+
+	// BaseBackoff is the wait before the first retry, each following retry waits twice as long
+	BaseBackoff time.Duration
+
+	// MaxBackoff caps the wait between attempts
+	MaxBackoff time.Duration
+
+	// Jitter randomizes each wait between half and all of its backoff
+	// so concurrent requests don't retry at the same time
+	Jitter bool
+}
+
+// DefaultRetryPolicy returns the retry policy used when the provider configuration has no retry block
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: DefaultRetryMaxAttempts,
+		BaseBackoff: DefaultRetryBaseBackoff,
+		MaxBackoff:  DefaultRetryMaxBackoff,
+		Jitter:      true,
+	}
+}
+
+// backoff returns the wait before the given retry, retries are counted from 1
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	wait := p.BaseBackoff
+	for i := 1; i < retry && wait < p.MaxBackoff; i++ {
+		wait *= 2
+	}
+
+	if p.MaxBackoff > 0 && wait > p.MaxBackoff {
+		wait = p.MaxBackoff
+	}
+
+	if p.Jitter && wait > 0 {
+		wait = wait/2 + time.Duration(rand.Int63n(int64(wait/2)+1))
+	}
+
+	return wait
+}
+
+// waitBeforeRetry waits before the given retry, or for retryAfter if the server asked for a longer wait
+// returns the error of ctx if it is done before the wait is over
+func (p RetryPolicy) waitBeforeRetry(ctx context.Context, retry int, retryAfter time.Duration) error {
+	wait := p.backoff(retry)
+	if retryAfter > wait {
+		wait = retryAfter
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// parseRetryAfter returns the wait requested by the Retry-After header of a response
+// the header holds either a number of seconds or an HTTP date
+func parseRetryAfter(headers http.Header) time.Duration {
+	value := strings.TrimSpace(headers.Get("Retry-After"))
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(date); wait > 0 {
+			return wait
+		}
+	}
+
+	return 0
+}
+
+// isTransientStatusCode reports whether a request that failed with the given status code may succeed if retried
+func isTransientStatusCode(statusCode int) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests, http.StatusRequestTimeout, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+
+	return false
+}
+
+// isTransientRequestError reports whether a request that failed to be sent may succeed if retried
+// network errors are retried, cancellation and certificate errors are not
+func isTransientRequestError(ctx context.Context, err error) bool {
+	if ctx.Err() != nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var certErr *tls.CertificateVerificationError
+	return !errors.As(err, &certErr)
+}
+
+// transientGraphErrorCodes are the codes of GraphQL errors of a server that failed to handle the request
+var transientGraphErrorCodes = map[string]struct{}{
+	"INTERNAL_SERVER_ERROR": {},
+	"SERVICE_UNAVAILABLE":   {},
+	"TIMEOUT":               {},
+}
+
+// isTransientGraphError reports whether a request that failed with the given GraphQL error may succeed if retried
+// only a conflict with another session and errors of the server are retried, any other error is about the request
+// itself or the objects it refers to, e.g. a duplicate name, and will fail the same way if retried
+func isTransientGraphError(err error) bool {
+	var sessionConflictErr *SessionConflictError
+	if errors.As(err, &sessionConflictErr) {
+		return true
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		_, ok := transientGraphErrorCodes[apiErr.Code]
+		return ok
+	}
+
+	return false
+}
@@ -0,0 +1,166 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	policy := RetryPolicy{BaseBackoff: time.Second, MaxBackoff: 10 * time.Second}
+	for _, tc := range []struct {
+		retry int
+		want  time.Duration
+	}{
+		{retry: 1, want: time.Second},
+		{retry: 2, want: 2 * time.Second},
+		{retry: 3, want: 4 * time.Second},
+		{retry: 4, want: 8 * time.Second},
+		{retry: 5, want: 10 * time.Second},
+		{retry: 100, want: 10 * time.Second},
+	} {
+		if got := policy.backoff(tc.retry); got != tc.want {
+			t.Errorf("backoff(%d) = %v, want %v", tc.retry, got, tc.want)
+		}
+	}
+}
+
+func TestBackoffJitter(t *testing.T) {
+	policy := RetryPolicy{BaseBackoff: time.Second, MaxBackoff: 10 * time.Second, Jitter: true}
+	for _, tc := range []struct {
+		retry    int
+		min, max time.Duration
+	}{
+		{retry: 1, min: 500 * time.Millisecond, max: time.Second},
+		{retry: 3, min: 2 * time.Second, max: 4 * time.Second},
+		{retry: 10, min: 5 * time.Second, max: 10 * time.Second},
+	} {
+		seen := map[time.Duration]bool{}
+		for i := 0; i < 100; i++ {
+			got := policy.backoff(tc.retry)
+			if got < tc.min || got > tc.max {
+				t.Fatalf("backoff(%d) = %v, want between %v and %v", tc.retry, got, tc.min, tc.max)
+			}
+
+			seen[got] = true
+		}
+
+		if len(seen) < 2 {
+			t.Errorf("backoff(%d) is not randomized", tc.retry)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		value    string
+		min, max time.Duration
+	}{
+		{name: "missing"},
+		{name: "seconds", value: "3", min: 3 * time.Second, max: 3 * time.Second},
+		{name: "padded seconds", value: " 7 ", min: 7 * time.Second, max: 7 * time.Second},
+		{name: "zero seconds", value: "0"},
+		{name: "negative seconds", value: "-5"},
+		{name: "http date", value: time.Now().Add(time.Minute).UTC().Format(http.TimeFormat), min: 58 * time.Second, max: time.Minute},
+		{name: "past http date", value: time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat)},
+		{name: "invalid", value: "soon"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			headers := http.Header{}
+			if tc.value != "" {
+				headers.Set("Retry-After", tc.value)
+			}
+
+			if got := parseRetryAfter(headers); got < tc.min || got > tc.max {
+				t.Errorf("parseRetryAfter(%q) = %v, want between %v and %v", tc.value, got, tc.min, tc.max)
+			}
+		})
+	}
+}
+
+func TestWaitBeforeRetry(t *testing.T) {
+	policy := RetryPolicy{BaseBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+	for _, tc := range []struct {
+		name       string
+		retryAfter time.Duration
+		cancel     bool
+		timeout    time.Duration
+		minWait    time.Duration
+		wantErr    error
+	}{
+		{name: "backoff"},
+		{name: "longer retry after", retryAfter: 50 * time.Millisecond, minWait: 50 * time.Millisecond},
+		{name: "canceled", retryAfter: time.Hour, cancel: true, wantErr: context.Canceled},
+		{name: "deadline", retryAfter: time.Hour, timeout: 10 * time.Millisecond, wantErr: context.DeadlineExceeded},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tc.timeout > 0 {
+				ctx, cancel = context.WithTimeout(ctx, tc.timeout)
+				defer cancel()
+			}
+
+			if tc.cancel {
+				cancel()
+			}
+
+			start := time.Now()
+			err := policy.waitBeforeRetry(ctx, 1, tc.retryAfter)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("waitBeforeRetry() error = %v, want %v", err, tc.wantErr)
+			}
+
+			if elapsed := time.Since(start); elapsed < tc.minWait || elapsed > time.Second {
+				t.Errorf("waitBeforeRetry() waited %v, want at least %v", elapsed, tc.minWait)
+			}
+		})
+	}
+}
+
+func TestIsTransientStatusCode(t *testing.T) {
+	for statusCode, want := range map[int]bool{
+		http.StatusTooManyRequests:     true,
+		http.StatusRequestTimeout:      true,
+		http.StatusBadGateway:          true,
+		http.StatusServiceUnavailable:  true,
+		http.StatusGatewayTimeout:      true,
+		http.StatusBadRequest:          false,
+		http.StatusUnauthorized:        false,
+		http.StatusNotFound:            false,
+		http.StatusInternalServerError: false,
+	} {
+		if got := isTransientStatusCode(statusCode); got != want {
+			t.Errorf("isTransientStatusCode(%d) = %t, want %t", statusCode, got, want)
+		}
+	}
+}
+
+func TestIsTransientGraphError(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		graphError GraphError
+		want       bool
+	}{
+		{name: "already exists", graphError: GraphError{Message: "Asset with name my-asset already exists"}, want: false},
+		{name: "not found", graphError: GraphError{Message: "gone", Extensions: &GraphErrorExtension{Code: "NOT_FOUND"}}, want: false},
+		{name: "missing dependency", graphError: GraphError{Message: "Trigger with ID 1234 does not exist"}, want: false},
+		{name: "in use", graphError: GraphError{Message: "Object can't be deleted since it is pointed from other objects"}, want: false},
+		{name: "invalid input", graphError: GraphError{Message: "bad", Extensions: &GraphErrorExtension{Code: "BAD_USER_INPUT"}}, want: false},
+		{name: "unauthorized", graphError: GraphError{Message: "denied", Extensions: &GraphErrorExtension{Code: "FORBIDDEN"}}, want: false},
+		{name: "unknown code", graphError: GraphError{Message: "Name must be unique", Extensions: &GraphErrorExtension{Code: "SOMETHING_ELSE"}}, want: false},
+		{name: "unknown message", graphError: GraphError{Message: "Name must be unique"}, want: false},
+		{name: "session conflict", graphError: GraphError{Message: "locked", Extensions: &GraphErrorExtension{Code: "SESSION_LOCKED"}}, want: true},
+		{name: "server error", graphError: GraphError{Message: "oops", Extensions: &GraphErrorExtension{Code: "INTERNAL_SERVER_ERROR"}}, want: true},
+		{name: "server timeout", graphError: GraphError{Message: "timed out", Extensions: &GraphErrorExtension{Code: "timeout"}}, want: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isTransientGraphError(newGraphError(tc.graphError, nil)); got != tc.want {
+				t.Errorf("isTransientGraphError() = %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,326 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/utils"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// objectKinds are the suffixes of the object type names, each kind has a delete<Kind> mutation
+var objectKinds = []string{"Asset", "Profile", "Practice", "Behavior", "Trigger"}
+
+// referenceListFields are the fields of update inputs that hold IDs of other objects, so an add is undone
+// by removing the same IDs and the other way around
+var referenceListFields = map[string]struct{}{
+	"Profiles":  {},
+	"Behaviors": {},
+	"Triggers":  {},
+}
+
+type rollbackJournalKey struct{}
+
+// rollbackJournal records the inverse of each write made by a resource operation, see WithRollbackJournal
+type rollbackJournal struct {
+	mu   sync.Mutex
+	undo []undoOperation
+}
+
+// undoOperation is the inverse of a single write
+type undoOperation struct {
+	// description identifies the write that is undone, e.g. updateLogTrigger 1234
+	description string
+
+	// gql, responseKey and variables are the request that undoes the write, empty if nothing of it can be undone
+	gql         string
+	responseKey string
+	variables   map[string]any
+
+	// irreversible are the parts of the write that can't be undone, e.g. deleted objects or changed nested settings
+	irreversible []string
+}
+
+// WithRollbackJournal returns a context that records the inverse of the writes made with it,
+// so DiscardChanges undoes only those writes instead of discarding all the changes in the session
+func WithRollbackJournal(ctx context.Context) context.Context {
+	return context.WithValue(ctx, rollbackJournalKey{}, &rollbackJournal{})
+}
+
+func rollbackJournalFromContext(ctx context.Context) *rollbackJournal {
+	journal, _ := ctx.Value(rollbackJournalKey{}).(*rollbackJournal)
+	return journal
+}
+
+func (j *rollbackJournal) record(op undoOperation) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.undo = append(j.undo, op)
+}
+
+// take returns the recorded operations and clears the journal, so they are undone once
+func (j *rollbackJournal) take() []undoOperation {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	ret := j.undo
+	j.undo = nil
+	return ret
+}
+
+// SetDiscardOnFailure makes DiscardChanges discard all the changes in the session, as before targeted rollback,
+// instead of undoing only the writes of the failed operation
+func (c *Client) SetDiscardOnFailure(discard bool) {
+	c.discardOnFailure = discard
+}
+
+// prepareUndo prepares the inverse of a write made with a context that has a rollback journal
+// and returns a function that records it in the journal once the write succeeded with the given response
+// the previous values of updated fields are read before the write, since the update response doesn't hold them
+func (c *Client) prepareUndo(ctx context.Context, gql, responseKey string, variables map[string]any) func(response any) {
+	journal := rollbackJournalFromContext(ctx)
+	if journal == nil || !isWrite(gql, responseKey) {
+		return func(any) {}
+	}
+
+	switch {
+	case responseKey == "updatePracticeTriggers":
+		op := undoOperation{description: fmt.Sprintf("%s %v", responseKey, variables["practiceId"]), gql: gql, responseKey: responseKey, variables: map[string]any{}}
+		for k, v := range variables {
+			op.variables[k] = v
+		}
+
+		op.variables["addTriggers"], op.variables["removeTriggers"] = variables["removeTriggers"], variables["addTriggers"]
+		return func(any) { journal.record(op) }
+	case strings.HasPrefix(responseKey, "new"):
+		return func(response any) { journal.record(undoCreate(responseKey, response)) }
+	case strings.HasPrefix(responseKey, "update"):
+		op := c.undoUpdate(ctx, gql, responseKey, variables)
+		return func(any) { journal.record(op) }
+	case strings.HasPrefix(responseKey, "delete"):
+		description := responseKey
+		if match := changedIDRegex.FindStringSubmatch(gql); match != nil {
+			description += " " + match[1]
+		}
+
+		return func(any) {
+			journal.record(undoOperation{description: description, irreversible: []string{"deleted objects can't be restored"}})
+		}
+	}
+
+	return func(any) {
+		journal.record(undoOperation{description: responseKey, irreversible: []string{"the operation has no known inverse"}})
+	}
+}
+
+// undoCreate returns the inverse of a new<Type> mutation, which deletes the created object
+func undoCreate(responseKey string, response any) undoOperation {
+	typeName := strings.TrimPrefix(responseKey, "new")
+	op := undoOperation{description: responseKey}
+	id := ""
+	if fields, ok := response.(map[string]any); ok {
+		id, _ = fields["id"].(string)
+	}
+
+	kind := objectKind(typeName)
+	if id == "" || kind == "" {
+		op.irreversible = []string{"the ID or kind of the created object is unknown"}
+		return op
+	}
+
+	op.description += " " + id
+	op.gql = fmt.Sprintf(`mutation { delete%s(id: "%s") }`, kind, id)
+	op.responseKey = "delete" + kind
+	return op
+}
+
+// undoUpdate returns the inverse of an update<Type> mutation, which sends the same mutation with an input
+// that restores the previous values of the updated fields and removes the added references
+func (c *Client) undoUpdate(ctx context.Context, gql, responseKey string, variables map[string]any) undoOperation {
+	typeName := strings.TrimPrefix(responseKey, "update")
+	id, _ := variables["id"].(string)
+	op := undoOperation{description: strings.TrimSpace(responseKey + " " + id)}
+
+	inputKey, input := "", map[string]any{}
+	for k, v := range variables {
+		if strings.HasSuffix(k, "Input") {
+			inputKey = k
+			if err := convertJSON(v, &input); err != nil {
+				op.irreversible = []string{fmt.Sprintf("failed to read the input: %v", err)}
+				return op
+			}
+		}
+	}
+
+	if id == "" || inputKey == "" {
+		op.irreversible = []string{"the ID or input of the updated object is unknown"}
+		return op
+	}
+
+	inverse := map[string]any{}
+	var scalars []string
+	for k, v := range input {
+		switch value := v.(type) {
+		case nil:
+		case string, float64, bool:
+			scalars = append(scalars, k)
+		case []any:
+			if len(value) == 0 {
+				continue
+			}
+
+			if inverseKey, ids, ok := undoListChange(k, value); ok {
+				inverse[inverseKey] = ids
+				continue
+			}
+
+			op.irreversible = append(op.irreversible, k)
+		default:
+			op.irreversible = append(op.irreversible, k)
+		}
+	}
+
+	if len(scalars) > 0 {
+		previous, err := c.readFields(ctx, typeName, id, scalars)
+		if err != nil {
+			tflog.SubsystemWarn(ctx, logSubsystem, "Failed to read the fields before the update, they can't be rolled back", map[string]any{"error": err.Error()})
+			op.irreversible = append(op.irreversible, scalars...)
+		} else {
+			for _, k := range scalars {
+				inverse[k] = previous[k]
+			}
+		}
+	}
+
+	sort.Strings(op.irreversible)
+	if len(inverse) == 0 {
+		return op
+	}
+
+	op.gql, op.responseKey = gql, responseKey
+	op.variables = map[string]any{}
+	for k, v := range variables {
+		op.variables[k] = v
+	}
+
+	op.variables[inputKey] = inverse
+	return op
+}
+
+// undoListChange returns the inverse of adding or removing elements of a list field of an update input,
+// which is possible for references to other objects, e.g. addProfiles is undone by removeProfiles with the same IDs
+func undoListChange(key string, elems []any) (string, []any, bool) {
+	for _, prefix := range []string{"add", "remove"} {
+		field, found := strings.CutPrefix(key, prefix)
+		if !found {
+			continue
+		}
+
+		inversePrefix := "remove"
+		if prefix == "remove" {
+			inversePrefix = "add"
+		}
+
+		if _, ok := referenceListFields[field]; ok {
+			return inversePrefix + field, elems, true
+		}
+
+		// practices are added with their modes and removed by ID, so only adding them can be undone
+		if field == "Practices" && prefix == "add" {
+			ids := make([]any, 0, len(elems))
+			for _, elem := range elems {
+				wrapper, _ := elem.(map[string]any)
+				practiceID, _ := wrapper["practiceId"].(string)
+				if practiceID == "" {
+					return "", nil, false
+				}
+
+				ids = append(ids, practiceID)
+			}
+
+			return "removePractices", ids, true
+		}
+	}
+
+	return "", nil, false
+}
+
+// readFields reads the current values of the given fields of an object
+func (c *Client) readFields(ctx context.Context, typeName, id string, fields []string) (map[string]any, error) {
+	res, err := c.MakeGraphQLRequest(ctx, fmt.Sprintf(`{ get%s(id: "%s") { %s } }`, typeName, id, strings.Join(fields, " ")), "get"+typeName)
+	if err != nil {
+		return nil, err
+	}
+
+	ret, ok := res.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("got invalid response %#v", res)
+	}
+
+	return ret, nil
+}
+
+// rollback undoes the writes recorded in the journal of ctx in reverse order
+// returns an error listing the writes that failed to be undone and the changes that can't be undone
+func (c *Client) rollback(ctx context.Context) (bool, error) {
+	journal := rollbackJournalFromContext(ctx)
+	if journal == nil {
+		return true, nil
+	}
+
+	ops := journal.take()
+	if len(ops) == 0 {
+		return true, nil
+	}
+
+	// the writes that undo are not recorded themselves
+	ctx = context.WithValue(ctx, rollbackJournalKey{}, (*rollbackJournal)(nil))
+	tflog.SubsystemInfo(utils.NewLogContext(ctx), logSubsystem, "Rolling back the changes of the failed operation", map[string]any{"writes": len(ops)})
+
+	var errs []error
+	var irreversible []string
+	for i := len(ops) - 1; i >= 0; i-- {
+		op := ops[i]
+		if op.gql != "" {
+			if _, err := c.MakeGraphQLRequest(ctx, op.gql, op.responseKey, op.variables); err != nil {
+				errs = append(errs, fmt.Errorf("failed to roll back %s: %w", op.description, err))
+			}
+		}
+
+		for _, part := range op.irreversible {
+			irreversible = append(irreversible, fmt.Sprintf("%s (%s)", op.description, part))
+		}
+	}
+
+	if len(irreversible) > 0 {
+		errs = append(errs, fmt.Errorf("changes could not be rolled back and are left in the session, publish or discard them: %s",
+			strings.Join(irreversible, ", ")))
+	}
+
+	return len(errs) == 0, errors.Join(errs...)
+}
+
+// objectKind returns the kind of an object type name, e.g. Asset for WebApplicationAsset
+func objectKind(typeName string) string {
+	for _, kind := range objectKinds {
+		if strings.HasSuffix(typeName, kind) {
+			return kind
+		}
+	}
+
+	return ""
+}
+
+// convertJSON converts v to out through its JSON encoding, e.g. an input struct to a map of its fields
+func convertJSON(v any, out any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, out)
+}
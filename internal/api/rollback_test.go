@@ -0,0 +1,64 @@
+package api_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/api"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/apitest"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/provider"
+	publishenforce "github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources/publish-enforce"
+)
+
+func TestTargetedRollback(t *testing.T) {
+	server := apitest.NewServer(t)
+	c := server.Client(t)
+	c.EnableAutoPublish(api.AutoPublishOptions{
+		Publish: func(ctx context.Context, c *api.Client) error {
+			_, err := publishenforce.ExecutePublish(ctx, c, nil)
+			return err
+		},
+		QuietPeriod: 50 * time.Millisecond,
+	})
+
+	r := provider.Provider().ResourcesMap["inext_log_trigger"]
+	state := apitest.Apply(t, r, nil, map[string]any{"name": "trigger", "verbosity": "Standard"}, c)
+	otherID := server.AddObject("LogTrigger", map[string]any{"name": "portal-edit"})
+
+	// a failed create deletes only the created object
+	server.FailNextPublish("invalid trigger")
+	if _, err := apitest.TryApply(r, nil, map[string]any{"name": "failed"}, c); err == nil || !strings.Contains(err.Error(), "invalid trigger") {
+		t.Fatalf("expected a publish validation error, got %v", err)
+	}
+
+	if !strings.Contains(strings.Join(server.Operations(), ","), "deleteTrigger") {
+		t.Fatalf("the created trigger was not deleted: %v", server.Operations())
+	}
+
+	for _, obj := range server.Objects() {
+		if obj.Name() == "failed" {
+			t.Fatal("the created trigger was not rolled back")
+		}
+	}
+
+	if server.Object(otherID) == nil || server.Object(state.ID) == nil {
+		t.Fatal("changes of other resources or users were discarded")
+	}
+
+	// a failed update restores the previous values
+	server.FailNextPublish("invalid trigger")
+	if _, err := apitest.TryApply(r, state, map[string]any{"name": "renamed", "verbosity": "Extended"}, c); err == nil {
+		t.Fatal("expected a publish validation error")
+	}
+
+	trigger := server.Object(state.ID)
+	if trigger.Name() != "trigger" || trigger.Fields["verbosity"] != "Standard" {
+		t.Fatalf("the update was not rolled back: %#v", trigger.Fields)
+	}
+
+	if server.Object(otherID) == nil {
+		t.Fatal("the change of the other user was discarded")
+	}
+}
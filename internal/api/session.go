@@ -0,0 +1,260 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/utils"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Policies of handling unpublished changes of other users in the session, see SessionConflictOptions
+const (
+	// SessionConflictPolicyFail fails the operation
+	SessionConflictPolicyFail = "fail"
+
+	// SessionConflictPolicyWait waits for the changes to be published or discarded, up to the timeout
+	SessionConflictPolicyWait = "wait"
+
+	// SessionConflictPolicyProceed doesn't check the session, the changes of other users are published with the changes
+	// of the provider. No session guard is installed for it
+	SessionConflictPolicyProceed = "proceed"
+
+	DefaultSessionConflictPolicy       = SessionConflictPolicyProceed
+	DefaultSessionConflictTimeout      = 10 * time.Minute
+	DefaultSessionConflictPollInterval = 10 * time.Second
+)
+
+// SessionConflictPolicies are the valid values of SessionConflictOptions.Policy
+var SessionConflictPolicies = []string{SessionConflictPolicyFail, SessionConflictPolicyWait, SessionConflictPolicyProceed}
+
+// changedIDRegex matches the inline ID arguments of a mutation, e.g. deleteProfile(id: "...")
+var changedIDRegex = regexp.MustCompile(`\b(?:id|containerId)\s*:\s*"([^"]+)"`)
+
+// PendingChange is an object with changes that were not published yet
+type PendingChange struct {
+	ID     string
+	Name   string
+	Kind   string
+	Status string
+
+	// ModifiedBy is the user that last changed the object, empty if the API didn't report it
+	ModifiedBy string
+}
+
+func (p PendingChange) String() string {
+	return fmt.Sprintf("%s %q (ID %s, %s)", p.Kind, p.Name, p.ID, p.Status)
+}
+
+// SessionConflictOptions configures the handling of unpublished changes of other users, e.g. edits made in the portal,
+// which would otherwise be published or discarded together with the changes of the provider
+type SessionConflictOptions struct {
+	// Policy is one of SessionConflictPolicies
+	Policy string
+
+	// Timeout is how long the wait policy waits for the changes of other users, DefaultSessionConflictTimeout if zero
+	Timeout time.Duration
+
+	// PollInterval is how often the wait policy checks for the changes of other users, DefaultSessionConflictPollInterval if zero
+	PollInterval time.Duration
+
+	// PendingChanges returns all objects with unpublished changes
+	PendingChanges func(ctx context.Context, c *Client) ([]PendingChange, error)
+}
+
+// sessionGuard checks for unpublished changes of other users before the first write and before publishing
+// changes of objects written by the client are its own and never conflict, whatever their modifiedBy is, since the API
+// doesn't document whether it holds the client ID or a user name. Changes last modified by the client ID the client
+// authenticated with are its own as well, so the changes of an earlier run with the same API key don't conflict
+type sessionGuard struct {
+	opts SessionConflictOptions
+
+	// checkLock serializes the check before the first write, so concurrent writes wait for it
+	checkLock sync.Mutex
+	checked   bool
+
+	mu sync.Mutex
+
+	// ownChanges are the IDs of the objects written by the client
+	ownChanges map[string]struct{}
+}
+
+// SetSessionConflictOptions enables checking for unpublished changes of other users before writing and publishing
+// it is not called for SessionConflictPolicyProceed, which doesn't check the session
+func (c *Client) SetSessionConflictOptions(opts SessionConflictOptions) {
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultSessionConflictTimeout
+	}
+
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = DefaultSessionConflictPollInterval
+	}
+
+	c.sessionGuard = &sessionGuard{opts: opts, ownChanges: map[string]struct{}{}}
+}
+
+// CheckSessionConflicts handles the unpublished changes of other users according to the session conflict policy
+// returns an *UnpublishedChangesError if the policy is fail, or wait and the changes were not published in time
+func (c *Client) CheckSessionConflicts(ctx context.Context) error {
+	if c.sessionGuard == nil {
+		return nil
+	}
+
+	return c.sessionGuard.check(utils.NewLogContext(ctx), c)
+}
+
+// beforeWrite checks for changes of other users before the first write of the client
+func (g *sessionGuard) beforeWrite(ctx context.Context, c *Client, gql, responseKey string) error {
+	if !isWrite(gql, responseKey) {
+		return nil
+	}
+
+	g.checkLock.Lock()
+	defer g.checkLock.Unlock()
+	if g.checked {
+		return nil
+	}
+
+	if err := g.check(ctx, c); err != nil {
+		return err
+	}
+
+	g.checked = true
+	return nil
+}
+
+// afterWrite records the objects changed by a write as changes of the client
+func (g *sessionGuard) afterWrite(gql, responseKey string, variables map[string]any, response any) {
+	if !isWrite(gql, responseKey) {
+		return
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for _, match := range changedIDRegex.FindAllStringSubmatch(gql, -1) {
+		g.ownChanges[match[1]] = struct{}{}
+	}
+
+	for _, key := range []string{"id", "containerId"} {
+		if id, ok := variables[key].(string); ok && id != "" {
+			g.ownChanges[id] = struct{}{}
+		}
+	}
+
+	if responseMap, ok := response.(map[string]any); ok {
+		if id, ok := responseMap["id"].(string); ok && id != "" {
+			g.ownChanges[id] = struct{}{}
+		}
+	}
+}
+
+// conflicts returns the pending changes that were not made by the client
+func (g *sessionGuard) conflicts(ctx context.Context, c *Client) ([]PendingChange, error) {
+	pending, err := g.opts.PendingChanges(ctx, c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for unpublished changes: %w", err)
+	}
+
+	identity := c.identity()
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	var ret []PendingChange
+	for _, change := range pending {
+		if !g.isOwn(change, identity) {
+			ret = append(ret, change)
+		}
+	}
+
+	sort.Slice(ret, func(i, j int) bool { return ret[i].ID < ret[j].ID })
+	return ret, nil
+}
+
+// isOwn reports whether a pending change was made by the client with the given identity
+// the caller must hold g.mu
+func (g *sessionGuard) isOwn(change PendingChange, identity string) bool {
+	if _, ok := g.ownChanges[change.ID]; ok {
+		return true
+	}
+
+	return identity != "" && change.ModifiedBy == identity
+}
+
+func (g *sessionGuard) check(ctx context.Context, c *Client) error {
+	deadline := time.Now().Add(g.opts.Timeout)
+	for {
+		conflicts, err := g.conflicts(ctx, c)
+		if err != nil {
+			return err
+		}
+
+		if len(conflicts) == 0 {
+			return nil
+		}
+
+		switch g.opts.Policy {
+		case SessionConflictPolicyWait:
+			if time.Now().Add(g.opts.PollInterval).Before(deadline) {
+				tflog.SubsystemInfo(ctx, logSubsystem, "The session has unpublished changes of other users, waiting for them to be published", map[string]any{
+					"changes": changesString(conflicts),
+				})
+
+				timer := time.NewTimer(g.opts.PollInterval)
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+					return ctx.Err()
+				case <-timer.C:
+				}
+
+				continue
+			}
+
+			return &UnpublishedChangesError{Changes: conflicts, TimedOut: g.opts.Timeout}
+		default:
+			return &UnpublishedChangesError{Changes: conflicts}
+		}
+	}
+}
+
+// isWrite reports whether a GraphQL request changes objects in the session
+func isWrite(gql, responseKey string) bool {
+	if _, ok := sessionOperations[responseKey]; ok {
+		return false
+	}
+
+	return strings.HasPrefix(strings.TrimSpace(gql), "mutation")
+}
+
+// UnpublishedChangesError is returned when the session has unpublished changes of other users
+// and the session conflict policy does not allow to proceed
+type UnpublishedChangesError struct {
+	// Changes are the objects with unpublished changes of other users
+	Changes []PendingChange
+
+	// TimedOut is set if the wait policy waited for the changes to be published and the timeout passed
+	TimedOut time.Duration
+}
+
+func (e *UnpublishedChangesError) Error() string {
+	reason := ""
+	if e.TimedOut > 0 {
+		reason = fmt.Sprintf(" and they were not published within %s", e.TimedOut)
+	}
+
+	return fmt.Sprintf("the session has unpublished changes of other users%s: %s. "+
+		"Publish or discard them in the portal, or set session_conflict_policy to proceed to include them", reason, changesString(e.Changes))
+}
+
+func changesString(changes []PendingChange) string {
+	ret := make([]string, len(changes))
+	for i, change := range changes {
+		ret[i] = change.String()
+	}
+
+	return strings.Join(ret, ", ")
+}
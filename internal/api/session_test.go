@@ -0,0 +1,145 @@
+package api_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/api"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/apitest"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources/objects"
+	publishenforce "github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources/publish-enforce"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/pkg/regions"
+)
+
+func TestSessionConflictPolicy(t *testing.T) {
+	newClient := func(t *testing.T, server *apitest.Server, policy string) *api.Client {
+		c := server.Client(t)
+		c.SetSessionConflictOptions(api.SessionConflictOptions{
+			Policy:         policy,
+			Timeout:        time.Second,
+			PollInterval:   50 * time.Millisecond,
+			PendingChanges: objects.GetPendingChanges,
+		})
+
+		return c
+	}
+
+	r := resources.ResourceLogTrigger()
+	config := map[string]any{"name": "trigger"}
+
+	t.Run("fail", func(t *testing.T) {
+		server := apitest.NewServer(t)
+		c := newClient(t, server, api.SessionConflictPolicyFail)
+		server.AddObject("LogTrigger", map[string]any{"name": "portal-edit"})
+
+		var changesErr *api.UnpublishedChangesError
+		if err := c.CheckSessionConflicts(context.Background()); !errors.As(err, &changesErr) || len(changesErr.Changes) != 1 {
+			t.Fatalf("expected an unpublished changes error, got %v", err)
+		}
+
+		if _, err := apitest.TryApply(r, nil, config, c); err == nil || !strings.Contains(err.Error(), `unpublished changes of other users: Trigger "portal-edit"`) {
+			t.Fatalf("expected an unpublished changes error, got %v", err)
+		}
+
+		if len(server.Objects()) != 1 {
+			t.Fatal("the trigger was created or the change of the other user was discarded")
+		}
+	})
+
+	t.Run("wait", func(t *testing.T) {
+		server := apitest.NewServer(t)
+		c := newClient(t, server, api.SessionConflictPolicyWait)
+		otherID := server.AddObject("LogTrigger", map[string]any{"name": "portal-edit"})
+		go func() {
+			time.Sleep(200 * time.Millisecond)
+			server.RemoveObject(otherID)
+		}()
+
+		state := apitest.Apply(t, r, nil, config, c)
+
+		// changes of the client itself don't conflict when publishing
+		if _, err := publishenforce.ExecutePublish(context.Background(), c, nil); err != nil {
+			t.Fatal(err)
+		}
+
+		if server.PublishedObject(state.ID) == nil {
+			t.Fatal("trigger was not published")
+		}
+	})
+
+	t.Run("wait timeout", func(t *testing.T) {
+		server := apitest.NewServer(t)
+		c := newClient(t, server, api.SessionConflictPolicyWait)
+		server.AddObject("LogTrigger", map[string]any{"name": "portal-edit"})
+		if _, err := apitest.TryApply(r, nil, config, c); err == nil || !strings.Contains(err.Error(), "they were not published within 1s") {
+			t.Fatalf("expected a session conflict timeout, got %v", err)
+		}
+	})
+
+	t.Run("discard", func(t *testing.T) {
+		server := apitest.NewServer(t)
+		c := newClient(t, server, api.SessionConflictPolicyFail)
+		c.SetDiscardOnFailure(true)
+		apitest.Apply(t, r, nil, config, c)
+		server.AddObject("LogTrigger", map[string]any{"name": "portal-edit"})
+		if _, err := c.DiscardChanges(context.Background()); err == nil || !strings.Contains(err.Error(), "portal-edit") {
+			t.Fatalf("expected discard to be refused, got %v", err)
+		}
+
+		if len(server.Objects()) != 2 {
+			t.Fatal("changes were discarded")
+		}
+	})
+	t.Run("own changes of an earlier run", func(t *testing.T) {
+		server := apitest.NewServer(t)
+		apitest.Apply(t, r, nil, map[string]any{"name": "earlier-run"}, server.Client(t))
+
+		// the client authenticates with the same client ID as the earlier run
+		c := newClient(t, server, api.SessionConflictPolicyFail)
+		if err := c.CheckSessionConflicts(context.Background()); err != nil {
+			t.Fatalf("changes of the same client ID conflict: %v", err)
+		}
+	})
+
+	t.Run("own object with another modifiedBy", func(t *testing.T) {
+		server := apitest.NewServer(t)
+		c := newClient(t, server, api.SessionConflictPolicyFail)
+		state := apitest.Apply(t, r, nil, config, c)
+
+		// the object is the client's own, also if the API reports a user name rather than the client ID in modifiedBy
+		server.UpdateObject(state.ID, map[string]any{"name": "renamed"})
+		if err := c.CheckSessionConflicts(context.Background()); err != nil {
+			t.Fatalf("the object written by the client conflicts: %v", err)
+		}
+
+		server.AddObject("LogTrigger", map[string]any{"name": "portal-edit"})
+		var changesErr *api.UnpublishedChangesError
+		if err := c.CheckSessionConflicts(context.Background()); !errors.As(err, &changesErr) || len(changesErr.Changes) != 1 ||
+			changesErr.Changes[0].ModifiedBy != apitest.PortalUser {
+			t.Fatalf("expected the change of the other user to conflict, got %v", err)
+		}
+	})
+
+	t.Run("token only", func(t *testing.T) {
+		server := apitest.NewServer(t)
+		apitest.Apply(t, r, nil, map[string]any{"name": "earlier-run"}, server.Client(t))
+
+		// without a client ID the identity is unknown, so only the objects written by the client are its own
+		c := api.NewClient()
+		c.SetHost(server.URL)
+		c.PinEndpoint(regions.PolicyGraphQLPath)
+		if err := c.CachedTokenAuthentication(context.Background(), server.Client(t).GetToken(), "", ""); err != nil {
+			t.Fatal(err)
+		}
+
+		c.SetSessionConflictOptions(api.SessionConflictOptions{Policy: api.SessionConflictPolicyFail, PendingChanges: objects.GetPendingChanges})
+		var changesErr *api.UnpublishedChangesError
+		if err := c.CheckSessionConflicts(context.Background()); !errors.As(err, &changesErr) || len(changesErr.Changes) != 1 {
+			t.Fatalf("expected the changes of the earlier run to conflict, got %v", err)
+		}
+	})
+}
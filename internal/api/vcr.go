@@ -0,0 +1,397 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/utils"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// VCRModeEnvVar enables recording or replaying of API traffic, see VCRModeRecord and VCRModeReplay
+	VCRModeEnvVar = "INEXT_VCR_MODE"
+
+	// VCRCassetteEnvVar is the path of the cassette file traffic is recorded to or replayed from
+	// it is read on every request, so tests can switch cassettes without creating a new client
+	VCRCassetteEnvVar = "INEXT_VCR_CASSETTE"
+
+	// VCRModeRecord sends requests to the API and writes the sanitized GraphQL interactions to the cassette
+	VCRModeRecord = "record"
+
+	// VCRModeReplay answers requests from the cassette without sending them to the API
+	VCRModeReplay = "replay"
+
+	// vcrTokenTTL is the lifetime of the tokens issued while replaying
+	vcrTokenTTL = time.Hour
+)
+
+var (
+	// anonymousOperationRegex extracts the root field and its inline arguments from a query without an operation name
+	// e.g. getLogTrigger(id: "...")
+	anonymousOperationRegex = regexp.MustCompile(`^\s*(?:query|mutation)?\s*\{\s*(\w+\s*(?:\([^)]*\))?)`)
+
+	whitespaceRegex = regexp.MustCompile(`\s+`)
+
+	// cassettes are shared by all clients in the process, by path, so a cassette is recorded to by all
+	// the provider instances that a test configures
+	cassettes = struct {
+		sync.Mutex
+		byPath map[string]*cassette
+	}{byPath: map[string]*cassette{}}
+)
+
+// VCRMode returns the record/replay mode set in the environment, empty if API traffic is neither recorded nor replayed
+func VCRMode() string {
+	switch mode := os.Getenv(VCRModeEnvVar); mode {
+	case VCRModeRecord, VCRModeReplay:
+		return mode
+	}
+
+	return ""
+}
+
+// interaction is a single recorded GraphQL request and its response
+type interaction struct {
+	Operation  string `json:"operation"`
+	Variables  any    `json:"variables,omitempty"`
+	StatusCode int    `json:"status_code"`
+
+	// Response is the JSON response body, Body is the response body if it is not JSON
+	Response any    `json:"response,omitempty"`
+	Body     string `json:"body,omitempty"`
+
+	used bool
+}
+
+type cassette struct {
+	Interactions []*interaction `json:"interactions"`
+
+	path string
+	mu   sync.Mutex
+}
+
+// vcrTransport records GraphQL traffic to a cassette or replays it from one
+// authentication requests are never recorded, while replaying they are answered with a new unsigned token
+type vcrTransport struct {
+	mode string
+	base http.RoundTripper
+}
+
+func newVCRTransport(mode string, base http.RoundTripper) *vcrTransport {
+	return &vcrTransport{mode: mode, base: base}
+}
+
+func (t *vcrTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	isAuth := strings.HasPrefix(req.Header.Get("Content-Type"), "application/x-www-form-urlencoded")
+	path := os.Getenv(VCRCassetteEnvVar)
+	if t.mode == VCRModeRecord {
+		if isAuth || path == "" {
+			return t.base.RoundTrip(req)
+		}
+
+		return t.record(req, path)
+	}
+
+	if isAuth {
+		return replayAuthentication(req)
+	}
+
+	if path == "" {
+		return nil, fmt.Errorf("%s is %s but %s is not set", VCRModeEnvVar, VCRModeReplay, VCRCassetteEnvVar)
+	}
+
+	return t.replay(req, path)
+}
+
+func (t *vcrTransport) record(req *http.Request, path string) (*http.Response, error) {
+	operation, variables, err := readGraphQLRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	recorded := &interaction{Operation: operation, Variables: variables, StatusCode: res.StatusCode}
+	var response any
+	if err := json.Unmarshal(body, &response); err == nil {
+		recorded.Response = sanitizeResponse(response)
+	} else {
+		recorded.Body = scrubSecrets(string(body))
+	}
+
+	c, err := loadCassette(path, VCRModeRecord)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.add(recorded); err != nil {
+		return nil, fmt.Errorf("failed to write cassette %s: %w", path, err)
+	}
+
+	return res, nil
+}
+
+func (t *vcrTransport) replay(req *http.Request, path string) (*http.Response, error) {
+	operation, variables, err := readGraphQLRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := loadCassette(path, VCRModeReplay)
+	if err != nil {
+		return nil, err
+	}
+
+	recorded := c.match(operation, variables)
+	if recorded == nil {
+		return nil, fmt.Errorf("no interaction for operation %s with variables %s in cassette %s, record it again with %s=%s",
+			operation, canonicalJSON(variables), path, VCRModeEnvVar, VCRModeRecord)
+	}
+
+	body := []byte(recorded.Body)
+	header := http.Header{}
+	if recorded.Response != nil {
+		if body, err = json.Marshal(recorded.Response); err != nil {
+			return nil, err
+		}
+
+		header.Set("Content-Type", "application/json")
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// replayAuthentication answers an authentication request with an unsigned token of the Infinity Policy application
+func replayAuthentication(req *http.Request) (*http.Response, error) {
+	expiry := time.Now().Add(vcrTokenTTL)
+	token, err := jwt.NewWithClaims(jwt.SigningMethodNone, jwt.MapClaims{
+		appIDClaim: policyAppID,
+		"exp":      expiry.Unix(),
+	}).SignedString(jwt.UnsafeAllowNoneSignatureType)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(map[string]any{
+		"success": true,
+		"data":    map[string]any{"token": token, "expires": expiry.UTC().Format(time.RFC3339)},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": {"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// readGraphQLRequest returns the operation and the sanitized variables of a GraphQL request, leaving its body intact
+func readGraphQLRequest(req *http.Request) (string, any, error) {
+	if req.Body == nil {
+		return "", nil, errors.New("GraphQL request has no body")
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return "", nil, err
+	}
+
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	var request GraphQLRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		return "", nil, fmt.Errorf("failed to parse GraphQL request: %w", err)
+	}
+
+	var variables any
+	if len(request.Variables) > 0 {
+		variables = sanitize(request.Variables)
+	}
+
+	return vcrOperation(request.Query), variables, nil
+}
+
+// vcrOperation returns the name of the operation of a query, or for a query without a name
+// its root field with the inline arguments, since those identify the object the query is for
+func vcrOperation(gql string) string {
+	if name := operationName(gql); name != "unknown" {
+		return name
+	}
+
+	if match := anonymousOperationRegex.FindStringSubmatch(gql); match != nil {
+		return whitespaceRegex.ReplaceAllString(match[1], " ")
+	}
+
+	return whitespaceRegex.ReplaceAllString(strings.TrimSpace(gql), " ")
+}
+
+// sanitize returns a copy of v with the values of sensitive fields and secrets in strings redacted
+func sanitize(v any) any {
+	return scrubStrings(utils.RedactForLog(v))
+}
+
+// sanitizeResponse is sanitize for a GraphQL response, whose data field holds the response itself and isn't redacted
+func sanitizeResponse(response any) any {
+	fields, ok := response.(map[string]any)
+	if !ok {
+		return sanitize(response)
+	}
+
+	ret := make(map[string]any, len(fields))
+	for k, v := range fields {
+		ret[k] = sanitize(v)
+	}
+
+	return ret
+}
+
+func scrubStrings(v any) any {
+	switch value := v.(type) {
+	case map[string]any:
+		for k, fieldValue := range value {
+			value[k] = scrubStrings(fieldValue)
+		}
+	case []any:
+		for i, elem := range value {
+			value[i] = scrubStrings(elem)
+		}
+	case string:
+		return scrubSecrets(value)
+	}
+
+	return v
+}
+
+func scrubSecrets(s string) string {
+	for _, re := range utils.SecretRegexes {
+		s = re.ReplaceAllString(s, utils.RedactedValue)
+	}
+
+	return s
+}
+
+// canonicalJSON returns the JSON encoding of v with sorted keys, used to compare variables
+func canonicalJSON(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+
+	var generic any
+	if err := json.Unmarshal(b, &generic); err != nil {
+		return string(b)
+	}
+
+	b, _ = json.Marshal(generic)
+	return string(b)
+}
+
+// loadCassette returns the cassette of path, reading it on first use when replaying
+// and starting it empty on first use when recording
+func loadCassette(path, mode string) (*cassette, error) {
+	cassettes.Lock()
+	defer cassettes.Unlock()
+
+	if c, ok := cassettes.byPath[path]; ok {
+		return c, nil
+	}
+
+	c := &cassette{path: path, Interactions: []*interaction{}}
+	if mode == VCRModeReplay {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read cassette: %w", err)
+		}
+
+		if err := json.Unmarshal(b, c); err != nil {
+			return nil, fmt.Errorf("failed to parse cassette %s: %w", path, err)
+		}
+	}
+
+	cassettes.byPath[path] = c
+	return c, nil
+}
+
+// add appends an interaction to the cassette and writes the cassette to its file
+func (c *cassette) add(recorded *interaction) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.Interactions = append(c.Interactions, recorded)
+	b, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(c.path, append(b, '\n'), 0o644)
+}
+
+// match returns the first unused interaction with the given operation and variables
+// interactions are replayed in the order they were recorded, so repeated reads of an object return its state at each point.
+// once all matching interactions were used, the last one is returned again
+func (c *cassette) match(operation string, variables any) *interaction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := canonicalJSON(variables)
+	var last *interaction
+	for _, recorded := range c.Interactions {
+		if recorded.Operation != operation || canonicalJSON(recorded.Variables) != key {
+			continue
+		}
+
+		if !recorded.used {
+			recorded.used = true
+			return recorded
+		}
+
+		last = recorded
+	}
+
+	return last
+}
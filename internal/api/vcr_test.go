@@ -0,0 +1,114 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/acctest"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/api"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/apitest"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/pkg/regions"
+)
+
+func TestVCRRecordReplay(t *testing.T) {
+	cassettePath := filepath.Join(t.TempDir(), "cassette.json")
+	t.Setenv(api.VCRCassetteEnvVar, cassettePath)
+
+	// record the lifecycle of a profile against the fake
+	t.Setenv(api.VCRModeEnvVar, api.VCRModeRecord)
+	server := apitest.NewServer(t)
+	recordClient := newVCRClient(t, server.URL)
+	r := resources.ResourceKubernetesProfile()
+	config := map[string]any{"name": "profile", "profile_sub_type": "AppSecDefault"}
+	state := apitest.Apply(t, r, nil, config, recordClient)
+	recordedToken := state.Attributes["authentication_token"]
+	if recordedToken == "" {
+		t.Fatal("missing authentication token after create")
+	}
+
+	apitest.Destroy(t, r, state, recordClient)
+
+	cassette, err := os.ReadFile(cassettePath)
+	if err != nil {
+		t.Fatalf("cassette was not written: %v", err)
+	}
+
+	if strings.Contains(string(cassette), recordedToken) || strings.Contains(string(cassette), "Bearer") {
+		t.Fatalf("cassette holds a secret: %s", cassette)
+	}
+
+	if err := acctest.CheckCassette(cassettePath); err != nil {
+		t.Fatal(err)
+	}
+
+	// the check finds a token that was not redacted
+	leakedPath := filepath.Join(t.TempDir(), "leaked.json")
+	leaked := strings.Replace(string(cassette), `"token": "\u003credacted\u003e"`, `"token": "plain"`, 1)
+	if leaked == string(cassette) {
+		t.Fatalf("the cassette has no redacted authentication token: %s", cassette)
+	}
+
+	if err := os.WriteFile(leakedPath, []byte(leaked), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := acctest.CheckCassette(leakedPath); err == nil || !strings.Contains(err.Error(), "sensitive value") {
+		t.Fatalf("expected the unredacted token to be found, got %v", err)
+	}
+
+	var recorded struct {
+		Interactions []struct {
+			Operation string `json:"operation"`
+		} `json:"interactions"`
+	}
+
+	if err := json.Unmarshal(cassette, &recorded); err != nil {
+		t.Fatalf("failed to parse cassette: %v", err)
+	}
+
+	if len(recorded.Interactions) == 0 || recorded.Interactions[0].Operation != "newKubernetesProfile" {
+		t.Fatalf("unexpected interactions: %+v", recorded.Interactions)
+	}
+
+	// replay the same lifecycle with the fake closed, every request must be answered from the cassette
+	server.Close()
+	t.Setenv(api.VCRModeEnvVar, api.VCRModeReplay)
+	replayClient := newVCRClient(t, server.URL)
+	state = apitest.Apply(t, r, nil, config, replayClient)
+	if state.ID == "" || state.Attributes["name"] != "profile" {
+		t.Fatalf("unexpected state after replayed create: %#v", state.Attributes)
+	}
+
+	apitest.Destroy(t, r, state, replayClient)
+
+	config["name"] = "other"
+	if _, err := apitest.TryApply(r, nil, config, replayClient); err == nil || !strings.Contains(err.Error(), "no interaction for operation newKubernetesProfile") {
+		t.Fatalf("expected an unrecorded interaction error, got %v", err)
+	}
+}
+
+// newVCRClient returns a client of the fake that records or replays according to the environment
+func newVCRClient(t *testing.T, host string) *api.Client {
+	t.Helper()
+
+	httpClient, err := api.NewHTTPClient(api.HTTPClientOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := api.NewClient()
+	c.SetHTTPClient(httpClient)
+	c.SetHost(host)
+	c.PinEndpoint(regions.PolicyGraphQLPath)
+	c.SetRetryPolicy(api.RetryPolicy{MaxAttempts: 1})
+	if err := c.InfinityPortalAuthentication(context.Background(), apitest.DefaultClientID, apitest.DefaultAccessKey); err != nil {
+		t.Fatalf("failed to authenticate: %v", err)
+	}
+
+	return c
+}
@@ -0,0 +1,258 @@
+package apitest
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// operation is the root field of a GraphQL request and its resolved arguments
+type operation struct {
+	Field string
+	Args  map[string]any
+}
+
+// parseOperation parses the root field of a GraphQL request, resolving variables in its arguments
+// only the subset of GraphQL the provider sends is supported: a single root field, with arguments
+// that are literals, enums, lists, input objects or variables. Selection sets are ignored,
+// the fake responds with whole objects and clients only read the fields they know
+func parseOperation(query string, variables map[string]any) (operation, error) {
+	p := &parser{src: query, variables: variables}
+	p.skipSpace()
+
+	// skip the operation type, name and variable definitions, e.g. mutation newLogTrigger($input: LogTriggerInput)
+	if name := p.peekName(); name == "query" || name == "mutation" {
+		p.readName()
+		p.skipSpace()
+		if p.peekName() != "" {
+			p.readName()
+			p.skipSpace()
+		}
+
+		if p.peek() == '(' {
+			if err := p.skipBalanced('(', ')'); err != nil {
+				return operation{}, err
+			}
+		}
+	}
+
+	p.skipSpace()
+	if !p.consume('{') {
+		return operation{}, p.errorf("expected '{'")
+	}
+
+	p.skipSpace()
+	op := operation{Field: p.readName(), Args: map[string]any{}}
+	if op.Field == "" {
+		return operation{}, p.errorf("expected a root field")
+	}
+
+	p.skipSpace()
+	if !p.consume('(') {
+		return op, nil
+	}
+
+	for {
+		p.skipSpace()
+		if p.consume(')') {
+			return op, nil
+		}
+
+		name := p.readName()
+		if name == "" {
+			return operation{}, p.errorf("expected an argument name")
+		}
+
+		p.skipSpace()
+		if !p.consume(':') {
+			return operation{}, p.errorf("expected ':' after argument %s", name)
+		}
+
+		value, err := p.readValue()
+		if err != nil {
+			return operation{}, err
+		}
+
+		op.Args[name] = value
+	}
+}
+
+type parser struct {
+	src       string
+	pos       int
+	variables map[string]any
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	return fmt.Errorf("failed to parse GraphQL query at offset %d: %s", p.pos, fmt.Sprintf(format, args...))
+}
+
+func (p *parser) peek() byte {
+	if p.pos >= len(p.src) {
+		return 0
+	}
+
+	return p.src[p.pos]
+}
+
+func (p *parser) consume(c byte) bool {
+	if p.peek() != c {
+		return false
+	}
+
+	p.pos++
+	return true
+}
+
+// skipSpace skips whitespace, commas and comments, which are all insignificant in GraphQL
+func (p *parser) skipSpace() {
+	for p.pos < len(p.src) {
+		switch c := p.src[p.pos]; {
+		case c == '#':
+			for p.pos < len(p.src) && p.src[p.pos] != '\n' {
+				p.pos++
+			}
+		case c == ',' || unicode.IsSpace(rune(c)):
+			p.pos++
+		default:
+			return
+		}
+	}
+}
+
+func isNameChar(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+func (p *parser) peekName() string {
+	end := p.pos
+	for end < len(p.src) && isNameChar(p.src[end]) {
+		end++
+	}
+
+	return p.src[p.pos:end]
+}
+
+func (p *parser) readName() string {
+	name := p.peekName()
+	p.pos += len(name)
+	return name
+}
+
+func (p *parser) skipBalanced(open, close byte) error {
+	depth := 0
+	for ; p.pos < len(p.src); p.pos++ {
+		switch p.src[p.pos] {
+		case open:
+			depth++
+		case close:
+			depth--
+			if depth == 0 {
+				p.pos++
+				return nil
+			}
+		}
+	}
+
+	return p.errorf("unbalanced '%c'", open)
+}
+
+func (p *parser) readValue() (any, error) {
+	p.skipSpace()
+	switch c := p.peek(); {
+	case c == '$':
+		p.pos++
+		return p.variables[p.readName()], nil
+	case c == '"':
+		return p.readString()
+	case c == '[':
+		p.pos++
+		list := []any{}
+		for {
+			p.skipSpace()
+			if p.consume(']') {
+				return list, nil
+			}
+
+			value, err := p.readValue()
+			if err != nil {
+				return nil, err
+			}
+
+			list = append(list, value)
+		}
+	case c == '{':
+		p.pos++
+		object := map[string]any{}
+		for {
+			p.skipSpace()
+			if p.consume('}') {
+				return object, nil
+			}
+
+			name := p.readName()
+			p.skipSpace()
+			if name == "" || !p.consume(':') {
+				return nil, p.errorf("expected an input object field")
+			}
+
+			value, err := p.readValue()
+			if err != nil {
+				return nil, err
+			}
+
+			object[name] = value
+		}
+	case c == '-' || c >= '0' && c <= '9':
+		start := p.pos
+		p.pos++
+		for p.pos < len(p.src) && strings.IndexByte("0123456789.eE+-", p.src[p.pos]) >= 0 {
+			p.pos++
+		}
+
+		number, err := strconv.ParseFloat(p.src[start:p.pos], 64)
+		if err != nil {
+			return nil, p.errorf("invalid number %q", p.src[start:p.pos])
+		}
+
+		return number, nil
+	}
+
+	switch name := p.readName(); name {
+	case "":
+		return nil, p.errorf("expected a value")
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	default:
+		// enum values are resolved to their names, the same as they are sent in variables
+		return name, nil
+	}
+}
+
+func (p *parser) readString() (string, error) {
+	start := p.pos
+	p.pos++
+	for p.pos < len(p.src) {
+		switch p.src[p.pos] {
+		case '\\':
+			p.pos += 2
+		case '"':
+			p.pos++
+			value, err := strconv.Unquote(p.src[start:p.pos])
+			if err != nil {
+				return "", p.errorf("invalid string %s", p.src[start:p.pos])
+			}
+
+			return value, nil
+		default:
+			p.pos++
+		}
+	}
+
+	return "", p.errorf("unterminated string")
+}
@@ -0,0 +1,406 @@
+package apitest
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	taskStatusInProgress = "InProgress"
+	taskStatusSucceeded  = "Succeeded"
+)
+
+// builtinHandler returns the handler of the given root field, or nil if the fake doesn't implement it
+func builtinHandler(field string) Handler {
+	switch field {
+	case "getTask":
+		return handleGetTask
+	case "asyncPublishChanges":
+		return handleAsyncPublishChanges
+	case "publishChanges":
+		return handlePublishChanges
+	case "enforcePolicy":
+		return handleEnforcePolicy
+	case "discardChanges":
+		return handleDiscardChanges
+	case "updatePracticeTriggers":
+		return handleUpdatePracticeTriggers
+	case "triggerUsedBy":
+		return handleTriggerUsedBy
+	case "getAssets":
+		return func(s *Server, args map[string]any) (any, error) {
+			return map[string]any{"status": "Done", "assets": s.list(KindAsset)}, nil
+		}
+	}
+
+	for _, kind := range kinds {
+		switch field {
+		case "delete" + kind:
+			return deleteHandler(kind)
+		case strings.ToLower(kind) + "UsedBy":
+			return usedByHandler(kind)
+		case "get" + kind + "s":
+			return func(s *Server, args map[string]any) (any, error) {
+				return s.list(kind), nil
+			}
+		}
+	}
+
+	for _, typeName := range ObjectTypes {
+		switch field {
+		case "new" + typeName:
+			return newHandler(typeName)
+		case "update" + typeName:
+			return updateHandler(typeName)
+		case "get" + typeName:
+			return getHandler(typeName)
+		}
+	}
+
+	return nil
+}
+
+// inputArg returns the input object argument of a mutation, e.g. assetInput
+func inputArg(args map[string]any) map[string]any {
+	for _, value := range args {
+		if input, ok := value.(map[string]any); ok {
+			return input
+		}
+	}
+
+	return map[string]any{}
+}
+
+func idArg(args map[string]any) (string, error) {
+	id, ok := args["id"].(string)
+	if !ok || id == "" {
+		return "", &Error{Code: "BAD_USER_INPUT", Message: "missing argument id"}
+	}
+
+	return id, nil
+}
+
+func newHandler(typeName string) Handler {
+	return func(s *Server, args map[string]any) (any, error) {
+		input := inputArg(args)
+		if err := s.checkReferences(input); err != nil {
+			return nil, err
+		}
+
+		obj := s.newObject(typeName, input)
+		obj.Fields["modifiedBy"] = s.ClientID
+		s.objects[obj.ID] = obj
+		return s.view(obj), nil
+	}
+}
+
+func updateHandler(typeName string) Handler {
+	return func(s *Server, args map[string]any) (any, error) {
+		id, err := idArg(args)
+		if err != nil {
+			return nil, err
+		}
+
+		obj, ok := s.objects[id]
+		if !ok || obj.TypeName != typeName {
+			return nil, &Error{Code: "NOT_FOUND", Message: fmt.Sprintf("%s with ID %s does not exist", typeName, id)}
+		}
+
+		input := inputArg(args)
+		if err := s.checkReferences(input); err != nil {
+			return nil, err
+		}
+
+		s.applyPatch(obj.Fields, input)
+		obj.Fields["id"] = obj.ID
+		obj.Fields["modifiedBy"] = s.ClientID
+		if obj.Fields["objectStatus"] != "Created" {
+			obj.Fields["objectStatus"] = "Updated"
+		}
+
+		return true, nil
+	}
+}
+
+func getHandler(typeName string) Handler {
+	return func(s *Server, args map[string]any) (any, error) {
+		id, err := idArg(args)
+		if err != nil {
+			return nil, err
+		}
+
+		obj, ok := s.objects[id]
+		if !ok || obj.TypeName != typeName {
+			return nil, nil
+		}
+
+		return s.view(obj), nil
+	}
+}
+
+func deleteHandler(kind string) Handler {
+	return func(s *Server, args map[string]any) (any, error) {
+		id, err := idArg(args)
+		if err != nil {
+			return nil, err
+		}
+
+		obj, ok := s.objects[id]
+		if !ok || obj.Kind != kind {
+			return nil, &Error{Code: "NOT_FOUND", Message: fmt.Sprintf("%s with ID %s does not exist", kind, id)}
+		}
+
+		if len(s.referrers(id)) > 0 {
+			return nil, &Error{
+				Code:    "OBJECT_IN_USE",
+				Message: fmt.Sprintf("%s %s can't be deleted since it is pointed from other objects", kind, obj.Name()),
+			}
+		}
+
+		delete(s.objects, id)
+		return true, nil
+	}
+}
+
+// usedByHandler returns the objects that refer to a practice, behavior or profile
+func usedByHandler(kind string) Handler {
+	return func(s *Server, args map[string]any) (any, error) {
+		id, err := idArg(args)
+		if err != nil {
+			return nil, err
+		}
+
+		usedBy := []any{}
+		for _, referrer := range s.referrers(id) {
+			usedBy = append(usedBy, displayObject(referrer))
+		}
+
+		return usedBy, nil
+	}
+}
+
+// handleTriggerUsedBy returns the practices of assets that use a trigger, grouped by asset
+func handleTriggerUsedBy(s *Server, args map[string]any) (any, error) {
+	id, err := idArg(args)
+	if err != nil {
+		return nil, err
+	}
+
+	usedBy := []any{}
+	for _, referrer := range s.referrers(id) {
+		practices := []any{}
+		for _, practice := range asList(referrer.Fields["practices"]) {
+			if refersTo(practice, id) {
+				practices = append(practices, elementID(practice))
+			}
+		}
+
+		usedBy = append(usedBy, map[string]any{"container": referrer.ID, "practices": practices})
+	}
+
+	return usedBy, nil
+}
+
+func handleUpdatePracticeTriggers(s *Server, args map[string]any) (any, error) {
+	containerID, _ := args["containerId"].(string)
+	practiceID, _ := args["practiceId"].(string)
+	container, ok := s.objects[containerID]
+	if !ok {
+		return nil, &Error{Code: "NOT_FOUND", Message: fmt.Sprintf("Asset with ID %s does not exist", containerID)}
+	}
+
+	for _, practice := range asList(container.Fields["practices"]) {
+		practiceMap, ok := practice.(map[string]any)
+		if !ok || elementID(practice) != practiceID {
+			continue
+		}
+
+		s.applyPatch(practiceMap, map[string]any{
+			"addTriggers":    args["addTriggers"],
+			"removeTriggers": args["removeTriggers"],
+		})
+
+		return true, nil
+	}
+
+	return nil, &Error{Code: "NOT_FOUND", Message: fmt.Sprintf("Practice with ID %s is not connected to this Asset/Zone", practiceID)}
+}
+
+func handleAsyncPublishChanges(s *Server, args map[string]any) (any, error) {
+	t := s.publish()
+	return t.id, nil
+}
+
+// handlePublishChanges is the synchronous publish used by older versions of the CLI
+func handlePublishChanges(s *Server, args map[string]any) (any, error) {
+	t := s.publish()
+	errors := validationMessages(t.publishErrors)
+
+	return map[string]any{"isValid": len(errors) == 0, "errors": errors, "warnings": validationMessages(t.publishWarnings)}, nil
+}
+
+func handleEnforcePolicy(s *Server, args map[string]any) (any, error) {
+	t := &task{id: s.newID(), status: taskStatusSucceeded}
+	s.tasks[t.id] = t
+	return map[string]any{"id": t.id}, nil
+}
+
+func handleDiscardChanges(s *Server, args map[string]any) (any, error) {
+	s.objects = map[string]*Object{}
+	for id, obj := range s.published {
+		s.objects[id] = obj.clone()
+	}
+
+	return true, nil
+}
+
+func handleGetTask(s *Server, args map[string]any) (any, error) {
+	id, err := idArg(args)
+	if err != nil {
+		return nil, err
+	}
+
+	t, ok := s.tasks[id]
+	if !ok {
+		return nil, &Error{Code: "NOT_FOUND", Message: fmt.Sprintf("Task with ID %s does not exist", id)}
+	}
+
+	status := t.status
+	if t.polls < s.TaskPolls {
+		t.polls++
+		status = taskStatusInProgress
+	}
+
+	ret := map[string]any{"id": t.id, "status": status}
+	if t.isPublish {
+		errors := validationMessages(t.publishErrors)
+
+		ret["taskData"] = map[string]any{"publishData": map[string]any{
+			"isValid":  len(errors) == 0,
+			"errors":   errors,
+			"warnings": validationMessages(t.publishWarnings),
+		}}
+	}
+
+	return ret, nil
+}
+
+// validationMessages returns the messages as the errors or warnings of a publish
+func validationMessages(messages []string) []any {
+	ret := []any{}
+	for _, message := range messages {
+		ret = append(ret, map[string]any{"message": message})
+	}
+
+	return ret
+}
+
+// publish publishes the session, unless the publish was set to fail validation, and returns its task
+func (s *Server) publish() *task {
+	t := &task{id: s.newID(), status: taskStatusSucceeded, isPublish: true, publishErrors: s.publishErrors, publishWarnings: s.publishWarnings}
+	s.tasks[t.id] = t
+	s.publishErrors = nil
+	s.publishWarnings = nil
+	if len(t.publishErrors) > 0 {
+		return t
+	}
+
+	s.published = map[string]*Object{}
+	for id, obj := range s.objects {
+		obj.Fields["objectStatus"] = "Published"
+		s.published[id] = obj.clone()
+	}
+
+	return t
+}
+
+// dependencies describe how the API reports a missing object that an input refers to, by the field of the input
+var dependencies = map[string]struct {
+	param   string
+	message string
+}{
+	"profiles":  {param: "profileId", message: "Profile with ID %s does not exist"},
+	"triggers":  {param: "triggerId", message: "Trigger with ID %s does not exist"},
+	"practices": {param: "practiceId", message: "Practice with ID %s does not exist"},
+	"behaviors": {param: "parameterId", message: "Parameter with ID %s does not exist"},
+}
+
+// checkReferences returns a dependency error if the input refers to an object that does not exist
+func (s *Server) checkReferences(input map[string]any) error {
+	for key, value := range input {
+		field := key
+		if hasOperationPrefix(key, "add") {
+			field = fieldKey(map[string]any{}, strings.TrimPrefix(key, "add"))
+		}
+
+		dependency, ok := dependencies[field]
+		if !ok {
+			continue
+		}
+
+		for _, elem := range asList(value) {
+			id, _ := elem.(string)
+			if practice, ok := elem.(map[string]any); ok {
+				id, _ = practice["practiceId"].(string)
+			}
+
+			if _, ok := s.objects[id]; id != "" && !ok {
+				return &Error{
+					Code:    "DEPENDENCY_NOT_FOUND",
+					Message: fmt.Sprintf(dependency.message, id),
+					Params:  map[string]any{dependency.param: id},
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// list returns the objects of the given kind for a get<Kind>s query
+func (s *Server) list(kind string) []any {
+	var objs []*Object
+	for _, obj := range s.objects {
+		if obj.Kind == kind {
+			objs = append(objs, obj)
+		}
+	}
+
+	sort.Slice(objs, func(i, j int) bool { return objs[i].ID < objs[j].ID })
+	ret := make([]any, 0, len(objs))
+	for _, obj := range objs {
+		ret = append(ret, s.view(obj))
+	}
+
+	return ret
+}
+
+// view returns the object as it is returned by the API, with its computed usedBy field
+func (s *Server) view(obj *Object) map[string]any {
+	fields := deepCopy(obj.Fields).(map[string]any)
+	referrers := s.referrers(obj.ID)
+	switch obj.Kind {
+	case KindProfile:
+		usedBy := []any{}
+		for _, referrer := range referrers {
+			usedBy = append(usedBy, displayObject(referrer))
+		}
+
+		fields["usedBy"] = usedBy
+	case KindPractice, KindTrigger:
+		fields["usedBy"] = len(referrers)
+	}
+
+	return fields
+}
+
+func displayObject(obj *Object) map[string]any {
+	return map[string]any{
+		"id":           obj.ID,
+		"name":         obj.Name(),
+		"type":         obj.Kind,
+		"subType":      obj.SubType(),
+		"objectStatus": obj.Fields["objectStatus"],
+	}
+}
@@ -0,0 +1,99 @@
+package apitest
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// The helpers below run the lifecycle of a resource in-process, the same way terraform plans and applies it,
+// without the terraform binary. Configurations are given in their raw form, where blocks are lists of maps
+
+// Apply plans the given configuration against state and applies it, creating the resource if state is nil
+// returns the new state
+func Apply(t testing.TB, r *schema.Resource, state *terraform.InstanceState, config map[string]any, meta any) *terraform.InstanceState {
+	t.Helper()
+
+	state, err := TryApply(r, state, config, meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return state
+}
+
+// TryApply is Apply that returns the error of the plan or apply instead of failing the test
+func TryApply(r *schema.Resource, state *terraform.InstanceState, config map[string]any, meta any) (*terraform.InstanceState, error) {
+	ctx := context.Background()
+	resourceConfig := terraform.NewResourceConfigRaw(config)
+	diff, err := r.SimpleDiff(ctx, state, resourceConfig, meta)
+	if err != nil {
+		return state, err
+	}
+
+	if diff == nil {
+		return state, nil
+	}
+
+	// the timeouts block is passed to the operation in the diff, as terraform does when planning
+	timeouts := &schema.ResourceTimeout{}
+	if err := timeouts.ConfigDecode(r, resourceConfig); err != nil {
+		return state, err
+	}
+
+	if err := timeouts.DiffEncode(diff); err != nil {
+		return state, err
+	}
+
+	newState, diags := r.Apply(ctx, state, diff, meta)
+	if diags.HasError() {
+		return newState, diagsError(diags)
+	}
+
+	return newState, nil
+}
+
+// Refresh reads the resource of state, returns nil if the resource no longer exists
+func Refresh(t testing.TB, r *schema.Resource, state *terraform.InstanceState, meta any) *terraform.InstanceState {
+	t.Helper()
+
+	newState, diags := r.RefreshWithoutUpgrade(context.Background(), state, meta)
+	if diags.HasError() {
+		t.Fatal(diagsError(diags))
+	}
+
+	return newState
+}
+
+// Destroy deletes the resource of state
+func Destroy(t testing.TB, r *schema.Resource, state *terraform.InstanceState, meta any) {
+	t.Helper()
+
+	if _, diags := r.Apply(context.Background(), state, &terraform.InstanceDiff{Destroy: true}, meta); diags.HasError() {
+		t.Fatal(diagsError(diags))
+	}
+}
+
+// diagsError returns an error with the summaries and details of the errors in diags
+func diagsError(diags diag.Diagnostics) error {
+	var messages []string
+	for _, d := range diags {
+		if d.Severity != diag.Error {
+			continue
+		}
+
+		message := d.Summary
+		if d.Detail != "" {
+			message += ": " + d.Detail
+		}
+
+		messages = append(messages, message)
+	}
+
+	return errors.New(strings.Join(messages, "; "))
+}
@@ -0,0 +1,399 @@
+// Package apitest is an in-memory fake of the Infinity Next API for tests
+//
+// The fake serves the authentication endpoint and the GraphQL operations the provider uses over an httptest.Server,
+// so the lifecycle of every resource can be tested without network access:
+//
+//	server := apitest.NewServer(t)
+//	client := server.Client(t)
+//	// call resource functions with client, then inspect server.Object(id)
+package apitest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/api"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/pkg/regions"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	DefaultClientID  = "apitest-client-id"
+	DefaultAccessKey = "apitest-access-key"
+
+	// PolicyAppID is the appId claim of tokens issued for Infinity Policy API keys
+	PolicyAppID = "f47b536c-a990-42fb-9ab2-ec38f8c2dcff"
+
+	// PortalUser is the user that changes made outside of the test, by AddObject and UpdateObject, are attributed to
+	// changes made through the API are attributed to the client ID of the API key
+	PortalUser = "portal-user@example.com"
+
+	// referenceIDHeader is the header the API returns the reference ID of a request in
+	referenceIDHeader = "Logger-Token"
+)
+
+// Handler resolves an operation, it is called with the lock of the server held
+// returning an *Error responds with a GraphQL error, any other error responds with an internal server error
+type Handler func(s *Server, args map[string]any) (any, error)
+
+// Error is a GraphQL error returned by a Handler
+type Error struct {
+	Code    string
+	Message string
+
+	// Params are the message params of the error, e.g. the ID of a missing object
+	Params map[string]any
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+// Server is an in-memory fake of the Infinity Next API
+// objects are kept in a session that is published by asyncPublishChanges and reverted by discardChanges
+type Server struct {
+	*httptest.Server
+
+	// ClientID and AccessKey are the credentials accepted by the authentication endpoint
+	ClientID  string
+	AccessKey string
+
+	// TokenTTL is the lifetime of issued tokens
+	TokenTTL time.Duration
+
+	// TaskPolls is the number of times getTask reports a task as in progress before it is done
+	TaskPolls int
+
+	mu         sync.Mutex
+	objects    map[string]*Object
+	published  map[string]*Object
+	tasks      map[string]*task
+	handlers   map[string]Handler
+	tokens     map[string]time.Time
+	signingKey []byte
+	lastID     int
+	operations []string
+	failures   []int
+
+	// publishErrors are the validation errors of the next publish
+	publishErrors []string
+
+	// publishWarnings are the validation warnings of the next publish
+	publishWarnings []string
+}
+
+type task struct {
+	id              string
+	polls           int
+	status          string
+	publishErrors   []string
+	publishWarnings []string
+	isPublish       bool
+}
+
+// NewServer starts a fake API server that is closed when the test ends
+func NewServer(t testing.TB) *Server {
+	t.Helper()
+
+	s := &Server{
+		ClientID:   DefaultClientID,
+		AccessKey:  DefaultAccessKey,
+		TokenTTL:   time.Hour,
+		objects:    map[string]*Object{},
+		published:  map[string]*Object{},
+		tasks:      map[string]*task{},
+		handlers:   map[string]Handler{},
+		tokens:     map[string]time.Time{},
+		signingKey: []byte("apitest-signing-key"),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(regions.DefaultAuthPath, s.serveAuth)
+	mux.HandleFunc(regions.PolicyGraphQLPath, s.serveGraphQL)
+	s.Server = httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+// Client returns an API client that is authenticated with the fake, with retries that don't wait
+func (s *Server) Client(t testing.TB) *api.Client {
+	t.Helper()
+
+	c := api.NewClient()
+	c.SetHost(s.URL)
+	c.PinEndpoint(regions.PolicyGraphQLPath)
+	c.SetRetryPolicy(api.RetryPolicy{MaxAttempts: api.DefaultRetryMaxAttempts})
+	if err := c.InfinityPortalAuthentication(context.Background(), s.ClientID, s.AccessKey); err != nil {
+		t.Fatalf("failed to authenticate with the fake API: %v", err)
+	}
+
+	return c
+}
+
+// ProviderConfig returns a provider block that points the provider at the fake
+func (s *Server) ProviderConfig() string {
+	return fmt.Sprintf(`
+provider "inext" {
+	host       = %q
+	client_id  = %q
+	access_key = %q
+}
+`, s.URL, s.ClientID, s.AccessKey)
+}
+
+// Handle sets the handler of the given root field, replacing the built-in handler if there is one
+func (s *Server) Handle(field string, handler Handler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[field] = handler
+}
+
+// FailNext makes the next count GraphQL requests fail with the given HTTP status code
+func (s *Server) FailNext(count int, statusCode int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := 0; i < count; i++ {
+		s.failures = append(s.failures, statusCode)
+	}
+}
+
+// FailNextPublish makes the next publish fail validation with the given errors
+func (s *Server) FailNextPublish(errors ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.publishErrors = errors
+}
+
+// WarnNextPublish makes the next publish succeed with the given validation warnings
+func (s *Server) WarnNextPublish(warnings ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.publishWarnings = warnings
+}
+
+// RevokeTokens invalidates all issued tokens, the next request of each client is rejected with 401
+func (s *Server) RevokeTokens() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens = map[string]time.Time{}
+}
+
+// Operations returns the root fields of all GraphQL requests served so far, in order
+func (s *Server) Operations() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.operations...)
+}
+
+// Object returns a copy of the object with the given ID in the session, or nil if there is no such object
+func (s *Server) Object(id string) *Object {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if obj, ok := s.objects[id]; ok {
+		return obj.clone()
+	}
+
+	return nil
+}
+
+// PublishedObject returns a copy of the object with the given ID as of the last publish, or nil if there is no such object
+func (s *Server) PublishedObject(id string) *Object {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if obj, ok := s.published[id]; ok {
+		return obj.clone()
+	}
+
+	return nil
+}
+
+// Objects returns copies of all objects in the session
+func (s *Server) Objects() []*Object {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ret := make([]*Object, 0, len(s.objects))
+	for _, obj := range s.objects {
+		ret = append(ret, obj.clone())
+	}
+
+	return ret
+}
+
+// AddObject adds an object of the given type to the session, as if it was created outside of the test
+// returns the ID of the new object
+func (s *Server) AddObject(typeName string, input map[string]any) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if kindOf(typeName) == "" {
+		panic("apitest: unknown object type " + typeName)
+	}
+
+	input = deepCopy(normalizeJSON(input)).(map[string]any)
+	obj := s.newObject(typeName, input)
+	obj.Fields["modifiedBy"] = PortalUser
+	s.objects[obj.ID] = obj
+	return obj.ID
+}
+
+// UpdateObject updates fields of an object in the session, as if it was changed outside of the test
+func (s *Server) UpdateObject(id string, input map[string]any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	obj, ok := s.objects[id]
+	if !ok {
+		panic("apitest: unknown object " + id)
+	}
+
+	s.applyPatch(obj.Fields, deepCopy(normalizeJSON(input)).(map[string]any))
+	obj.Fields["modifiedBy"] = PortalUser
+	if obj.Fields["objectStatus"] != "Created" {
+		obj.Fields["objectStatus"] = "Updated"
+	}
+}
+
+// RemoveObject removes an object from the session, as if it was deleted outside of the test
+func (s *Server) RemoveObject(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.objects, id)
+}
+
+func (s *Server) serveAuth(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r.PostForm.Get("clientId") != s.ClientID || r.PostForm.Get("accessKey") != s.AccessKey {
+		writeJSON(w, http.StatusUnauthorized, map[string]any{"success": false, "message": "invalid credentials"})
+		return
+	}
+
+	expiry := time.Now().Add(s.TokenTTL)
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"appId": PolicyAppID,
+		"exp":   expiry.Unix(),
+		"jti":   s.newID(),
+	}).SignedString(s.signingKey)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	s.tokens[token] = expiry
+	writeJSON(w, http.StatusOK, map[string]any{
+		"success": true,
+		"data": map[string]any{
+			"token":     token,
+			"csrf":      "",
+			"expires":   expiry.UTC().Format(time.RFC3339),
+			"expiresIn": int(s.TokenTTL.Seconds()),
+		},
+	})
+}
+
+func (s *Server) serveGraphQL(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		Query     string         `json:"query"`
+		Variables map[string]any `json:"variables"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	w.Header().Set(referenceIDHeader, s.newID())
+	if len(s.failures) > 0 {
+		statusCode := s.failures[0]
+		s.failures = s.failures[1:]
+		http.Error(w, http.StatusText(statusCode), statusCode)
+		return
+	}
+
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if expiry, ok := s.tokens[token]; !ok || time.Now().After(expiry) {
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	op, err := parseOperation(request.Query, request.Variables)
+	if err != nil {
+		writeGraphQLError(w, &Error{Code: "GRAPHQL_PARSE_FAILED", Message: err.Error()})
+		return
+	}
+
+	s.operations = append(s.operations, op.Field)
+	handler := s.handlers[op.Field]
+	if handler == nil {
+		handler = builtinHandler(op.Field)
+	}
+
+	if handler == nil {
+		writeGraphQLError(w, &Error{Code: "GRAPHQL_VALIDATION_FAILED", Message: fmt.Sprintf("Cannot query field %q", op.Field)})
+		return
+	}
+
+	result, err := handler(s, op.Args)
+	if err != nil {
+		if graphErr, ok := err.(*Error); ok {
+			writeGraphQLError(w, graphErr)
+			return
+		}
+
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"data": map[string]any{op.Field: result}})
+}
+
+func writeGraphQLError(w http.ResponseWriter, err *Error) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"data": nil,
+		"errors": []map[string]any{{
+			"message":    err.Message,
+			"extensions": map[string]any{"code": err.Code, "messageParams": err.Params},
+		}},
+	})
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// normalizeJSON converts v to the types it would have after a JSON round trip, e.g. []string to []any
+func normalizeJSON(v any) any {
+	b, err := json.Marshal(v)
+	if err != nil {
+		panic(fmt.Sprintf("apitest: failed to marshal %#v: %v", v, err))
+	}
+
+	var ret any
+	if err := json.Unmarshal(b, &ret); err != nil {
+		panic(fmt.Sprintf("apitest: failed to unmarshal %s: %v", b, err))
+	}
+
+	return ret
+}
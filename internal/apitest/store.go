@@ -0,0 +1,396 @@
+package apitest
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Kinds of objects, the kind of an object is the suffix of its type name
+const (
+	KindAsset    = "Asset"
+	KindProfile  = "Profile"
+	KindPractice = "Practice"
+	KindBehavior = "Behavior"
+	KindTrigger  = "Trigger"
+)
+
+// ObjectTypes are the type names of the objects the fake stores
+// each type has the new<Type>, update<Type> and get<Type> operations
+var ObjectTypes = []string{
+	"WebApplicationAsset",
+	"WebAPIAsset",
+	"CloudGuardAppSecGatewayProfile",
+	"DockerProfile",
+	"EmbeddedProfile",
+	"KubernetesProfile",
+	"WebApplicationPractice",
+	"WebAPIPractice",
+	"RateLimitPractice",
+	"ExceptionBehavior",
+	"TrustedSourceBehavior",
+	"WebUserResponseBehavior",
+	"LogTrigger",
+}
+
+var kinds = []string{KindAsset, KindProfile, KindPractice, KindBehavior, KindTrigger}
+
+// referenceFields hold IDs of other objects in inputs, and are returned as objects with an id field
+var referenceFields = map[string]struct{}{
+	"profiles":  {},
+	"behaviors": {},
+	"triggers":  {},
+}
+
+// Object is an object stored by the fake
+type Object struct {
+	ID       string
+	TypeName string
+	Kind     string
+
+	// Fields are the fields of the object as they are returned by the API
+	Fields map[string]any
+}
+
+// SubType returns the type of the object within its kind, e.g. WebApplication for a WebApplicationAsset
+func (o *Object) SubType() string {
+	return strings.TrimSuffix(o.TypeName, o.Kind)
+}
+
+// Name returns the name of the object
+func (o *Object) Name() string {
+	name, _ := o.Fields["name"].(string)
+	return name
+}
+
+func (o *Object) clone() *Object {
+	return &Object{
+		ID:       o.ID,
+		TypeName: o.TypeName,
+		Kind:     o.Kind,
+		Fields:   deepCopy(o.Fields).(map[string]any),
+	}
+}
+
+// kindOf returns the kind of the given object type name, or an empty string if it is not a known type
+func kindOf(typeName string) string {
+	for _, objectType := range ObjectTypes {
+		if objectType != typeName {
+			continue
+		}
+
+		for _, kind := range kinds {
+			if strings.HasSuffix(typeName, kind) {
+				return kind
+			}
+		}
+	}
+
+	return ""
+}
+
+// typeField returns the name of the field that holds the sub type of objects of the given kind, e.g. assetType
+func typeField(kind string) string {
+	return strings.ToLower(kind) + "Type"
+}
+
+// newObject builds an object of the given type from the input of its new<Type> mutation
+func (s *Server) newObject(typeName string, input map[string]any) *Object {
+	obj := &Object{
+		ID:       s.newID(),
+		TypeName: typeName,
+		Kind:     kindOf(typeName),
+		Fields:   map[string]any{},
+	}
+
+	for key, value := range input {
+		obj.Fields[key] = s.convertInput(key, value)
+	}
+
+	obj.Fields["id"] = obj.ID
+	obj.Fields[typeField(obj.Kind)] = obj.SubType()
+	obj.Fields["objectStatus"] = "Created"
+	switch obj.Kind {
+	case KindProfile:
+		authentication, _ := obj.Fields["authentication"].(map[string]any)
+		if authentication == nil {
+			authentication = map[string]any{}
+		}
+
+		authentication["token"] = "cp-" + s.newID()
+		obj.Fields["authentication"] = authentication
+	case KindPractice, KindBehavior:
+		if _, ok := obj.Fields["visibility"]; !ok {
+			obj.Fields["visibility"] = "Shared"
+		}
+	}
+
+	return obj
+}
+
+// convertInput converts a field of an input to the field as it is returned by the API
+// references to other objects become objects with an id, and list elements are assigned IDs
+func (s *Server) convertInput(key string, value any) any {
+	list, ok := value.([]any)
+	if !ok {
+		if object, ok := value.(map[string]any); ok {
+			converted := make(map[string]any, len(object))
+			for k, v := range object {
+				converted[k] = s.convertInput(k, v)
+			}
+
+			return converted
+		}
+
+		// files are returned as objects that wrap their data URL
+		if data, ok := value.(string); ok && strings.EqualFold(key, "OasSchema") {
+			return map[string]any{"data": data, "name": "", "size": len(data), "isFileExist": data != ""}
+		}
+
+		return value
+	}
+
+	converted := make([]any, 0, len(list))
+	for _, elem := range list {
+		converted = append(converted, s.convertListElement(key, elem))
+	}
+
+	return converted
+}
+
+func (s *Server) convertListElement(key string, elem any) any {
+	switch value := elem.(type) {
+	case string:
+		if _, ok := referenceFields[key]; ok {
+			return map[string]any{"id": value}
+		}
+
+		switch key {
+		case "URLs":
+			return map[string]any{"id": s.newID(), "URL": value}
+		case "values":
+			return map[string]any{"id": s.newID(), "IdentifierValue": value}
+		case "sourcesIdentifiers":
+			return map[string]any{"id": s.newID(), "source": value}
+		case "actions":
+			return map[string]any{"id": s.newID(), "action": value}
+		}
+
+		return value
+	case map[string]any:
+		converted := map[string]any{}
+		for k, v := range value {
+			converted[k] = s.convertInput(k, v)
+		}
+
+		// practices of assets refer to a practice and hold its modes and triggers
+		if practiceID, ok := converted["practiceId"]; ok {
+			delete(converted, "practiceId")
+			delete(converted, "practiceWrapperId")
+			converted["practice"] = map[string]any{"id": practiceID}
+		}
+
+		if _, ok := converted["id"]; !ok {
+			converted["id"] = s.newID()
+		}
+
+		return converted
+	}
+
+	return elem
+}
+
+// applyPatch applies the input of an update<Type> mutation to fields
+// add<Field>, remove<Field> and update<Field> inputs add elements to the list field, remove elements by their
+// ID (or the ID of the object they refer to) and merge elements with the same ID. Other inputs replace the field,
+// or are merged into it if both are objects
+func (s *Server) applyPatch(fields map[string]any, patch map[string]any) {
+	for key, value := range patch {
+		switch {
+		case hasOperationPrefix(key, "add"):
+			field := fieldKey(fields, strings.TrimPrefix(key, "add"))
+			list, _ := fields[field].([]any)
+			for _, elem := range asList(value) {
+				list = append(list, s.convertListElement(field, elem))
+			}
+
+			fields[field] = list
+		case hasOperationPrefix(key, "remove"):
+			field := fieldKey(fields, strings.TrimPrefix(key, "remove"))
+			removed := map[string]struct{}{}
+			for _, elem := range asList(value) {
+				if id, ok := elem.(string); ok {
+					removed[id] = struct{}{}
+				}
+			}
+
+			list, _ := fields[field].([]any)
+			kept := []any{}
+			for _, elem := range list {
+				if _, ok := removed[elementID(elem)]; !ok {
+					kept = append(kept, elem)
+				}
+			}
+
+			fields[field] = kept
+		case hasOperationPrefix(key, "update"):
+			field := fieldKey(fields, strings.TrimPrefix(key, "update"))
+			list, _ := fields[field].([]any)
+			for _, update := range asList(value) {
+				updateMap, ok := update.(map[string]any)
+				if !ok {
+					continue
+				}
+
+				for _, elem := range list {
+					if elemMap, ok := elem.(map[string]any); ok && elemMap["id"] == updateMap["id"] {
+						s.applyPatch(elemMap, updateMap)
+					}
+				}
+			}
+		default:
+			field := fieldKey(fields, key)
+			existing, existingIsMap := fields[field].(map[string]any)
+			valueMap, valueIsMap := value.(map[string]any)
+			if existingIsMap && valueIsMap {
+				s.applyPatch(existing, valueMap)
+				continue
+			}
+
+			fields[field] = s.convertInput(field, value)
+		}
+	}
+}
+
+// hasOperationPrefix reports whether key is the given operation on a field, e.g. addProfiles for add
+func hasOperationPrefix(key, prefix string) bool {
+	if !strings.HasPrefix(key, prefix) || len(key) == len(prefix) {
+		return false
+	}
+
+	r, _ := utf8.DecodeRuneInString(key[len(prefix):])
+	return unicode.IsUpper(r)
+}
+
+// fieldKey returns the key of fields that matches name case-insensitively, as inputs and objects differ in case
+// (e.g. the url of updateURLs is the URL field of an URL). If there is no such field,
+// the name is returned with its first letter in lower case unless it is an acronym
+func fieldKey(fields map[string]any, name string) string {
+	for key := range fields {
+		if strings.EqualFold(key, name) {
+			return key
+		}
+	}
+
+	r, size := utf8.DecodeRuneInString(name)
+	next, _ := utf8.DecodeRuneInString(name[size:])
+	if unicode.IsUpper(next) {
+		return name
+	}
+
+	return string(unicode.ToLower(r)) + name[size:]
+}
+
+func asList(value any) []any {
+	if list, ok := value.([]any); ok {
+		return list
+	}
+
+	return nil
+}
+
+// elementID returns the ID of a list element, or the ID of the practice it refers to
+// so practices of assets can be removed by the ID of their practice
+func elementID(elem any) string {
+	switch value := elem.(type) {
+	case string:
+		return value
+	case map[string]any:
+		if practice, ok := value["practice"].(map[string]any); ok {
+			if id, ok := practice["id"].(string); ok {
+				return id
+			}
+		}
+
+		id, _ := value["id"].(string)
+		return id
+	}
+
+	return ""
+}
+
+// referrers returns the objects that refer to the object with the given ID, sorted by ID
+func (s *Server) referrers(id string) []*Object {
+	var ret []*Object
+	for _, obj := range s.objects {
+		if obj.ID != id && refersTo(obj.Fields, id) {
+			ret = append(ret, obj)
+		}
+	}
+
+	sort.Slice(ret, func(i, j int) bool { return ret[i].ID < ret[j].ID })
+	return ret
+}
+
+// refersTo reports whether v holds a reference to the object with the given ID
+// references are objects with an id field, the IDs of list elements are not references
+func refersTo(v any, id string) bool {
+	switch value := v.(type) {
+	case map[string]any:
+		for key, fieldValue := range value {
+			if key == "id" {
+				continue
+			}
+
+			if fieldMap, ok := fieldValue.(map[string]any); ok && fieldMap["id"] == id {
+				return true
+			}
+
+			if refersTo(fieldValue, id) {
+				return true
+			}
+		}
+	case []any:
+		for _, elem := range value {
+			if elemMap, ok := elem.(map[string]any); ok && elemMap["id"] == id && len(elemMap) == 1 {
+				return true
+			}
+
+			if refersTo(elem, id) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func deepCopy(v any) any {
+	switch value := v.(type) {
+	case map[string]any:
+		ret := make(map[string]any, len(value))
+		for k, elem := range value {
+			ret[k] = deepCopy(elem)
+		}
+
+		return ret
+	case []any:
+		ret := make([]any, len(value))
+		for i, elem := range value {
+			ret[i] = deepCopy(elem)
+		}
+
+		return ret
+	}
+
+	return v
+}
+
+// newID returns a new unique ID in the UUID format of the API
+// the caller must hold the lock of the server
+func (s *Server) newID() string {
+	s.lastID++
+	return fmt.Sprintf("00000000-0000-4000-8000-%012x", s.lastID)
+}
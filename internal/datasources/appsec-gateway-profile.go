@@ -0,0 +1,52 @@
+package datasources
+
+import (
+	"context"
+
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/api"
+	models "github.com/CheckPointSW/terraform-provider-infinity-next/internal/models/objects"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources"
+	appsecgatewayprofile "github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources/appsec-gateway-profile"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources/objects"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceAppSecGatewayProfile() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to get an existing CloudGuard AppSec gateway profile by ID or name",
+
+		ReadContext: dataSourceAppSecGatewayProfileRead,
+
+		Schema: dataSourceSchemaFromResourceSchema(resources.ResourceAppSecGatewayProfile().Schema),
+	}
+}
+
+func dataSourceAppSecGatewayProfileRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := meta.(*api.Client)
+
+	id, err := lookupID(d, func(name string) (string, error) {
+		return objects.FindProfileIDByName(ctx, c, name, models.ProfileTypeAppSecGateway)
+	})
+	if err != nil {
+		return utils.DiagError("unable to find AppSecGatewayProfile", err, diags)
+	}
+
+	profile, err := appsecgatewayprofile.GetCloudGuardAppSecGatewayProfile(ctx, c, id)
+	if err != nil {
+		return utils.DiagError("unable to perform AppSecGatewayProfile data source Read", err, diags)
+	}
+
+	if err := notFoundIfEmptyID(profile.ID, id); err != nil {
+		return utils.DiagError("unable to perform AppSecGatewayProfile data source Read", err, diags)
+	}
+
+	if err := appsecgatewayprofile.ReadCloudGuardAppSecGatewayProfileToResourceData(profile, d); err != nil {
+		return utils.DiagError("unable to perform AppSecGatewayProfile data source read to state file", err, diags)
+	}
+
+	return diags
+}
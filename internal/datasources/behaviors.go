@@ -0,0 +1,80 @@
+package datasources
+
+import (
+	"context"
+
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/api"
+	models "github.com/CheckPointSW/terraform-provider-infinity-next/internal/models/objects"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources/objects"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceBehaviors() *schema.Resource {
+	dataSourceSchema := listDataSourceSchema()
+	dataSourceSchema["behavior_type"] = stringSetSchema("Return only behaviors of one of these types: Exception, TrustedSource or WebUserResponse",
+		models.BehaviorTypeException, models.BehaviorTypeTrustedSources, models.BehaviorTypeWebUserResponse)
+	dataSourceSchema["visibility"] = stringSetSchema("Return only behaviors with one of these visibilities: Shared or Local",
+		models.VisibilityShared, models.VisibilityLocal)
+	dataSourceSchema["behaviors"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "The matching behaviors",
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"id": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"name": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"behavior_type": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"visibility": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		},
+	}
+
+	return &schema.Resource{
+		Description: "Use this data source to list the behaviors that match the given filters. Behaviors have no tags, so they can't be filtered by tags",
+
+		ReadContext: dataSourceBehaviorsRead,
+
+		Schema: dataSourceSchema,
+	}
+}
+
+func dataSourceBehaviorsRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := meta.(*api.Client)
+
+	matchName, err := nameMatcher(d)
+	if err != nil {
+		return utils.DiagError("invalid name_regex", err, diags)
+	}
+
+	behaviorTypes := stringSetFromResourceData(d, "behavior_type")
+	visibilities := stringSetFromResourceData(d, "visibility")
+	behaviors, err := objects.GetBehaviors(ctx, c)
+	if err != nil {
+		return utils.DiagError("unable to perform Behaviors data source Read", err, diags)
+	}
+
+	// the list queries return all objects, so they are filtered here
+	behaviors = utils.Filter(behaviors, func(behavior models.DisplayBehavior) bool {
+		return matchName(behavior.Name) &&
+			matchesAny(behavior.BehaviorType, behaviorTypes) &&
+			matchesAny(behavior.Visibility, visibilities)
+	})
+
+	return setListResult(d, "behaviors", behaviors.ToSchema(), utils.Map(behaviors, func(behavior models.DisplayBehavior) string { return behavior.ID }), diags)
+}
@@ -0,0 +1,86 @@
+package datasources
+
+import (
+	"fmt"
+
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// dataSourceSchemaFromResourceSchema converts the schema of a resource to the schema of its data source
+// all attributes become computed, except for id and name which are used to look up the object
+func dataSourceSchemaFromResourceSchema(resourceSchema map[string]*schema.Schema) map[string]*schema.Schema {
+	ret := computedSchemaMap(resourceSchema)
+	ret["id"] = &schema.Schema{
+		Description:  "The ID of the object to look up",
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{"id", "name"},
+	}
+
+	ret["name"] = &schema.Schema{
+		Description:  "The name of the object to look up",
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{"id", "name"},
+	}
+
+	return ret
+}
+
+func computedSchemaMap(schemaMap map[string]*schema.Schema) map[string]*schema.Schema {
+	ret := make(map[string]*schema.Schema, len(schemaMap))
+	for k, v := range schemaMap {
+		ret[k] = computedSchema(v)
+	}
+
+	return ret
+}
+
+// computedSchema returns a copy of the given schema that is only computed, without any
+// of the fields that are not allowed on a computed-only attribute (defaults, validations etc.)
+func computedSchema(s *schema.Schema) *schema.Schema {
+	ret := &schema.Schema{
+		Type:        s.Type,
+		Description: s.Description,
+		Computed:    true,
+		Sensitive:   s.Sensitive,
+		Set:         s.Set,
+	}
+
+	switch elem := s.Elem.(type) {
+	case *schema.Schema:
+		ret.Elem = &schema.Schema{Type: elem.Type}
+	case *schema.Resource:
+		ret.Elem = &schema.Resource{Schema: computedSchemaMap(elem.Schema)}
+	}
+
+	return ret
+}
+
+// lookupID returns the ID of the object to read: the configured id if set,
+// otherwise the ID returned from findByName for the configured name
+func lookupID(d *schema.ResourceData, findByName func(name string) (string, error)) (string, error) {
+	if id, ok := d.GetOk("id"); ok && id.(string) != "" {
+		return id.(string), nil
+	}
+
+	name := d.Get("name").(string)
+	if name == "" {
+		return "", fmt.Errorf("one of id or name must be set")
+	}
+
+	return findByName(name)
+}
+
+// notFoundIfEmptyID returns ErrorNotFound when an object was read with an empty ID
+// which is how the API responds to a get query of an object that does not exist
+func notFoundIfEmptyID(objectID, id string) error {
+	if objectID == "" {
+		return fmt.Errorf("object with ID %s: %w", id, api.ErrorNotFound)
+	}
+
+	return nil
+}
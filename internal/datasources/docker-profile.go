@@ -0,0 +1,52 @@
+package datasources
+
+import (
+	"context"
+
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/api"
+	models "github.com/CheckPointSW/terraform-provider-infinity-next/internal/models/objects"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources"
+	dockerprofile "github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources/docker-profile"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources/objects"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceDockerProfile() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to get an existing docker profile by ID or name",
+
+		ReadContext: dataSourceDockerProfileRead,
+
+		Schema: dataSourceSchemaFromResourceSchema(resources.ResourceDockerProfile().Schema),
+	}
+}
+
+func dataSourceDockerProfileRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := meta.(*api.Client)
+
+	id, err := lookupID(d, func(name string) (string, error) {
+		return objects.FindProfileIDByName(ctx, c, name, models.ProfileTypeDocker)
+	})
+	if err != nil {
+		return utils.DiagError("unable to find DockerProfile", err, diags)
+	}
+
+	profile, err := dockerprofile.GetDockerProfile(ctx, c, id)
+	if err != nil {
+		return utils.DiagError("unable to perform DockerProfile data source Read", err, diags)
+	}
+
+	if err := notFoundIfEmptyID(profile.ID, id); err != nil {
+		return utils.DiagError("unable to perform DockerProfile data source Read", err, diags)
+	}
+
+	if err := dockerprofile.ReadDockerProfileToResourceData(profile, d); err != nil {
+		return utils.DiagError("unable to perform DockerProfile data source read to state file", err, diags)
+	}
+
+	return diags
+}
@@ -0,0 +1,52 @@
+package datasources
+
+import (
+	"context"
+
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/api"
+	models "github.com/CheckPointSW/terraform-provider-infinity-next/internal/models/objects"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources"
+	embeddedprofile "github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources/embedded-profile"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources/objects"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceEmbeddedProfile() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to get an existing embedded profile by ID or name",
+
+		ReadContext: dataSourceEmbeddedProfileRead,
+
+		Schema: dataSourceSchemaFromResourceSchema(resources.ResourceEmbeddedProfile().Schema),
+	}
+}
+
+func dataSourceEmbeddedProfileRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := meta.(*api.Client)
+
+	id, err := lookupID(d, func(name string) (string, error) {
+		return objects.FindProfileIDByName(ctx, c, name, models.ProfileTypeEmbedded)
+	})
+	if err != nil {
+		return utils.DiagError("unable to find EmbeddedProfile", err, diags)
+	}
+
+	profile, err := embeddedprofile.GetEmbeddedProfile(ctx, c, id)
+	if err != nil {
+		return utils.DiagError("unable to perform EmbeddedProfile data source Read", err, diags)
+	}
+
+	if err := notFoundIfEmptyID(profile.ID, id); err != nil {
+		return utils.DiagError("unable to perform EmbeddedProfile data source Read", err, diags)
+	}
+
+	if err := embeddedprofile.ReadEmbeddedProfileToResourceData(profile, d); err != nil {
+		return utils.DiagError("unable to perform EmbeddedProfile data source read to state file", err, diags)
+	}
+
+	return diags
+}
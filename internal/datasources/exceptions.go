@@ -0,0 +1,52 @@
+package datasources
+
+import (
+	"context"
+
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/api"
+	models "github.com/CheckPointSW/terraform-provider-infinity-next/internal/models/objects"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources/exceptions"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources/objects"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceExceptions() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to get an existing exceptions behavior by ID or name",
+
+		ReadContext: dataSourceExceptionsRead,
+
+		Schema: dataSourceSchemaFromResourceSchema(resources.ResourceExceptions().Schema),
+	}
+}
+
+func dataSourceExceptionsRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := meta.(*api.Client)
+
+	id, err := lookupID(d, func(name string) (string, error) {
+		return objects.FindBehaviorIDByName(ctx, c, name, models.BehaviorTypeException)
+	})
+	if err != nil {
+		return utils.DiagError("unable to find Exceptions", err, diags)
+	}
+
+	behavior, err := exceptions.GetExceptionBehavior(ctx, c, id)
+	if err != nil {
+		return utils.DiagError("unable to perform Exceptions data source Read", err, diags)
+	}
+
+	if err := notFoundIfEmptyID(behavior.ID, id); err != nil {
+		return utils.DiagError("unable to perform Exceptions data source Read", err, diags)
+	}
+
+	if err := exceptions.ReadExceptionBehaviorToResourceData(behavior, d); err != nil {
+		return utils.DiagError("unable to perform Exceptions data source read to state file", err, diags)
+	}
+
+	return diags
+}
@@ -0,0 +1,52 @@
+package datasources
+
+import (
+	"context"
+
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/api"
+	models "github.com/CheckPointSW/terraform-provider-infinity-next/internal/models/objects"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources"
+	kubernetesprofile "github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources/kubernetes-profile"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources/objects"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceKubernetesProfile() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to get an existing kubernetes profile by ID or name",
+
+		ReadContext: dataSourceKubernetesProfileRead,
+
+		Schema: dataSourceSchemaFromResourceSchema(resources.ResourceKubernetesProfile().Schema),
+	}
+}
+
+func dataSourceKubernetesProfileRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := meta.(*api.Client)
+
+	id, err := lookupID(d, func(name string) (string, error) {
+		return objects.FindProfileIDByName(ctx, c, name, models.ProfileTypeKubernetes)
+	})
+	if err != nil {
+		return utils.DiagError("unable to find KubernetesProfile", err, diags)
+	}
+
+	profile, err := kubernetesprofile.GetKubernetesProfile(ctx, c, id)
+	if err != nil {
+		return utils.DiagError("unable to perform KubernetesProfile data source Read", err, diags)
+	}
+
+	if err := notFoundIfEmptyID(profile.ID, id); err != nil {
+		return utils.DiagError("unable to perform KubernetesProfile data source Read", err, diags)
+	}
+
+	if err := kubernetesprofile.ReadKubernetesProfileToResourceData(profile, d); err != nil {
+		return utils.DiagError("unable to perform KubernetesProfile data source read to state file", err, diags)
+	}
+
+	return diags
+}
@@ -0,0 +1,130 @@
+package datasources
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// listDataSourceSchema returns the attributes shared by all list data sources:
+// the name filters and the computed list of IDs of the matching objects
+func listDataSourceSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name_search": {
+			Type:        schema.TypeString,
+			Description: "Return only objects whose name contains this string, ignoring case",
+			Optional:    true,
+		},
+		"name_regex": {
+			Type:             schema.TypeString,
+			Description:      "Return only objects whose name matches this regular expression",
+			Optional:         true,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsValidRegExp),
+		},
+		"ids": {
+			Type:        schema.TypeList,
+			Description: "The IDs of the matching objects",
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	}
+}
+
+// stringSetSchema returns an optional set of strings filter attribute
+// whose values are validated against the given allowed values, if any
+func stringSetSchema(description string, allowedValues ...string) *schema.Schema {
+	elem := &schema.Schema{Type: schema.TypeString}
+	if len(allowedValues) > 0 {
+		elem.ValidateDiagFunc = validation.ToDiagFunc(validation.StringInSlice(allowedValues, false))
+	}
+
+	return &schema.Schema{
+		Type:        schema.TypeSet,
+		Description: description,
+		Optional:    true,
+		Elem:        elem,
+	}
+}
+
+// nameMatcher returns a function that reports whether a name matches the configured name_search and name_regex
+// the list queries return all objects, so the names are matched here rather than by the server
+func nameMatcher(d *schema.ResourceData) (func(string) bool, error) {
+	nameSearch := strings.ToLower(d.Get("name_search").(string))
+	nameRegex := d.Get("name_regex").(string)
+	if nameRegex == "" {
+		return func(name string) bool { return strings.Contains(strings.ToLower(name), nameSearch) }, nil
+	}
+
+	re, err := regexp.Compile(nameRegex)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(name string) bool {
+		return strings.Contains(strings.ToLower(name), nameSearch) && re.MatchString(name)
+	}, nil
+}
+
+// stringSetFromResourceData returns the values of a set of strings attribute
+func stringSetFromResourceData(d *schema.ResourceData, key string) []string {
+	set, ok := d.Get(key).(*schema.Set)
+	if !ok {
+		return nil
+	}
+
+	ret := make([]string, 0, set.Len())
+	for _, v := range set.List() {
+		ret = append(ret, v.(string))
+	}
+
+	return ret
+}
+
+// matchesAny reports whether value is one of the allowed values
+// an empty list of allowed values matches every value
+func matchesAny(value string, allowedValues []string) bool {
+	if len(allowedValues) == 0 {
+		return true
+	}
+
+	for _, allowed := range allowedValues {
+		if value == allowed {
+			return true
+		}
+	}
+
+	return false
+}
+
+// listID returns a stable ID for a list data source, derived from the IDs of the matching objects
+func listID(ids []string) string {
+	return strconv.Itoa(schema.HashString(strings.Join(ids, ",")))
+}
+
+// setListResult sets the matching objects under key, their IDs under ids and the ID of the data source
+func setListResult[T any](d *schema.ResourceData, key string, objectsSchema []T, ids []string, diags diag.Diagnostics) diag.Diagnostics {
+	objectsMaps, err := utils.UnmarshalAs[[]map[string]any](objectsSchema)
+	if err != nil {
+		return utils.DiagError("unable to convert "+key+" to state file", err, diags)
+	}
+
+	if err := d.Set(key, objectsMaps); err != nil {
+		return utils.DiagError("unable to set "+key+" in state file", err, diags)
+	}
+
+	if err := d.Set("ids", ids); err != nil {
+		return utils.DiagError("unable to set ids in state file", err, diags)
+	}
+
+	d.SetId(listID(ids))
+
+	return diags
+}
@@ -0,0 +1,52 @@
+package datasources
+
+import (
+	"context"
+
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/api"
+	models "github.com/CheckPointSW/terraform-provider-infinity-next/internal/models/objects"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources"
+	logtrigger "github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources/log-trigger"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources/objects"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceLogTrigger() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to get an existing log trigger by ID or name",
+
+		ReadContext: dataSourceLogTriggerRead,
+
+		Schema: dataSourceSchemaFromResourceSchema(resources.ResourceLogTrigger().Schema),
+	}
+}
+
+func dataSourceLogTriggerRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := meta.(*api.Client)
+
+	id, err := lookupID(d, func(name string) (string, error) {
+		return objects.FindTriggerIDByName(ctx, c, name, models.TriggerTypeLog)
+	})
+	if err != nil {
+		return utils.DiagError("unable to find LogTrigger", err, diags)
+	}
+
+	logTrigger, err := logtrigger.GetLogTrigger(ctx, c, id)
+	if err != nil {
+		return utils.DiagError("unable to perform LogTrigger data source Read", err, diags)
+	}
+
+	if err := notFoundIfEmptyID(logTrigger.ID, id); err != nil {
+		return utils.DiagError("unable to perform LogTrigger data source Read", err, diags)
+	}
+
+	if err := logtrigger.ReadLogTriggerToResourceData(logTrigger, d); err != nil {
+		return utils.DiagError("unable to perform LogTrigger data source read to state file", err, diags)
+	}
+
+	return diags
+}
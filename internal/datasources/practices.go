@@ -0,0 +1,88 @@
+package datasources
+
+import (
+	"context"
+
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/api"
+	models "github.com/CheckPointSW/terraform-provider-infinity-next/internal/models/objects"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources/objects"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourcePractices() *schema.Resource {
+	dataSourceSchema := listDataSourceSchema()
+	dataSourceSchema["practice_type"] = stringSetSchema("Return only practices of one of these types: WebApplication, WebAPI or RateLimit",
+		models.PracticeTypeWebApplication, models.PracticeTypeWebAPI, models.PracticeTypeRateLimit)
+	dataSourceSchema["visibility"] = stringSetSchema("Return only practices with one of these visibilities: Shared or Local",
+		models.VisibilityShared, models.VisibilityLocal)
+	dataSourceSchema["practices"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "The matching practices",
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"id": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"name": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"practice_type": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"category": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"visibility": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"default": {
+					Type:     schema.TypeBool,
+					Computed: true,
+				},
+			},
+		},
+	}
+
+	return &schema.Resource{
+		Description: "Use this data source to list the practices that match the given filters. Practices have no tags, so they can't be filtered by tags",
+
+		ReadContext: dataSourcePracticesRead,
+
+		Schema: dataSourceSchema,
+	}
+}
+
+func dataSourcePracticesRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := meta.(*api.Client)
+
+	matchName, err := nameMatcher(d)
+	if err != nil {
+		return utils.DiagError("invalid name_regex", err, diags)
+	}
+
+	practiceTypes := stringSetFromResourceData(d, "practice_type")
+	visibilities := stringSetFromResourceData(d, "visibility")
+	practices, err := objects.GetPractices(ctx, c)
+	if err != nil {
+		return utils.DiagError("unable to perform Practices data source Read", err, diags)
+	}
+
+	// the list queries return all objects, so they are filtered here
+	practices = utils.Filter(practices, func(practice models.DisplayPractice) bool {
+		return matchName(practice.Name) &&
+			matchesAny(practice.PracticeType, practiceTypes) &&
+			matchesAny(practice.Visibility, visibilities)
+	})
+
+	return setListResult(d, "practices", practices.ToSchema(), utils.Map(practices, func(practice models.DisplayPractice) string { return practice.ID }), diags)
+}
@@ -0,0 +1,79 @@
+package datasources
+
+import (
+	"context"
+
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/api"
+	models "github.com/CheckPointSW/terraform-provider-infinity-next/internal/models/objects"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources/objects"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceProfiles() *schema.Resource {
+	dataSourceSchema := listDataSourceSchema()
+	dataSourceSchema["profile_type"] = stringSetSchema("Return only profiles of one of these types: CloudGuardAppSecGateway, Embedded, Docker or Kubernetes",
+		models.ProfileTypeAppSecGateway, models.ProfileTypeEmbedded, models.ProfileTypeDocker, models.ProfileTypeKubernetes)
+	dataSourceSchema["profile_sub_type"] = stringSetSchema("Return only profiles of one of these sub types, for example Aws or Azure")
+	dataSourceSchema["profiles"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "The matching profiles",
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"id": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"name": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"profile_type": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"profile_sub_type": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		},
+	}
+
+	return &schema.Resource{
+		Description: "Use this data source to list the profiles that match the given filters. Profiles have no tags or visibility, so they can't be filtered by them",
+
+		ReadContext: dataSourceProfilesRead,
+
+		Schema: dataSourceSchema,
+	}
+}
+
+func dataSourceProfilesRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := meta.(*api.Client)
+
+	matchName, err := nameMatcher(d)
+	if err != nil {
+		return utils.DiagError("invalid name_regex", err, diags)
+	}
+
+	profileTypes := stringSetFromResourceData(d, "profile_type")
+	profileSubTypes := stringSetFromResourceData(d, "profile_sub_type")
+	profiles, err := objects.GetProfiles(ctx, c)
+	if err != nil {
+		return utils.DiagError("unable to perform Profiles data source Read", err, diags)
+	}
+
+	// the list queries return all objects, so they are filtered here
+	profiles = utils.Filter(profiles, func(profile models.DisplayProfile) bool {
+		return matchName(profile.Name) &&
+			matchesAny(profile.ProfileType, profileTypes) &&
+			matchesAny(profile.ProfileSubType, profileSubTypes)
+	})
+
+	return setListResult(d, "profiles", profiles.ToSchema(), utils.Map(profiles, func(profile models.DisplayProfile) string { return profile.ID }), diags)
+}
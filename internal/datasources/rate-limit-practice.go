@@ -0,0 +1,52 @@
+package datasources
+
+import (
+	"context"
+
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/api"
+	models "github.com/CheckPointSW/terraform-provider-infinity-next/internal/models/objects"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources/objects"
+	ratelimitpractice "github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources/rate-limit-practice"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceRateLimitPractice() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to get an existing rate limit practice by ID or name",
+
+		ReadContext: dataSourceRateLimitPracticeRead,
+
+		Schema: dataSourceSchemaFromResourceSchema(resources.ResourceRateLimitPractice().Schema),
+	}
+}
+
+func dataSourceRateLimitPracticeRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := meta.(*api.Client)
+
+	id, err := lookupID(d, func(name string) (string, error) {
+		return objects.FindPracticeIDByName(ctx, c, name, models.PracticeTypeRateLimit)
+	})
+	if err != nil {
+		return utils.DiagError("unable to find RateLimitPractice", err, diags)
+	}
+
+	practice, err := ratelimitpractice.GetRateLimitPractice(ctx, c, id, true)
+	if err != nil {
+		return utils.DiagError("unable to perform RateLimitPractice data source Read", err, diags)
+	}
+
+	if err := notFoundIfEmptyID(practice.ID, id); err != nil {
+		return utils.DiagError("unable to perform RateLimitPractice data source Read", err, diags)
+	}
+
+	if err := ratelimitpractice.ReadRateLimitPracticeToResourceData(practice, d); err != nil {
+		return utils.DiagError("unable to perform RateLimitPractice data source read to state file", err, diags)
+	}
+
+	return diags
+}
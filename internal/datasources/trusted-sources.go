@@ -0,0 +1,52 @@
+package datasources
+
+import (
+	"context"
+
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/api"
+	models "github.com/CheckPointSW/terraform-provider-infinity-next/internal/models/objects"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources/objects"
+	trustedsources "github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources/trusted-sources"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceTrustedSources() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to get an existing trusted sources behavior by ID or name",
+
+		ReadContext: dataSourceTrustedSourcesRead,
+
+		Schema: dataSourceSchemaFromResourceSchema(resources.ResourceTrustedSources().Schema),
+	}
+}
+
+func dataSourceTrustedSourcesRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := meta.(*api.Client)
+
+	id, err := lookupID(d, func(name string) (string, error) {
+		return objects.FindBehaviorIDByName(ctx, c, name, models.BehaviorTypeTrustedSources)
+	})
+	if err != nil {
+		return utils.DiagError("unable to find TrustedSources", err, diags)
+	}
+
+	behavior, err := trustedsources.GetTrustedSourceBehavior(ctx, c, id)
+	if err != nil {
+		return utils.DiagError("unable to perform TrustedSources data source Read", err, diags)
+	}
+
+	if err := notFoundIfEmptyID(behavior.ID, id); err != nil {
+		return utils.DiagError("unable to perform TrustedSources data source Read", err, diags)
+	}
+
+	if err := trustedsources.ReadTrustedSourceBehaviorToResourceData(behavior, d); err != nil {
+		return utils.DiagError("unable to perform TrustedSources data source read to state file", err, diags)
+	}
+
+	return diags
+}
@@ -0,0 +1,52 @@
+package datasources
+
+import (
+	"context"
+
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/api"
+	models "github.com/CheckPointSW/terraform-provider-infinity-next/internal/models/objects"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources/objects"
+	webapipractice "github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources/web-api-practice"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceWebAPIPractice() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to get an existing web API practice by ID or name",
+
+		ReadContext: dataSourceWebAPIPracticeRead,
+
+		Schema: dataSourceSchemaFromResourceSchema(resources.ResourceWebAPIPractice().Schema),
+	}
+}
+
+func dataSourceWebAPIPracticeRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := meta.(*api.Client)
+
+	id, err := lookupID(d, func(name string) (string, error) {
+		return objects.FindPracticeIDByName(ctx, c, name, models.PracticeTypeWebAPI)
+	})
+	if err != nil {
+		return utils.DiagError("unable to find WebAPIPractice", err, diags)
+	}
+
+	practice, err := webapipractice.GetWebAPIPractice(ctx, c, id)
+	if err != nil {
+		return utils.DiagError("unable to perform WebAPIPractice data source Read", err, diags)
+	}
+
+	if err := notFoundIfEmptyID(practice.ID, id); err != nil {
+		return utils.DiagError("unable to perform WebAPIPractice data source Read", err, diags)
+	}
+
+	if err := webapipractice.ReadWebAPIPracticeToResourceData(practice, d); err != nil {
+		return utils.DiagError("unable to perform WebAPIPractice data source read to state file", err, diags)
+	}
+
+	return diags
+}
@@ -0,0 +1,52 @@
+package datasources
+
+import (
+	"context"
+
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/api"
+	models "github.com/CheckPointSW/terraform-provider-infinity-next/internal/models/objects"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources/objects"
+	webappasset "github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources/web-app-asset"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceWebAppAsset() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to get an existing web application asset by ID or name",
+
+		ReadContext: dataSourceWebAppAssetRead,
+
+		Schema: dataSourceSchemaFromResourceSchema(resources.ResourceWebAppAsset().Schema),
+	}
+}
+
+func dataSourceWebAppAssetRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := meta.(*api.Client)
+
+	id, err := lookupID(d, func(name string) (string, error) {
+		return objects.FindAssetIDByName(ctx, c, name, models.AssetTypeWebApplication)
+	})
+	if err != nil {
+		return utils.DiagError("unable to find WebAppAsset", err, diags)
+	}
+
+	asset, err := webappasset.GetWebApplicationAsset(ctx, c, id)
+	if err != nil {
+		return utils.DiagError("unable to perform WebAppAsset data source Read", err, diags)
+	}
+
+	if err := notFoundIfEmptyID(asset.ID, id); err != nil {
+		return utils.DiagError("unable to perform WebAppAsset data source Read", err, diags)
+	}
+
+	if err := webappasset.ReadWebApplicationAssetToResourceData(asset, d); err != nil {
+		return utils.DiagError("unable to perform WebAppAsset data source read to state file", err, diags)
+	}
+
+	return diags
+}
@@ -0,0 +1,114 @@
+package datasources
+
+import (
+	"context"
+
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/api"
+	models "github.com/CheckPointSW/terraform-provider-infinity-next/internal/models/objects"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources/objects"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceWebAppAssets() *schema.Resource {
+	dataSourceSchema := listDataSourceSchema()
+	dataSourceSchema["state"] = stringSetSchema("Return only assets in one of these states: Active, Suggested or InActive",
+		models.AssetStateActive, models.AssetStateSuggested, models.AssetStateInactive)
+	dataSourceSchema["tags"] = &schema.Schema{
+		Type:        schema.TypeMap,
+		Description: "Return only assets that have all of these tags, mapping tag key to tag value",
+		Optional:    true,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+	dataSourceSchema["assets"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "The matching web application assets",
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"id": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"name": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"asset_type": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"state": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"upstream_url": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"tags": {
+					Type:     schema.TypeMap,
+					Computed: true,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+			},
+		},
+	}
+
+	return &schema.Resource{
+		Description: "Use this data source to list the web application assets that match the given filters",
+
+		ReadContext: dataSourceWebAppAssetsRead,
+
+		Schema: dataSourceSchema,
+	}
+}
+
+func dataSourceWebAppAssetsRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := meta.(*api.Client)
+
+	matchName, err := nameMatcher(d)
+	if err != nil {
+		return utils.DiagError("invalid name_regex", err, diags)
+	}
+
+	states := stringSetFromResourceData(d, "state")
+	wantTags := make(map[string]string)
+	for k, v := range d.Get("tags").(map[string]any) {
+		wantTags[k] = v.(string)
+	}
+
+	assets, err := objects.GetAssets(ctx, c)
+	if err != nil {
+		return utils.DiagError("unable to perform WebAppAssets data source Read", err, diags)
+	}
+
+	// the list queries return all objects, so they are filtered here
+	assets = utils.Filter(assets, func(asset models.DisplayAsset) bool {
+		return asset.AssetType == models.AssetTypeWebApplication &&
+			matchName(asset.Name) &&
+			matchesAny(asset.State, states) &&
+			hasTags(asset.Tags, wantTags)
+	})
+
+	return setListResult(d, "assets", assets.ToSchema(), utils.Map(assets, func(asset models.DisplayAsset) string { return asset.ID }), diags)
+}
+
+// hasTags reports whether tags contains every key-value pair of wantTags
+func hasTags(tags models.Tags, wantTags map[string]string) bool {
+	tagsMap := tags.ToMap()
+	for k, v := range wantTags {
+		if value, ok := tagsMap[k]; !ok || value != v {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,52 @@
+package datasources
+
+import (
+	"context"
+
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/api"
+	models "github.com/CheckPointSW/terraform-provider-infinity-next/internal/models/objects"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources/objects"
+	webapppractice "github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources/web-app-practice"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceWebAppPractice() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to get an existing web application practice by ID or name",
+
+		ReadContext: dataSourceWebAppPracticeRead,
+
+		Schema: dataSourceSchemaFromResourceSchema(resources.ResourceWebAppPractice().Schema),
+	}
+}
+
+func dataSourceWebAppPracticeRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := meta.(*api.Client)
+
+	id, err := lookupID(d, func(name string) (string, error) {
+		return objects.FindPracticeIDByName(ctx, c, name, models.PracticeTypeWebApplication)
+	})
+	if err != nil {
+		return utils.DiagError("unable to find WebAppPractice", err, diags)
+	}
+
+	practice, err := webapppractice.GetWebApplicationPractice(ctx, c, id)
+	if err != nil {
+		return utils.DiagError("unable to perform WebAppPractice data source Read", err, diags)
+	}
+
+	if err := notFoundIfEmptyID(practice.ID, id); err != nil {
+		return utils.DiagError("unable to perform WebAppPractice data source Read", err, diags)
+	}
+
+	if err := webapppractice.ReadWebApplicationPracticeToResourceData(practice, d); err != nil {
+		return utils.DiagError("unable to perform WebAppPractice data source read to state file", err, diags)
+	}
+
+	return diags
+}
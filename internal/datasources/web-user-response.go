@@ -0,0 +1,52 @@
+package datasources
+
+import (
+	"context"
+
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/api"
+	models "github.com/CheckPointSW/terraform-provider-infinity-next/internal/models/objects"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources/objects"
+	webuserresponse "github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources/web-user-response"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceWebUserResponse() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to get an existing web user response behavior by ID or name",
+
+		ReadContext: dataSourceWebUserResponseRead,
+
+		Schema: dataSourceSchemaFromResourceSchema(resources.ResourceWebUserResponse().Schema),
+	}
+}
+
+func dataSourceWebUserResponseRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := meta.(*api.Client)
+
+	id, err := lookupID(d, func(name string) (string, error) {
+		return objects.FindBehaviorIDByName(ctx, c, name, models.BehaviorTypeWebUserResponse)
+	})
+	if err != nil {
+		return utils.DiagError("unable to find WebUserResponse", err, diags)
+	}
+
+	behavior, err := webuserresponse.GetWebUserResponseBehavior(ctx, c, id)
+	if err != nil {
+		return utils.DiagError("unable to perform WebUserResponse data source Read", err, diags)
+	}
+
+	if err := notFoundIfEmptyID(behavior.ID, id); err != nil {
+		return utils.DiagError("unable to perform WebUserResponse data source Read", err, diags)
+	}
+
+	if err := webuserresponse.ReadWebUserResponseBehaviorToResourceData(behavior, d); err != nil {
+		return utils.DiagError("unable to perform WebUserResponse data source read to state file", err, diags)
+	}
+
+	return diags
+}
@@ -0,0 +1,323 @@
+// Package export exports the objects of a tenant as a Terraform configuration
+//
+// Each object is read with the same queries and converted with the same functions as the Read of its resource, so the
+// exported configuration matches the state the provider would read after importing it
+package export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/api"
+	models "github.com/CheckPointSW/terraform-provider-infinity-next/internal/models/objects"
+	ratelimitmodels "github.com/CheckPointSW/terraform-provider-infinity-next/internal/models/rate-limit-practice"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources"
+	appsecgatewayprofile "github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources/appsec-gateway-profile"
+	dockerprofile "github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources/docker-profile"
+	embeddedprofile "github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources/embedded-profile"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources/exceptions"
+	kubernetesprofile "github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources/kubernetes-profile"
+	logtrigger "github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources/log-trigger"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources/objects"
+	ratelimitpractice "github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources/rate-limit-practice"
+	trustedsources "github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources/trusted-sources"
+	webapiasset "github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources/web-api-asset"
+	webapipractice "github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources/web-api-practice"
+	webappasset "github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources/web-app-asset"
+	webapppractice "github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources/web-app-practice"
+	webuserresponse "github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources/web-user-response"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ImportsFile is the name of the file with the import blocks of all exported resources
+const ImportsFile = "imports.tf"
+
+// readFunc reads the object with the given ID into the ResourceData of its resource
+type readFunc func(ctx context.Context, c *api.Client, id string, d *schema.ResourceData) error
+
+// exporter exports the objects of a type as resources of a resource type
+type exporter struct {
+	kind         string
+	subType      string
+	resourceType string
+	file         string
+	resource     func() *schema.Resource
+	read         readFunc
+}
+
+// exporters are the exporters of the supported object types
+var exporters = []exporter{
+	{
+		kind: "Asset", subType: models.AssetTypeWebApplication, resourceType: "inext_web_app_asset", file: "assets.tf",
+		resource: resources.ResourceWebAppAsset, read: reader(webappasset.GetWebApplicationAsset, webappasset.ReadWebApplicationAssetToResourceData),
+	},
+	{
+		kind: "Asset", subType: models.AssetTypeWebAPI, resourceType: "inext_web_api_asset", file: "assets.tf",
+		resource: resources.ResourceWebAPIAsset, read: reader(webapiasset.GetWebAPIAsset, webapiasset.ReadWebAPIAssetToResourceData),
+	},
+	{
+		kind: "Profile", subType: models.ProfileTypeAppSecGateway, resourceType: "inext_appsec_gateway_profile", file: "profiles.tf",
+		resource: resources.ResourceAppSecGatewayProfile,
+		read:     reader(appsecgatewayprofile.GetCloudGuardAppSecGatewayProfile, appsecgatewayprofile.ReadCloudGuardAppSecGatewayProfileToResourceData),
+	},
+	{
+		kind: "Profile", subType: models.ProfileTypeDocker, resourceType: "inext_docker_profile", file: "profiles.tf",
+		resource: resources.ResourceDockerProfile, read: reader(dockerprofile.GetDockerProfile, dockerprofile.ReadDockerProfileToResourceData),
+	},
+	{
+		kind: "Profile", subType: models.ProfileTypeEmbedded, resourceType: "inext_embedded_profile", file: "profiles.tf",
+		resource: resources.ResourceEmbeddedProfile, read: reader(embeddedprofile.GetEmbeddedProfile, embeddedprofile.ReadEmbeddedProfileToResourceData),
+	},
+	{
+		kind: "Profile", subType: models.ProfileTypeKubernetes, resourceType: "inext_kubernetes_profile", file: "profiles.tf",
+		resource: resources.ResourceKubernetesProfile, read: reader(kubernetesprofile.GetKubernetesProfile, kubernetesprofile.ReadKubernetesProfileToResourceData),
+	},
+	{
+		kind: "Practice", subType: models.PracticeTypeWebApplication, resourceType: "inext_web_app_practice", file: "practices.tf",
+		resource: resources.ResourceWebAppPractice, read: reader(webapppractice.GetWebApplicationPractice, webapppractice.ReadWebApplicationPracticeToResourceData),
+	},
+	{
+		kind: "Practice", subType: models.PracticeTypeWebAPI, resourceType: "inext_web_api_practice", file: "practices.tf",
+		resource: resources.ResourceWebAPIPractice, read: reader(webapipractice.GetWebAPIPractice, webapipractice.ReadWebAPIPracticeToResourceData),
+	},
+	{
+		kind: "Practice", subType: models.PracticeTypeRateLimit, resourceType: "inext_rate_limit_practice", file: "practices.tf",
+		resource: resources.ResourceRateLimitPractice,
+		read: reader(func(ctx context.Context, c *api.Client, id string) (ratelimitmodels.RateLimitPractice, error) {
+			return ratelimitpractice.GetRateLimitPractice(ctx, c, id, true)
+		}, ratelimitpractice.ReadRateLimitPracticeToResourceData),
+	},
+	{
+		kind: "Behavior", subType: models.BehaviorTypeException, resourceType: "inext_exceptions", file: "behaviors.tf",
+		resource: resources.ResourceExceptions, read: reader(exceptions.GetExceptionBehavior, exceptions.ReadExceptionBehaviorToResourceData),
+	},
+	{
+		kind: "Behavior", subType: models.BehaviorTypeTrustedSources, resourceType: "inext_trusted_sources", file: "behaviors.tf",
+		resource: resources.ResourceTrustedSources, read: reader(trustedsources.GetTrustedSourceBehavior, trustedsources.ReadTrustedSourceBehaviorToResourceData),
+	},
+	{
+		kind: "Behavior", subType: models.BehaviorTypeWebUserResponse, resourceType: "inext_web_user_response", file: "behaviors.tf",
+		resource: resources.ResourceWebUserResponse, read: reader(webuserresponse.GetWebUserResponseBehavior, webuserresponse.ReadWebUserResponseBehaviorToResourceData),
+	},
+	{
+		kind: "Trigger", subType: models.TriggerTypeLog, resourceType: "inext_log_trigger", file: "triggers.tf",
+		resource: resources.ResourceLogTrigger, read: reader(logtrigger.GetLogTrigger, logtrigger.ReadLogTriggerToResourceData),
+	},
+}
+
+// reader returns a readFunc that gets an object and reads it into ResourceData with the functions of its resource
+func reader[T any](get func(ctx context.Context, c *api.Client, id string) (T, error), toResourceData func(T, *schema.ResourceData) error) readFunc {
+	return func(ctx context.Context, c *api.Client, id string, d *schema.ResourceData) error {
+		obj, err := get(ctx, c, id)
+		if err != nil {
+			return err
+		}
+
+		return toResourceData(obj, d)
+	}
+}
+
+// Resource is an exported object, as a resource of the exported configuration
+type Resource struct {
+	// Type is the type of the resource, e.g. inext_web_app_asset
+	Type string
+
+	// Name is the name of the resource in the configuration, derived from the name of the object
+	Name string
+
+	// ID and ObjectName are the ID and the name of the object
+	ID         string
+	ObjectName string
+
+	// File is the name of the file the resource is written to
+	File string
+}
+
+// Address returns the address of the resource in the configuration, e.g. inext_web_app_asset.my_asset
+func (r Resource) Address() string {
+	return r.Type + "." + r.Name
+}
+
+// Result is an exported configuration
+type Result struct {
+	// Resources are the exported resources, ordered by their type and name
+	Resources []Resource
+
+	// Skipped are the objects that were not exported since the provider has no resource for their type
+	Skipped []string
+
+	// Files are the contents of the exported .tf files by their names, including ImportsFile
+	Files map[string][]byte
+}
+
+// object is an object of the tenant as it is listed by the get<kind>s queries
+type object struct {
+	id       string
+	name     string
+	kind     string
+	subType  string
+	exporter *exporter
+	data     *schema.ResourceData
+}
+
+// Export reads all the objects of the tenant that have a resource and returns them as a Terraform configuration
+// references to other exported objects are written as references to their resources, and an import block is written
+// for each resource so a single plan adopts all of them
+func Export(ctx context.Context, c *api.Client) (*Result, error) {
+	result := &Result{}
+	listed, err := listObjects(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	var objs []*object
+	for _, obj := range listed {
+		if obj.exporter == nil {
+			result.Skipped = append(result.Skipped, fmt.Sprintf("%s %q (ID %s, type %s)", obj.kind, obj.name, obj.id, obj.subType))
+			continue
+		}
+
+		r := obj.exporter.resource()
+		obj.data = r.Data(nil)
+		err := obj.exporter.read(ctx, c, obj.id, obj.data)
+		if errors.Is(err, api.ErrorNotFound) {
+			// the object was deleted in the session
+			continue
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s %q (ID %s): %w", obj.exporter.resourceType, obj.name, obj.id, err)
+		}
+
+		objs = append(objs, obj)
+	}
+
+	sort.SliceStable(objs, func(i, j int) bool {
+		if objs[i].exporter.resourceType != objs[j].exporter.resourceType {
+			return objs[i].exporter.resourceType < objs[j].exporter.resourceType
+		}
+
+		return objs[i].name < objs[j].name
+	})
+
+	names := map[string]struct{}{}
+	for _, obj := range objs {
+		name := resourceName(obj.exporter.kind, obj.name)
+		for i := 2; ; i++ {
+			if _, ok := names[obj.exporter.resourceType+"."+name]; !ok {
+				break
+			}
+
+			name = fmt.Sprintf("%s_%d", resourceName(obj.exporter.kind, obj.name), i)
+		}
+
+		names[obj.exporter.resourceType+"."+name] = struct{}{}
+		result.Resources = append(result.Resources, Resource{
+			Type:       obj.exporter.resourceType,
+			Name:       name,
+			ID:         obj.id,
+			ObjectName: obj.name,
+			File:       obj.exporter.file,
+		})
+	}
+
+	files := newFiles(result.Resources)
+	for i, obj := range objs {
+		files.writeResource(result.Resources[i], obj.exporter.resource(), obj.data)
+	}
+
+	result.Files = files.bytes()
+	return result, nil
+}
+
+// listObjects lists the objects of all kinds, with the exporter of their type or nil if their type isn't supported
+func listObjects(ctx context.Context, c *api.Client) ([]*object, error) {
+	var ret []*object
+	add := func(kind, subType, id, name string) {
+		obj := &object{id: id, name: name, kind: kind, subType: subType}
+		for i := range exporters {
+			if exporters[i].kind == kind && exporters[i].subType == subType {
+				obj.exporter = &exporters[i]
+			}
+		}
+
+		ret = append(ret, obj)
+	}
+
+	assets, err := objects.GetAssets(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, asset := range assets {
+		add("Asset", asset.AssetType, asset.ID, asset.Name)
+	}
+
+	profiles, err := objects.GetProfiles(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, profile := range profiles {
+		add("Profile", profile.ProfileType, profile.ID, profile.Name)
+	}
+
+	practices, err := objects.GetPractices(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, practice := range practices {
+		add("Practice", practice.PracticeType, practice.ID, practice.Name)
+	}
+
+	behaviors, err := objects.GetBehaviors(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, behavior := range behaviors {
+		add("Behavior", behavior.BehaviorType, behavior.ID, behavior.Name)
+	}
+
+	triggers, err := objects.GetTriggers(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, trigger := range triggers {
+		add("Trigger", trigger.TriggerType, trigger.ID, trigger.Name)
+	}
+
+	return ret, nil
+}
+
+// resourceName returns a valid name for the resource of an object, derived from the name of the object
+// e.g. "My App (prod)" becomes my_app_prod
+func resourceName(kind, name string) string {
+	var b strings.Builder
+	underscore := false
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if underscore && b.Len() > 0 {
+				b.WriteByte('_')
+			}
+
+			b.WriteRune(r)
+			underscore = false
+			continue
+		}
+
+		underscore = true
+	}
+
+	ret := b.String()
+	if ret == "" || (ret[0] >= '0' && ret[0] <= '9') {
+		ret = strings.ToLower(kind) + "_" + ret
+	}
+
+	return strings.TrimSuffix(ret, "_")
+}
@@ -0,0 +1,71 @@
+package export_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/apitest"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/export"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+func TestExport(t *testing.T) {
+	server := apitest.NewServer(t)
+	c := server.Client(t)
+
+	trigger := apitest.Apply(t, resources.ResourceLogTrigger(), nil, map[string]any{"name": "My Trigger", "verbosity": "Extended"}, c)
+	apitest.Apply(t, resources.ResourceLogTrigger(), nil, map[string]any{"name": "my-trigger"}, c)
+	practice := apitest.Apply(t, resources.ResourceWebAppPractice(), nil, map[string]any{"name": "practice"}, c)
+	asset := apitest.Apply(t, resources.ResourceWebAppAsset(), nil, map[string]any{
+		"name": "asset",
+		"urls": []any{"http://host/path"},
+		"practice": []any{map[string]any{
+			"id":        practice.ID,
+			"main_mode": "Prevent",
+			"triggers":  []any{trigger.ID},
+		}},
+	}, c)
+
+	result, err := export.Export(context.Background(), c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var addresses []string
+	for _, r := range result.Resources {
+		addresses = append(addresses, r.Address())
+	}
+
+	want := "inext_log_trigger.my_trigger,inext_log_trigger.my_trigger_2,inext_web_app_asset.asset,inext_web_app_practice.practice"
+	if strings.Join(addresses, ",") != want {
+		t.Fatalf("unexpected exported resources: %v", addresses)
+	}
+
+	for name, content := range result.Files {
+		if _, diags := hclsyntax.ParseConfig(content, name, hcl.InitialPos); diags.HasErrors() {
+			t.Fatalf("invalid %s: %v\n%s", name, diags, content)
+		}
+	}
+
+	assets := string(result.Files["assets.tf"])
+	for _, expected := range []string{
+		`resource "inext_web_app_asset" "asset"`,
+		`id        = inext_web_app_practice.practice.id`,
+		`triggers  = [inext_log_trigger.my_trigger.id]`,
+	} {
+		if !strings.Contains(assets, expected) {
+			t.Fatalf("expected %q in assets.tf:\n%s", expected, assets)
+		}
+	}
+
+	if triggers := string(result.Files["triggers.tf"]); !strings.Contains(triggers, `verbosity = "Extended"`) || strings.Count(triggers, "verbosity") != 1 {
+		t.Fatalf("expected only the non-default verbosity in triggers.tf:\n%s", triggers)
+	}
+
+	if imports := string(result.Files[export.ImportsFile]); !strings.Contains(imports, "to = inext_web_app_asset.asset\n  id = \""+asset.ID+"\"") {
+		t.Fatalf("expected an import block of the asset:\n%s", imports)
+	}
+}
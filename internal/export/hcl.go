@@ -0,0 +1,225 @@
+package export
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// files builds the exported .tf files
+type files struct {
+	files map[string]*hclwrite.File
+
+	// references are the traversals of the id attributes of the exported resources, by the IDs of their objects
+	references map[string]hcl.Traversal
+}
+
+func newFiles(exported []Resource) *files {
+	f := &files{files: map[string]*hclwrite.File{}, references: map[string]hcl.Traversal{}}
+	imports := f.file(ImportsFile).Body()
+	for _, r := range exported {
+		address := hcl.Traversal{hcl.TraverseRoot{Name: r.Type}, hcl.TraverseAttr{Name: r.Name}}
+		f.references[r.ID] = append(address, hcl.TraverseAttr{Name: "id"})
+
+		if len(imports.Blocks()) > 0 {
+			imports.AppendNewline()
+		}
+
+		block := imports.AppendNewBlock("import", nil).Body()
+		block.SetAttributeTraversal("to", address)
+		block.SetAttributeValue("id", cty.StringVal(r.ID))
+	}
+
+	return f
+}
+
+func (f *files) file(name string) *hclwrite.File {
+	if _, ok := f.files[name]; !ok {
+		f.files[name] = hclwrite.NewEmptyFile()
+	}
+
+	return f.files[name]
+}
+
+// writeResource writes the resource block of an exported object, with the values it was read into d
+func (f *files) writeResource(exported Resource, r *schema.Resource, d *schema.ResourceData) {
+	body := f.file(exported.File).Body()
+	if len(body.Blocks()) > 0 {
+		body.AppendNewline()
+	}
+
+	values := map[string]any{}
+	for key := range r.Schema {
+		values[key] = d.Get(key)
+	}
+
+	block := body.AppendNewBlock("resource", []string{exported.Type, exported.Name})
+	f.writeBody(block.Body(), r.Schema, values, exported.ID)
+}
+
+// writeBody writes the configurable attributes and nested blocks of the given values, attributes are written before blocks
+// and the name attribute first. Attributes that are not set or have their default value are omitted
+func (f *files) writeBody(body *hclwrite.Body, schemaMap map[string]*schema.Schema, values map[string]any, selfID string) {
+	keys := make([]string, 0, len(schemaMap))
+	for key, s := range schemaMap {
+		if s.Required || s.Optional {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i] == "name" || keys[j] == "name" {
+			return keys[i] == "name"
+		}
+
+		return keys[i] < keys[j]
+	})
+
+	var blocks []string
+	for _, key := range keys {
+		s := schemaMap[key]
+		if _, ok := s.Elem.(*schema.Resource); ok {
+			blocks = append(blocks, key)
+			continue
+		}
+
+		value := values[key]
+		if set, ok := value.(*schema.Set); ok {
+			value = sortedList(set.List())
+		}
+
+		if !s.Required && isDefault(s, value) {
+			continue
+		}
+
+		body.SetAttributeRaw(key, f.tokens(value, selfID))
+	}
+
+	for _, key := range blocks {
+		elem := schemaMap[key].Elem.(*schema.Resource)
+		for _, item := range list(values[key]) {
+			itemValues, ok := item.(map[string]any)
+			if !ok {
+				continue
+			}
+
+			f.writeBody(body.AppendNewBlock(key, nil).Body(), elem.Schema, itemValues, selfID)
+		}
+	}
+}
+
+// tokens returns the expression of a value, IDs of other exported objects are written as references to their resources
+func (f *files) tokens(value any, selfID string) hclwrite.Tokens {
+	switch v := value.(type) {
+	case string:
+		if reference, ok := f.references[v]; ok && v != selfID {
+			return hclwrite.TokensForTraversal(reference)
+		}
+
+		return hclwrite.TokensForValue(cty.StringVal(v))
+	case bool:
+		return hclwrite.TokensForValue(cty.BoolVal(v))
+	case int:
+		return hclwrite.TokensForValue(cty.NumberIntVal(int64(v)))
+	case float64:
+		return hclwrite.TokensForValue(cty.NumberFloatVal(v))
+	case *schema.Set:
+		return f.tokens(sortedList(v.List()), selfID)
+	case []any:
+		elems := make([]hclwrite.Tokens, 0, len(v))
+		for _, elem := range v {
+			elems = append(elems, f.tokens(elem, selfID))
+		}
+
+		return hclwrite.TokensForTuple(elems)
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+		attrs := make([]hclwrite.ObjectAttrTokens, 0, len(v))
+		for _, key := range keys {
+			attrs = append(attrs, hclwrite.ObjectAttrTokens{
+				Name:  hclwrite.TokensForValue(cty.StringVal(key)),
+				Value: f.tokens(v[key], selfID),
+			})
+		}
+
+		return hclwrite.TokensForObject(attrs)
+	}
+
+	return hclwrite.TokensForValue(cty.NullVal(cty.DynamicPseudoType))
+}
+
+// isDefault reports whether an optional attribute has its default value, or is not set if it has no default
+// an empty string is never written, since the provider can't tell it from an attribute that is not set
+func isDefault(s *schema.Schema, value any) bool {
+	if value == "" {
+		return true
+	}
+
+	if s.Default != nil {
+		return reflect.DeepEqual(value, s.Default)
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return true
+	case []any:
+		return len(v) == 0
+	case map[string]any:
+		return len(v) == 0
+	}
+
+	return reflect.ValueOf(value).IsZero()
+}
+
+// list returns the elements of a list or set value
+func list(value any) []any {
+	switch v := value.(type) {
+	case *schema.Set:
+		return v.List()
+	case []any:
+		return v
+	}
+
+	return nil
+}
+
+// sortedList returns the elements of a set of strings sorted, so sets are written in a stable order
+// sets of other values are returned in the order of the set
+func sortedList(values []any) []any {
+	strs := make([]string, 0, len(values))
+	for _, value := range values {
+		str, ok := value.(string)
+		if !ok {
+			return values
+		}
+
+		strs = append(strs, str)
+	}
+
+	sort.Strings(strs)
+	ret := make([]any, 0, len(strs))
+	for _, str := range strs {
+		ret = append(ret, str)
+	}
+
+	return ret
+}
+
+// bytes returns the formatted contents of the files by their names
+func (f *files) bytes() map[string][]byte {
+	ret := make(map[string][]byte, len(f.files))
+	for name, file := range f.files {
+		ret[name] = hclwrite.Format(file.Bytes())
+	}
+
+	return ret
+}
@@ -27,35 +27,40 @@ type ExceptionBehavior struct {
 	Exceptions ExceptionsObjects `json:"exceptions"`
 }
 
-func (exceptions ExceptionsObjects) ToSchema() []SchemaExceptionObject {
+func (exceptions ExceptionsObjects) ToSchema() ([]SchemaExceptionObject, error) {
 	var ret []SchemaExceptionObject
 	for _, exception := range exceptions {
-		ret = append(ret, exception.ToSchema())
+		schemaException, err := exception.ToSchema()
+		if err != nil {
+			return nil, err
+		}
+
+		ret = append(ret, schemaException)
 	}
 
-	return ret
+	return ret, nil
 }
 
-func (exception ExceptionObject) ToSchema() SchemaExceptionObject {
+func (exception ExceptionObject) ToSchema() (SchemaExceptionObject, error) {
 	var ret SchemaExceptionObject
 
 	ret.ID = exception.ID
 	ret.Comment = exception.Comment
 	var action Action
 	if err := json.Unmarshal([]byte(exception.Actions[0].Action), &action); err != nil {
-		fmt.Printf("failed to unmarshal action string to struct: %+v", err)
+		return ret, fmt.Errorf("failed to unmarshal action of exception %s: %w", exception.ID, err)
 	}
 
 	ret.Action = action.Value
 	ret.ActionID = exception.Actions[0].ID
 	var match Match
 	if err := json.Unmarshal([]byte(exception.Match), &match); err != nil {
-		fmt.Printf("failed to unmarshal match string to struct: %+v", err)
+		return ret, fmt.Errorf("failed to unmarshal match of exception %s: %w", exception.ID, err)
 	}
 
 	ret.Match = []SchemaMatchExpression{MatchToSchema(match)}
 
-	return ret
+	return ret, nil
 }
 
 func MatchToSchema(match Match) SchemaMatchExpression {
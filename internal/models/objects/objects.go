@@ -0,0 +1,109 @@
+package models
+
+const (
+	AssetTypeWebApplication = "WebApplication"
+	AssetTypeWebAPI         = "WebAPI"
+
+	ProfileTypeAppSecGateway = "CloudGuardAppSecGateway"
+	ProfileTypeEmbedded      = "Embedded"
+	ProfileTypeDocker        = "Docker"
+	ProfileTypeKubernetes    = "Kubernetes"
+
+	PracticeTypeWebApplication = "WebApplication"
+	PracticeTypeWebAPI         = "WebAPI"
+	PracticeTypeRateLimit      = "RateLimit"
+
+	BehaviorTypeException       = "Exception"
+	BehaviorTypeTrustedSources  = "TrustedSource"
+	BehaviorTypeWebUserResponse = "WebUserResponse"
+
+	TriggerTypeLog = "Log"
+
+	AssetStateActive    = "Active"
+	AssetStateSuggested = "Suggested"
+	AssetStateInactive  = "InActive"
+
+	VisibilityShared = "Shared"
+	VisibilityLocal  = "Local"
+)
+
+// Tag represents a tag of an asset as it is returned from the getAssets query
+type Tag struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type Tags []Tag
+
+// DisplayAsset represents an asset as it is returned from the getAssets query
+type DisplayAsset struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	AssetType   string `json:"assetType"`
+	State       string `json:"state,omitempty"`
+	UpstreamURL string `json:"upstreamURL,omitempty"`
+	Tags        Tags   `json:"tags,omitempty"`
+}
+
+type DisplayAssets []DisplayAsset
+
+// DisplayAssetsResponse represents the response of the getAssets query
+type DisplayAssetsResponse struct {
+	Status string        `json:"status"`
+	Assets DisplayAssets `json:"assets"`
+}
+
+// DisplayProfile represents a profile as it is returned from the getProfiles query
+type DisplayProfile struct {
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	ProfileType    string `json:"profileType"`
+	ProfileSubType string `json:"profileSubType,omitempty"`
+}
+
+type DisplayProfiles []DisplayProfile
+
+// DisplayPractice represents a practice as it is returned from the getPractices query
+type DisplayPractice struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	PracticeType string `json:"practiceType"`
+	Category     string `json:"category,omitempty"`
+	Visibility   string `json:"visibility,omitempty"`
+	Default      bool   `json:"default"`
+}
+
+type DisplayPractices []DisplayPractice
+
+// DisplayBehavior represents a behavior as it is returned from the getBehaviors query
+type DisplayBehavior struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	BehaviorType string `json:"behaviorType"`
+	Visibility   string `json:"visibility,omitempty"`
+}
+
+type DisplayBehaviors []DisplayBehavior
+
+// DisplayTrigger represents a trigger as it is returned from the getTriggers query
+type DisplayTrigger struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	TriggerType string `json:"triggerType"`
+}
+
+type DisplayTriggers []DisplayTrigger
+
+// ObjectStatusPublished is the status of an object without unpublished changes
+// other statuses, such as Created, Updated and Deleted, mark objects with changes in the session
+const ObjectStatusPublished = "Published"
+
+// StatusObject represents an object with its status as it is returned from the get<kind>s queries
+type StatusObject struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	ObjectStatus string `json:"objectStatus"`
+	ModifiedBy   string `json:"modifiedBy,omitempty"`
+}
+
+type StatusObjects []StatusObject
@@ -0,0 +1,107 @@
+package models
+
+// SchemaAsset represents an asset in the assets attribute of a list data source
+type SchemaAsset struct {
+	ID          string            `json:"id"`
+	Name        string            `json:"name"`
+	AssetType   string            `json:"asset_type"`
+	State       string            `json:"state"`
+	UpstreamURL string            `json:"upstream_url"`
+	Tags        map[string]string `json:"tags"`
+}
+
+// SchemaProfile represents a profile in the profiles attribute of a list data source
+type SchemaProfile struct {
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	ProfileType    string `json:"profile_type"`
+	ProfileSubType string `json:"profile_sub_type"`
+}
+
+// SchemaPractice represents a practice in the practices attribute of a list data source
+type SchemaPractice struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	PracticeType string `json:"practice_type"`
+	Category     string `json:"category"`
+	Visibility   string `json:"visibility"`
+	Default      bool   `json:"default"`
+}
+
+// SchemaBehavior represents a behavior in the behaviors attribute of a list data source
+type SchemaBehavior struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	BehaviorType string `json:"behavior_type"`
+	Visibility   string `json:"visibility"`
+}
+
+// ToMap returns the tags as a map from tag key to tag value
+func (tags Tags) ToMap() map[string]string {
+	ret := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		ret[tag.Key] = tag.Value
+	}
+
+	return ret
+}
+
+func (assets DisplayAssets) ToSchema() []SchemaAsset {
+	ret := make([]SchemaAsset, len(assets))
+	for i, asset := range assets {
+		ret[i] = SchemaAsset{
+			ID:          asset.ID,
+			Name:        asset.Name,
+			AssetType:   asset.AssetType,
+			State:       asset.State,
+			UpstreamURL: asset.UpstreamURL,
+			Tags:        asset.Tags.ToMap(),
+		}
+	}
+
+	return ret
+}
+
+func (profiles DisplayProfiles) ToSchema() []SchemaProfile {
+	ret := make([]SchemaProfile, len(profiles))
+	for i, profile := range profiles {
+		ret[i] = SchemaProfile{
+			ID:             profile.ID,
+			Name:           profile.Name,
+			ProfileType:    profile.ProfileType,
+			ProfileSubType: profile.ProfileSubType,
+		}
+	}
+
+	return ret
+}
+
+func (practices DisplayPractices) ToSchema() []SchemaPractice {
+	ret := make([]SchemaPractice, len(practices))
+	for i, practice := range practices {
+		ret[i] = SchemaPractice{
+			ID:           practice.ID,
+			Name:         practice.Name,
+			PracticeType: practice.PracticeType,
+			Category:     practice.Category,
+			Visibility:   practice.Visibility,
+			Default:      practice.Default,
+		}
+	}
+
+	return ret
+}
+
+func (behaviors DisplayBehaviors) ToSchema() []SchemaBehavior {
+	ret := make([]SchemaBehavior, len(behaviors))
+	for i, behavior := range behaviors {
+		ret[i] = SchemaBehavior{
+			ID:           behavior.ID,
+			Name:         behavior.Name,
+			BehaviorType: behavior.BehaviorType,
+			Visibility:   behavior.Visibility,
+		}
+	}
+
+	return ret
+}
@@ -17,6 +17,14 @@ type EnforcePolicyResult struct {
 	ID string
 }
 
+// EnforceResult represents the outcome of an enforce operation that was waited for
+type EnforceResult struct {
+	TaskID string
+
+	// ProfileIDs are the IDs of the enforced profiles
+	ProfileIDs []string
+}
+
 // AsyncPublishResult represents the result of an async publish operation
 type AsyncPublishResult struct {
 	ID string
@@ -36,8 +44,9 @@ type TaskData struct {
 
 // TaskPublishData holds the publish validation result nested inside TaskData
 type TaskPublishData struct {
-	IsValid bool
-	Errors  []ValidationMessage
+	IsValid  bool
+	Errors   []ValidationMessage
+	Warnings []ValidationMessage
 }
 
 // HasPublishValidationErrors returns true if the task result contains publish validation errors
@@ -58,3 +67,17 @@ func (r *TaskResult) PublishValidationErrors() []string {
 
 	return msgs
 }
+
+// PublishValidationWarnings returns the publish validation warning messages
+func (r *TaskResult) PublishValidationWarnings() []string {
+	if r.TaskData == nil || r.TaskData.PublishData == nil {
+		return nil
+	}
+
+	msgs := make([]string, 0, len(r.TaskData.PublishData.Warnings))
+	for _, w := range r.TaskData.PublishData.Warnings {
+		msgs = append(msgs, w.Message)
+	}
+
+	return msgs
+}
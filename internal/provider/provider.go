@@ -2,9 +2,18 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
 
 	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/api"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/datasources"
 	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources/objects"
+	publishenforce "github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources/publish-enforce"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/utils"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/pkg/regions"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -17,31 +26,168 @@ func init() {
 }
 
 func Provider() *schema.Provider {
-	return &schema.Provider{
+	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"region": {
-				Description:      "The region where Infinity Policy operations will take place. Options are: eu, us, au, in, ae, ca",
+				Description:      "The region where Infinity Policy operations will take place. Options are: " + strings.Join(regions.PublicNames(), ", "),
 				Type:             schema.TypeString,
 				Optional:         true,
 				ForceNew:         true,
-				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"eu", "us", "au", "in", "ae", "ca", "dev", "preprod"}, false)),
-				DefaultFunc:      schema.EnvDefaultFunc("INEXT_REGION", "eu"),
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(regions.Names(), false)),
+				DefaultFunc:      schema.EnvDefaultFunc("INEXT_REGION", regions.DefaultRegion),
+			},
+			"host": {
+				Description: "The base URL of the API, for example https://cloudinfra-gw.portal.checkpoint.com. Overrides the host of the region,\n" +
+					"use it to point the provider at a new region, a proxy gateway or a mock server",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsURLWithHTTPorHTTPS),
+				DefaultFunc:      schema.EnvDefaultFunc("INEXT_HOST", nil),
+			},
+			"graphql_path": {
+				Description: "The path of the GraphQL API under the host, for example " + regions.PolicyGraphQLPath + ".\n" +
+					"Overrides the path of the region and the path chosen by the application of the API key",
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("INEXT_GRAPHQL_PATH", nil),
+			},
+			"auth_path": {
+				Description: "The path of the authentication API under the host. Defaults to " + regions.DefaultAuthPath,
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("INEXT_AUTH_PATH", nil),
 			},
 			"client_id": {
 				Description: "The client id for API operations, You can retrieve this\n" +
 					"from the 'Global Settings -> API Keys' section of the Infinity Next portal",
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("INEXT_CLIENT_ID", ""),
 			},
 			"access_key": {
 				Description: "The access key for API operations. You can retrieve this\n" +
 					"from the 'Global Settings -> API Keys' section of the Infinity Next portal",
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				Sensitive:   true,
 				DefaultFunc: schema.EnvDefaultFunc("INEXT_ACCESS_KEY", ""),
 			},
+			"token": {
+				Description: "A pre-issued API token to use instead of client_id and access_key, e.g. a short-lived token minted by a pipeline.\n" +
+					"The token can't be refreshed, so it must be valid for the whole run",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("INEXT_TOKEN", ""),
+			},
+			"retry": {
+				Description: "Controls how failed API requests are retried. Only transient failures are retried: network errors,\n" +
+					"rate limiting (429, honoring the `Retry-After` header), gateway errors and timeouts",
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"max_attempts": {
+							Description:      "The total number of attempts of a request, including the first one",
+							Type:             schema.TypeInt,
+							Optional:         true,
+							Default:          api.DefaultRetryMaxAttempts,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+						},
+						"base_backoff": {
+							Description:      "The wait before the first retry, each following retry waits twice as long. For example: 500ms, 2s",
+							Type:             schema.TypeString,
+							Optional:         true,
+							Default:          api.DefaultRetryBaseBackoff.String(),
+							ValidateDiagFunc: utils.ValidateDuration,
+						},
+						"max_backoff": {
+							Description:      "The maximum wait between attempts. For example: 30s, 1m",
+							Type:             schema.TypeString,
+							Optional:         true,
+							Default:          api.DefaultRetryMaxBackoff.String(),
+							ValidateDiagFunc: utils.ValidateDuration,
+						},
+						"jitter": {
+							Description: "Randomize the wait between attempts so concurrent requests don't retry at the same time",
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+						},
+					},
+				},
+			},
+			"auto_publish": {
+				Description: "Publish the changes of all resources at the end of each apply, instead of leaving them for the `inext_publish_enforce` resource.\n" +
+					"The changes are published once no resource was written for a few seconds, and publish validation errors are reported on the resources that were written.\n" +
+					"There is no publish when the provider stops: if an apply is interrupted or a resource times out before its publish finished, the changes may be left unpublished in the session",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("INEXT_AUTO_PUBLISH", false),
+			},
+			"auto_enforce": {
+				Description: "Enforce the policy after each automatic publish. Requires `auto_publish`",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("INEXT_AUTO_ENFORCE", false),
+			},
+			"session_conflict_policy": {
+				Description: "What to do when the session has unpublished changes of other users, e.g. edits made in the portal, before the first write and before publishing.\n" +
+					"`fail` fails the operation, `wait` waits for the changes to be published or discarded up to `session_conflict_timeout`,\n" +
+					"`proceed` doesn't check the session, and publishes and discards them together with the changes of the provider.\n" +
+					"With `fail` and `wait` and `discard_on_failure`, the changes of a failed operation are not discarded while the session has changes of other users",
+				Type:             schema.TypeString,
+				Optional:         true,
+				DefaultFunc:      schema.EnvDefaultFunc("INEXT_SESSION_CONFLICT_POLICY", api.DefaultSessionConflictPolicy),
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(api.SessionConflictPolicies, false)),
+			},
+			"session_conflict_timeout": {
+				Description:      "How long the `wait` session conflict policy waits for the changes of other users. For example: 30s, 10m",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          api.DefaultSessionConflictTimeout.String(),
+				ValidateDiagFunc: utils.ValidateDuration,
+			},
+			"discard_on_failure": {
+				Description: "Discard all the changes in the session when a resource operation fails, as in earlier versions of the provider.\n" +
+					"By default only the changes of the failed operation are rolled back, and the changes of other resources and users are kept",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("INEXT_DISCARD_ON_FAILURE", false),
+			},
+			"http_proxy": {
+				Description: "The URL of the proxy to send API requests through, for example http://proxy.example.com:3128.\n" +
+					"Defaults to the proxy configured with the HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables",
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("INEXT_HTTP_PROXY", nil),
+			},
+			"ca_cert_file": {
+				Description:   "Path to a file of PEM encoded CA certificates to trust in addition to the system certificates, e.g. the CA of a TLS-intercepting proxy",
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"ca_cert_pem"},
+			},
+			"ca_cert_pem": {
+				Description:   "PEM encoded CA certificates to trust in addition to the system certificates, e.g. the CA of a TLS-intercepting proxy",
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"ca_cert_file"},
+			},
+			"insecure_skip_verify": {
+				Description: "Skip verification of the API server certificate. **Do not use in production**, this is intended for lab environments only",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"request_timeout": {
+				Description:      "The timeout of a single API request. For example: 30s, 2m",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          api.DefaultRequestTimeout.String(),
+				ValidateDiagFunc: utils.ValidateDuration,
+			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
 			"inext_log_trigger":            resources.ResourceLogTrigger(),
@@ -60,8 +206,51 @@ func Provider() *schema.Provider {
 			"inext_web_user_response":      resources.ResourceWebUserResponse(),
 			"inext_publish_enforce":        resources.ResourcePublishEnforce(),
 		},
+		DataSourcesMap: map[string]*schema.Resource{
+			"inext_log_trigger":            datasources.DataSourceLogTrigger(),
+			"inext_appsec_gateway_profile": datasources.DataSourceAppSecGatewayProfile(),
+			"inext_embedded_profile":       datasources.DataSourceEmbeddedProfile(),
+			"inext_docker_profile":         datasources.DataSourceDockerProfile(),
+			"inext_kubernetes_profile":     datasources.DataSourceKubernetesProfile(),
+			"inext_web_app_asset":          datasources.DataSourceWebAppAsset(),
+			"inext_web_api_asset":          datasources.DataSourceWebAPIAsset(),
+			"inext_web_app_practice":       datasources.DataSourceWebAppPractice(),
+			"inext_web_api_practice":       datasources.DataSourceWebAPIPractice(),
+			"inext_rate_limit_practice":    datasources.DataSourceRateLimitPractice(),
+			"inext_trusted_sources":        datasources.DataSourceTrustedSources(),
+			"inext_exceptions":             datasources.DataSourceExceptions(),
+			"inext_web_user_response":      datasources.DataSourceWebUserResponse(),
+			"inext_web_app_assets":         datasources.DataSourceWebAppAssets(),
+			"inext_profiles":               datasources.DataSourceProfiles(),
+			"inext_practices":              datasources.DataSourcePractices(),
+			"inext_behaviors":              datasources.DataSourceBehaviors(),
+		},
 		ConfigureContextFunc: providerConfigure,
 	}
+
+	for _, r := range p.ResourcesMap {
+		withRollbackJournal(r)
+	}
+
+	return p
+}
+
+// withRollbackJournal makes the create, update and delete operations of a resource record their writes,
+// so a failed operation rolls back only its own changes
+func withRollbackJournal(r *schema.Resource) {
+	wrap := func(f func(context.Context, *schema.ResourceData, any) diag.Diagnostics) func(context.Context, *schema.ResourceData, any) diag.Diagnostics {
+		if f == nil {
+			return nil
+		}
+
+		return func(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+			return f(api.WithRollbackJournal(ctx), d, meta)
+		}
+	}
+
+	r.CreateContext = wrap(r.CreateContext)
+	r.UpdateContext = wrap(r.UpdateContext)
+	r.DeleteContext = wrap(r.DeleteContext)
 }
 
 func providerConfigure(ctx context.Context, d *schema.ResourceData) (any, diag.Diagnostics) {
@@ -71,55 +260,188 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (any, diag.D
 	region := d.Get("region").(string)
 	client_id := d.Get("client_id").(string)
 	access_key := d.Get("access_key").(string)
+	token := d.Get("token").(string)
 
-	if len(client_id) == 0 || len(access_key) == 0 {
+	if len(token) > 0 && (len(client_id) > 0 || len(access_key) > 0) {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Conflicting credentials",
+			Detail:   "Must define either token or client_id and access_key, not both",
+		})
+		return nil, diags
+	}
+
+	if len(token) == 0 && (len(client_id) == 0 || len(access_key) == 0) {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  "Missing credentials",
-			Detail:   "Must define client_id and access_key",
+			Detail:   "Must define client_id and access_key, or token",
 		})
 		return nil, diags
 	}
 
 	client := api.NewClient()
 
-	switch region {
-	case "eu":
-		client.SetHost("https://cloudinfra-gw.portal.checkpoint.com")
-		client.SetEndpoint("/app/i2/graphql/V1")
-	case "us":
-		client.SetHost("https://cloudinfra-gw-us.portal.checkpoint.com")
-		client.SetEndpoint("/app/i2/graphql/V1")
-	case "au":
-		client.SetHost("https://cloudinfra-gw.ap.portal.checkpoint.com")
-		client.SetEndpoint("/app/i2/graphql/V1")
-	case "in":
-		client.SetHost("https://cloudinfra-gw.in.portal.checkpoint.com")
-		client.SetEndpoint("/app/i2/graphql/V1")
-	case "ae":
-		client.SetHost("https://cloudinfra-gw.ae.portal.checkpoint.com")
-		client.SetEndpoint("/app/i2/graphql/V1")
-	case "ca":
-		client.SetHost("https://cloudinfra-gw.ca.portal.checkpoint.com")
-		client.SetEndpoint("/app/i2/graphql/V1")
-	case "dev":
-		client.SetHost("https://dev-cloudinfra-gw.kube1.iaas.checkpoint.com")
-		client.SetEndpoint("/app/infinity2gem/graphql/V1")
-	case "preprod":
-		client.SetHost("https://dev-cloudinfra-gw.kube1.iaas.checkpoint.com")
-		client.SetEndpoint("/app/i2/graphql/V1")
-	default:
+	endpoint, err := regions.Resolve(region, regions.Endpoint{
+		Host:        d.Get("host").(string),
+		GraphQLPath: d.Get("graphql_path").(string),
+		AuthPath:    d.Get("auth_path").(string),
+	})
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	client.SetHost(endpoint.Host)
+	client.SetAuthPath(endpoint.AuthPath)
+	if d.Get("graphql_path").(string) != "" {
+		client.PinEndpoint(endpoint.GraphQLPath)
+	} else {
+		client.SetEndpoint(endpoint.GraphQLPath)
+	}
+
+	retryPolicy, err := retryPolicyFromResourceData(d)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	client.SetRetryPolicy(retryPolicy)
+
+	httpClientOptions, err := httpClientOptionsFromResourceData(d)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	httpClient, err := api.NewHTTPClient(httpClientOptions)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	client.SetHTTPClient(httpClient)
+	if httpClientOptions.InsecureSkipVerify {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "TLS verification is disabled",
+			Detail:   "insecure_skip_verify is set, the certificate of the API server is not verified. Do not use this setting in production",
+		})
+	}
+
+	// proceed is the behavior without a policy, so the session isn't checked at all
+	if policy := d.Get("session_conflict_policy").(string); policy != api.SessionConflictPolicyProceed {
+		sessionConflictTimeout, err := time.ParseDuration(d.Get("session_conflict_timeout").(string))
+		if err != nil {
+			return nil, diag.FromErr(fmt.Errorf("invalid session_conflict_timeout: %w", err))
+		}
+
+		client.SetSessionConflictOptions(api.SessionConflictOptions{
+			Policy:         policy,
+			Timeout:        sessionConflictTimeout,
+			PendingChanges: objects.GetPendingChanges,
+		})
+	}
+
+	client.SetDiscardOnFailure(d.Get("discard_on_failure").(bool))
+	if d.Get("auto_enforce").(bool) && !d.Get("auto_publish").(bool) {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
-			Summary:  "Invalid region",
-			Detail:   "region must be one of: eu, us, au, in, ae, ca",
+			Summary:  "Invalid auto_enforce",
+			Detail:   "auto_enforce requires auto_publish, the policy can only be enforced after it was published",
 		})
 		return nil, diags
 	}
 
-	if err := client.InfinityPortalAuthentication(client_id, access_key); err != nil {
+	if d.Get("auto_publish").(bool) {
+		client.EnableAutoPublish(autoPublishOptions(d.Get("auto_enforce").(bool)))
+	}
+
+	if len(token) > 0 {
+		if err := client.TokenAuthentication(ctx, token); err != nil {
+			return nil, diag.FromErr(err)
+		}
+
+		return client, diags
+	}
+
+	if err := client.InfinityPortalAuthentication(ctx, client_id, access_key); err != nil {
 		return nil, diag.FromErr(err)
 	}
 
-	return client, nil
+	return client, diags
+}
+
+// autoPublishOptions returns the options of auto publish, which publishes and enforces the same way as the inext_publish_enforce resource
+func autoPublishOptions(enforce bool) api.AutoPublishOptions {
+	opts := api.AutoPublishOptions{
+		Publish: func(ctx context.Context, c *api.Client) error {
+			_, err := publishenforce.ExecutePublish(ctx, c, nil)
+			return err
+		},
+	}
+
+	if enforce {
+		opts.Enforce = func(ctx context.Context, c *api.Client) error {
+			_, err := publishenforce.ExecuteEnforce(ctx, c, nil)
+			return err
+		}
+	}
+
+	return opts
+}
+
+// retryPolicyFromResourceData returns the retry policy configured in the retry block
+// or the default policy if there is no retry block
+func retryPolicyFromResourceData(d *schema.ResourceData) (api.RetryPolicy, error) {
+	policy := api.DefaultRetryPolicy()
+	retryBlocks := d.Get("retry").([]any)
+	if len(retryBlocks) == 0 || retryBlocks[0] == nil {
+		return policy, nil
+	}
+
+	retryBlock := retryBlocks[0].(map[string]any)
+	policy.MaxAttempts = retryBlock["max_attempts"].(int)
+	policy.Jitter = retryBlock["jitter"].(bool)
+
+	var err error
+	if policy.BaseBackoff, err = time.ParseDuration(retryBlock["base_backoff"].(string)); err != nil {
+		return policy, fmt.Errorf("invalid retry base_backoff: %w", err)
+	}
+
+	if policy.MaxBackoff, err = time.ParseDuration(retryBlock["max_backoff"].(string)); err != nil {
+		return policy, fmt.Errorf("invalid retry max_backoff: %w", err)
+	}
+
+	if policy.MaxBackoff < policy.BaseBackoff {
+		return policy, fmt.Errorf("retry max_backoff (%s) must not be shorter than base_backoff (%s)", policy.MaxBackoff, policy.BaseBackoff)
+	}
+
+	return policy, nil
+}
+
+// httpClientOptionsFromResourceData returns the options of the HTTP client from the provider configuration
+func httpClientOptionsFromResourceData(d *schema.ResourceData) (api.HTTPClientOptions, error) {
+	opts := api.HTTPClientOptions{
+		ProxyURL:           d.Get("http_proxy").(string),
+		InsecureSkipVerify: d.Get("insecure_skip_verify").(bool),
+	}
+
+	timeout, err := time.ParseDuration(d.Get("request_timeout").(string))
+	if err != nil {
+		return opts, fmt.Errorf("invalid request_timeout: %w", err)
+	}
+
+	opts.Timeout = timeout
+
+	if caCertPEM := d.Get("ca_cert_pem").(string); caCertPEM != "" {
+		opts.CACertPEM = []byte(caCertPEM)
+	}
+
+	if caCertFile := d.Get("ca_cert_file").(string); caCertFile != "" {
+		caCertPEM, err := os.ReadFile(caCertFile)
+		if err != nil {
+			return opts, fmt.Errorf("failed to read ca_cert_file: %w", err)
+		}
+
+		opts.CACertPEM = caCertPEM
+	}
+
+	return opts, nil
 }
@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/api"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/utils"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -17,6 +18,7 @@ func ResourceAccessToken() *schema.Resource {
 		ReadContext:   resourceAccessTokenRead,
 		UpdateContext: resourceAccessTokenUpdate,
 		DeleteContext: resourceAccessTokenDelete,
+		Timeouts:      utils.ResourceTimeouts(utils.DefaultTimeout),
 
 		Schema: map[string]*schema.Schema{
 			"value": {
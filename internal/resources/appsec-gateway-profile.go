@@ -2,7 +2,7 @@ package resources
 
 import (
 	"context"
-	"strings"
+	"errors"
 
 	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/api"
 	models "github.com/CheckPointSW/terraform-provider-infinity-next/internal/models/appsec-gateway-profile"
@@ -32,6 +32,7 @@ func ResourceAppSecGatewayProfile() *schema.Resource {
 		ReadContext:   resourceAppSecGatewayProfileRead,
 		UpdateContext: resourceAppSecGatewayProfileUpdate,
 		DeleteContext: resourceAppSecGatewayProfileDelete,
+		Timeouts:      utils.ResourceTimeouts(utils.DefaultTimeout),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -215,16 +216,16 @@ func resourceAppSecGatewayProfileCreate(ctx context.Context, d *schema.ResourceD
 
 	profile, err := appsecgatewayprofile.NewAppSecGatewayProfile(ctx, c, createInput)
 	if err != nil {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
 		return utils.DiagError("unable to perform AppSecGatewayProfile Create", err, diags)
 	}
 
-	isValid, err := c.PublishChanges()
+	isValid, err := c.PublishChanges(ctx)
 	if err != nil || !isValid {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -232,7 +233,7 @@ func resourceAppSecGatewayProfileCreate(ctx context.Context, d *schema.ResourceD
 	}
 
 	if err = appsecgatewayprofile.ReadCloudGuardAppSecGatewayProfileToResourceData(profile, d); err != nil {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -250,6 +251,10 @@ func resourceAppSecGatewayProfileRead(ctx context.Context, d *schema.ResourceDat
 	id := d.Id()
 
 	profile, err := appsecgatewayprofile.GetCloudGuardAppSecGatewayProfile(ctx, c, id)
+	if errors.Is(err, api.ErrorNotFound) {
+		return utils.DiagNotFound(d, "AppSecGatewayProfile", diags)
+	}
+
 	if err != nil {
 		return utils.DiagError("unable to perform AppSecGatewayProfile Read", err, diags)
 	}
@@ -273,16 +278,16 @@ func resourceAppSecGatewayProfileUpdate(ctx context.Context, d *schema.ResourceD
 
 	result, err := appsecgatewayprofile.UpdateAppSecGatewayProfile(ctx, c, d.Id(), updateInput)
 	if err != nil || !result {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
 		return utils.DiagError("unable to perform AppSecGatewayProfile Update", err, diags)
 	}
 
-	isValid, err := c.PublishChanges()
+	isValid, err := c.PublishChanges(ctx)
 	if err != nil || !isValid {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -309,7 +314,8 @@ func resourceAppSecGatewayProfileDelete(ctx context.Context, d *schema.ResourceD
 	result, err := appsecgatewayprofile.DeleteAppSecGatewayProfile(ctx, c, ID)
 	if err != nil || !result {
 		// Check if the error is due to the profile being used by other resources
-		if err != nil && strings.Contains(err.Error(), errorMsgPointedObjects) {
+		var inUseErr *api.ObjectInUseError
+		if errors.As(err, &inUseErr) {
 			// Get AppSecGatewayProfile to check if it is used by other resources
 			profile, err2 := appsecgatewayprofile.GetCloudGuardAppSecGatewayProfile(ctx, c, ID)
 			if err2 != nil {
@@ -326,14 +332,14 @@ func resourceAppSecGatewayProfileDelete(ctx context.Context, d *schema.ResourceD
 			// Retry delete after removing references
 			result, err := appsecgatewayprofile.DeleteAppSecGatewayProfile(ctx, c, ID)
 			if err != nil || !result {
-				if _, discardErr := c.DiscardChanges(); discardErr != nil {
+				if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 					diags = utils.DiagError("failed to discard changes", discardErr, diags)
 				}
 
 				return utils.DiagError("unable to perform AppSecGatewayProfile Delete after updating references", err, diags)
 			}
 		} else {
-			if _, discardErr := c.DiscardChanges(); discardErr != nil {
+			if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 				diags = utils.DiagError("failed to discard changes", discardErr, diags)
 			}
 
@@ -342,9 +348,9 @@ func resourceAppSecGatewayProfileDelete(ctx context.Context, d *schema.ResourceD
 
 	}
 
-	isValid, err := c.PublishChanges()
+	isValid, err := c.PublishChanges(ctx)
 	if err != nil || !isValid {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -368,7 +374,7 @@ func handleAppSecGatewayProfileReferences(ctx context.Context, usedBy models.Dis
 
 			updated, err := webapiasset.UpdateWebAPIAsset(ctx, c, usedByResource.ID, webAPIAsset)
 			if err != nil || !updated {
-				if _, discardErr := c.DiscardChanges(); discardErr != nil {
+				if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 					diags = utils.DiagError("failed to discard changes", discardErr, diags)
 				}
 
@@ -382,7 +388,7 @@ func handleAppSecGatewayProfileReferences(ctx context.Context, usedBy models.Dis
 
 			updated, err := webappasset.UpdateWebApplicationAsset(ctx, c, usedByResource.ID, webAppAsset)
 			if err != nil || !updated {
-				if _, discardErr := c.DiscardChanges(); discardErr != nil {
+				if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 					diags = utils.DiagError("failed to discard changes", discardErr, diags)
 				}
 
@@ -113,6 +113,10 @@ func GetCloudGuardAppSecGatewayProfile(ctx context.Context, c *api.Client, id st
 		return models.CloudGuardAppSecGatewayProfile{}, fmt.Errorf("failed to get CloudGuardAppSecGatewayProfile: %w", err)
 	}
 
+	if res == nil {
+		return models.CloudGuardAppSecGatewayProfile{}, api.ErrorNotFound
+	}
+
 	profile, err := utils.UnmarshalAs[models.CloudGuardAppSecGatewayProfile](res)
 	if err != nil {
 		return models.CloudGuardAppSecGatewayProfile{}, fmt.Errorf("failed to convert response to CloudGuardAppSecGatewayProfile struct. Error: %w", err)
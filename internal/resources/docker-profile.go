@@ -2,7 +2,7 @@ package resources
 
 import (
 	"context"
-	"strings"
+	"errors"
 
 	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/api"
 	models "github.com/CheckPointSW/terraform-provider-infinity-next/internal/models/docker-profile"
@@ -26,6 +26,7 @@ func ResourceDockerProfile() *schema.Resource {
 		ReadContext:   resourceDockerProfileRead,
 		UpdateContext: resourceDockerProfileUpdate,
 		DeleteContext: resourceDockerProfileDelete,
+		Timeouts:      utils.ResourceTimeouts(utils.DefaultTimeout),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -101,16 +102,16 @@ func resourceDockerProfileCreate(ctx context.Context, d *schema.ResourceData, me
 
 	profile, err := dockerprofile.NewDockerProfile(ctx, c, createInput)
 	if err != nil {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
 		return utils.DiagError("unable to perform DockerProfile Create", err, diags)
 	}
 
-	isValid, err := c.PublishChanges()
+	isValid, err := c.PublishChanges(ctx)
 	if err != nil || !isValid {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -118,7 +119,7 @@ func resourceDockerProfileCreate(ctx context.Context, d *schema.ResourceData, me
 	}
 
 	if err = dockerprofile.ReadDockerProfileToResourceData(profile, d); err != nil {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -136,6 +137,10 @@ func resourceDockerProfileRead(ctx context.Context, d *schema.ResourceData, meta
 	id := d.Id()
 
 	profile, err := dockerprofile.GetDockerProfile(ctx, c, id)
+	if errors.Is(err, api.ErrorNotFound) {
+		return utils.DiagNotFound(d, "DockerProfile", diags)
+	}
+
 	if err != nil {
 		return utils.DiagError("unable to perform DockerProfile Read", err, diags)
 	}
@@ -159,16 +164,16 @@ func resourceDockerProfileUpdate(ctx context.Context, d *schema.ResourceData, me
 
 	result, err := dockerprofile.UpdateDockerProfile(ctx, c, d.Id(), updateInput)
 	if err != nil || !result {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
 		return utils.DiagError("unable to perform DockerProfile Update", err, diags)
 	}
 
-	isValid, err := c.PublishChanges()
+	isValid, err := c.PublishChanges(ctx)
 	if err != nil || !isValid {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -195,7 +200,8 @@ func resourceDockerProfileDelete(ctx context.Context, d *schema.ResourceData, me
 	result, err := dockerprofile.DeleteDockerProfile(ctx, c, ID)
 	if err != nil || !result {
 		// Check if the error is due to the profile being used by other resources
-		if err != nil && strings.Contains(err.Error(), errorMsgPointedObjects) {
+		var inUseErr *api.ObjectInUseError
+		if errors.As(err, &inUseErr) {
 			// Get DockerProfile to check if it is used by other resources
 			profile, err2 := dockerprofile.GetDockerProfile(ctx, c, ID)
 			if err2 != nil {
@@ -212,14 +218,14 @@ func resourceDockerProfileDelete(ctx context.Context, d *schema.ResourceData, me
 			// Retry delete after removing references
 			result, err := dockerprofile.DeleteDockerProfile(ctx, c, ID)
 			if err != nil || !result {
-				if _, discardErr := c.DiscardChanges(); discardErr != nil {
+				if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 					diags = utils.DiagError("failed to discard changes", discardErr, diags)
 				}
 
 				return utils.DiagError("unable to perform DockerProfile Delete after updating references", err, diags)
 			}
 		} else {
-			if _, discardErr := c.DiscardChanges(); discardErr != nil {
+			if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 				diags = utils.DiagError("failed to discard changes", discardErr, diags)
 			}
 
@@ -228,9 +234,9 @@ func resourceDockerProfileDelete(ctx context.Context, d *schema.ResourceData, me
 
 	}
 
-	isValid, err := c.PublishChanges()
+	isValid, err := c.PublishChanges(ctx)
 	if err != nil || !isValid {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -254,7 +260,7 @@ func handleDockerProfileReferences(ctx context.Context, usedBy models.DisplayObj
 
 			updated, err := webapiasset.UpdateWebAPIAsset(ctx, c, usedByResource.ID, webAPIAsset)
 			if err != nil || !updated {
-				if _, discardErr := c.DiscardChanges(); discardErr != nil {
+				if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 					diags = utils.DiagError("failed to discard changes", discardErr, diags)
 				}
 
@@ -268,7 +274,7 @@ func handleDockerProfileReferences(ctx context.Context, usedBy models.DisplayObj
 
 			updated, err := webappasset.UpdateWebApplicationAsset(ctx, c, usedByResource.ID, webAppAsset)
 			if err != nil || !updated {
-				if _, discardErr := c.DiscardChanges(); discardErr != nil {
+				if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 					diags = utils.DiagError("failed to discard changes", discardErr, diags)
 				}
 
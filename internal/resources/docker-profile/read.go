@@ -68,6 +68,10 @@ func GetDockerProfile(ctx context.Context, c *api.Client, id string) (models.Doc
 		return models.DockerProfile{}, fmt.Errorf("failed to get DockerProfile: %w", err)
 	}
 
+	if res == nil {
+		return models.DockerProfile{}, api.ErrorNotFound
+	}
+
 	profile, err := utils.UnmarshalAs[models.DockerProfile](res)
 	if err != nil {
 		return models.DockerProfile{}, fmt.Errorf("failed to convert response to DockerProfile struct. Error: %w", err)
@@ -2,7 +2,7 @@ package resources
 
 import (
 	"context"
-	"strings"
+	"errors"
 
 	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/api"
 	models "github.com/CheckPointSW/terraform-provider-infinity-next/internal/models/embedded-profile"
@@ -28,6 +28,7 @@ func ResourceEmbeddedProfile() *schema.Resource {
 		ReadContext:   resourceEmbeddedProfileRead,
 		UpdateContext: resourceEmbeddedProfileUpdate,
 		DeleteContext: resourceEmbeddedProfileDelete,
+		Timeouts:      utils.ResourceTimeouts(utils.DefaultTimeout),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -170,16 +171,16 @@ func resourceEmbeddedProfileCreate(ctx context.Context, d *schema.ResourceData,
 
 	profile, err := embeddedprofile.NewEmbeddedProfile(ctx, c, createInput)
 	if err != nil {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
 		return utils.DiagError("unable to perform EmbeddedProfile Create", err, diags)
 	}
 
-	isValid, err := c.PublishChanges()
+	isValid, err := c.PublishChanges(ctx)
 	if err != nil || !isValid {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -187,7 +188,7 @@ func resourceEmbeddedProfileCreate(ctx context.Context, d *schema.ResourceData,
 	}
 
 	if err = embeddedprofile.ReadEmbeddedProfileToResourceData(profile, d); err != nil {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -205,6 +206,10 @@ func resourceEmbeddedProfileRead(ctx context.Context, d *schema.ResourceData, me
 	id := d.Id()
 
 	profile, err := embeddedprofile.GetEmbeddedProfile(ctx, c, id)
+	if errors.Is(err, api.ErrorNotFound) {
+		return utils.DiagNotFound(d, "EmbeddedProfile", diags)
+	}
+
 	if err != nil {
 		return utils.DiagError("unable to perform EmbeddedProfile Read", err, diags)
 	}
@@ -228,16 +233,16 @@ func resourceEmbeddedProfileUpdate(ctx context.Context, d *schema.ResourceData,
 
 	result, err := embeddedprofile.UpdateEmbeddedProfile(ctx, c, d.Id(), updateInput)
 	if err != nil || !result {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
 		return utils.DiagError("unable to perform EmbeddedProfile Update", err, diags)
 	}
 
-	isValid, err := c.PublishChanges()
+	isValid, err := c.PublishChanges(ctx)
 	if err != nil || !isValid {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -264,7 +269,8 @@ func resourceEmbeddedProfileDelete(ctx context.Context, d *schema.ResourceData,
 	result, err := embeddedprofile.DeleteEmbeddedProfile(ctx, c, ID)
 	if err != nil || !result {
 		// Check if the error is due to the profile being used by other resources
-		if err != nil && strings.Contains(err.Error(), errorMsgPointedObjects) {
+		var inUseErr *api.ObjectInUseError
+		if errors.As(err, &inUseErr) {
 			// Get EmbeddedProfile to check if it is used by other resources
 			profile, err2 := embeddedprofile.GetEmbeddedProfile(ctx, c, ID)
 			if err2 != nil {
@@ -281,14 +287,14 @@ func resourceEmbeddedProfileDelete(ctx context.Context, d *schema.ResourceData,
 			// Retry delete after removing references
 			result, err := embeddedprofile.DeleteEmbeddedProfile(ctx, c, ID)
 			if err != nil || !result {
-				if _, discardErr := c.DiscardChanges(); discardErr != nil {
+				if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 					diags = utils.DiagError("failed to discard changes", discardErr, diags)
 				}
 
 				return utils.DiagError("unable to perform EmbeddedProfile Delete after updating references", err, diags)
 			}
 		} else {
-			if _, discardErr := c.DiscardChanges(); discardErr != nil {
+			if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 				diags = utils.DiagError("failed to discard changes", discardErr, diags)
 			}
 
@@ -297,9 +303,9 @@ func resourceEmbeddedProfileDelete(ctx context.Context, d *schema.ResourceData,
 
 	}
 
-	isValid, err := c.PublishChanges()
+	isValid, err := c.PublishChanges(ctx)
 	if err != nil || !isValid {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -323,7 +329,7 @@ func handleEmbeddedProfileReferences(ctx context.Context, usedBy models.DisplayO
 
 			updated, err := webapiasset.UpdateWebAPIAsset(ctx, c, usedByResource.ID, webAPIAsset)
 			if err != nil || !updated {
-				if _, discardErr := c.DiscardChanges(); discardErr != nil {
+				if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 					diags = utils.DiagError("failed to discard changes", discardErr, diags)
 				}
 
@@ -337,7 +343,7 @@ func handleEmbeddedProfileReferences(ctx context.Context, usedBy models.DisplayO
 
 			updated, err := webappasset.UpdateWebApplicationAsset(ctx, c, usedByResource.ID, webAppAsset)
 			if err != nil || !updated {
-				if _, discardErr := c.DiscardChanges(); discardErr != nil {
+				if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 					diags = utils.DiagError("failed to discard changes", discardErr, diags)
 				}
 
@@ -89,6 +89,10 @@ func GetEmbeddedProfile(ctx context.Context, c *api.Client, id string) (models.E
 		return models.EmbeddedProfile{}, fmt.Errorf("failed to get EmbeddedProfile: %w", err)
 	}
 
+	if res == nil {
+		return models.EmbeddedProfile{}, api.ErrorNotFound
+	}
+
 	profile, err := utils.UnmarshalAs[models.EmbeddedProfile](res)
 	if err != nil {
 		return models.EmbeddedProfile{}, fmt.Errorf("failed to convert response to EmbeddedProfile struct. Error: %w", err)
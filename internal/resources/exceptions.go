@@ -2,7 +2,7 @@ package resources
 
 import (
 	"context"
-	"strings"
+	"errors"
 
 	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/api"
 	models "github.com/CheckPointSW/terraform-provider-infinity-next/internal/models/exceptions"
@@ -93,6 +93,7 @@ func ResourceExceptions() *schema.Resource {
 		ReadContext:   resourceExceptionsRead,
 		UpdateContext: resourceExceptionsUpdate,
 		DeleteContext: resourceExceptionsDelete,
+		Timeouts:      utils.ResourceTimeouts(utils.DefaultTimeout),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -155,23 +156,23 @@ func resourceExceptionsCreate(ctx context.Context, d *schema.ResourceData, meta
 	var diags diag.Diagnostics
 	c := meta.(*api.Client)
 
-	createInput, err := exceptions.CreateExceptionBehaviorInputFromResourceData(d)
+	createInput, err := exceptions.CreateExceptionBehaviorInputFromResourceData(ctx, d)
 	if err != nil {
 		return utils.DiagError("unable to perform ExceptionBehavior Create", err, diags)
 	}
 
 	behavior, err := exceptions.NewExceptionBehavior(ctx, c, createInput)
 	if err != nil {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
 		return utils.DiagError("unable to perform ExceptionBehavior Create", err, diags)
 	}
 
-	isValid, err := c.PublishChanges()
+	isValid, err := c.PublishChanges(ctx)
 	if err != nil || !isValid {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -179,7 +180,7 @@ func resourceExceptionsCreate(ctx context.Context, d *schema.ResourceData, meta
 	}
 
 	if err := exceptions.ReadExceptionBehaviorToResourceData(behavior, d); err != nil {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -194,8 +195,12 @@ func resourceExceptionsRead(ctx context.Context, d *schema.ResourceData, meta an
 	c := meta.(*api.Client)
 
 	behavior, err := exceptions.GetExceptionBehavior(ctx, c, d.Id())
+	if errors.Is(err, api.ErrorNotFound) {
+		return utils.DiagNotFound(d, "ExceptionBehavior", diags)
+	}
+
 	if err != nil {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -203,7 +208,7 @@ func resourceExceptionsRead(ctx context.Context, d *schema.ResourceData, meta an
 	}
 
 	if err := exceptions.ReadExceptionBehaviorToResourceData(behavior, d); err != nil {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -217,23 +222,23 @@ func resourceExceptionsUpdate(ctx context.Context, d *schema.ResourceData, meta
 	var diags diag.Diagnostics
 	c := meta.(*api.Client)
 
-	updateInput, err := exceptions.UpdateExceptionBehaviorInputFromResourceData(d)
+	updateInput, err := exceptions.UpdateExceptionBehaviorInputFromResourceData(ctx, d)
 	if err != nil {
 		return utils.DiagError("unable to perform ExceptionBehavior Update", err, diags)
 	}
 
 	result, err := exceptions.UpdateExceptionBehavior(ctx, c, d.Id(), updateInput)
 	if err != nil || !result {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
 		return utils.DiagError("unable to perform ExceptionBehavior Update", err, diags)
 	}
 
-	isValid, err := c.PublishChanges()
+	isValid, err := c.PublishChanges(ctx)
 	if err != nil || !isValid {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -242,7 +247,7 @@ func resourceExceptionsUpdate(ctx context.Context, d *schema.ResourceData, meta
 
 	behavior, err := exceptions.GetExceptionBehavior(ctx, c, d.Id())
 	if err != nil {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -250,7 +255,7 @@ func resourceExceptionsUpdate(ctx context.Context, d *schema.ResourceData, meta
 	}
 
 	if err := exceptions.ReadExceptionBehaviorToResourceData(behavior, d); err != nil {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -267,7 +272,8 @@ func resourceExceptionsDelete(ctx context.Context, d *schema.ResourceData, meta
 	result, err := exceptions.DeleteExceptionBehavior(ctx, c, d.Id())
 	if err != nil || !result {
 		// Check if the error is due to the exception behavior being used by other resources
-		if err != nil && strings.Contains(err.Error(), errorMsgPointedObjects) {
+		var inUseErr *api.ObjectInUseError
+		if errors.As(err, &inUseErr) {
 			// Get the resources that are using the exception behavior
 			usedBy, err2 := exceptions.UsedByExceptionBehavior(ctx, c, d.Id())
 			if err2 != nil {
@@ -285,7 +291,7 @@ func resourceExceptionsDelete(ctx context.Context, d *schema.ResourceData, meta
 				// Retry to delete the exception behavior
 				result, err := exceptions.DeleteExceptionBehavior(ctx, c, d.Id())
 				if err != nil || !result {
-					if _, discardErr := c.DiscardChanges(); discardErr != nil {
+					if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 						diags = utils.DiagError("failed to discard changes", discardErr, diags)
 					}
 
@@ -295,7 +301,7 @@ func resourceExceptionsDelete(ctx context.Context, d *schema.ResourceData, meta
 			}
 
 		} else {
-			if _, discardErr := c.DiscardChanges(); discardErr != nil {
+			if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 				diags = utils.DiagError("failed to discard changes", discardErr, diags)
 			}
 
@@ -303,9 +309,9 @@ func resourceExceptionsDelete(ctx context.Context, d *schema.ResourceData, meta
 		}
 	}
 
-	isValid, err := c.PublishChanges()
+	isValid, err := c.PublishChanges(ctx)
 	if err != nil || !isValid {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -333,7 +339,7 @@ func handleExceptionsReferences(ctx context.Context, usedBy models.DisplayObject
 
 			updated, err := webapiasset.UpdateWebAPIAsset(ctx, c, usedByResource.ID, webAPIAsset)
 			if err != nil || !updated {
-				if _, discardErr := c.DiscardChanges(); discardErr != nil {
+				if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 					diags = utils.DiagError("failed to discard changes", discardErr, diags)
 				}
 
@@ -347,7 +353,7 @@ func handleExceptionsReferences(ctx context.Context, usedBy models.DisplayObject
 
 			updated, err := webappasset.UpdateWebApplicationAsset(ctx, c, usedByResource.ID, webAppAsset)
 			if err != nil || !updated {
-				if _, discardErr := c.DiscardChanges(); discardErr != nil {
+				if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 					diags = utils.DiagError("failed to discard changes", discardErr, diags)
 				}
 
@@ -56,13 +56,13 @@ func mapToSchemaMatchExpression(conditionMap map[string]any) models.SchemaMatchE
 	return ret
 }
 
-func mapToExceptionObjectInput(exceptionMap map[string]any) models.ExceptionObjectInput {
+func mapToExceptionObjectInput(ctx context.Context, exceptionMap map[string]any) models.ExceptionObjectInput {
 	var ret models.ExceptionObjectInput
 	ret.Comment = exceptionMap["comment"].(string)
 	action := models.Action{Key: "action", Value: exceptionMap["action"].(string)}
 	actionBytes, err := json.Marshal(action)
 	if err != nil {
-		fmt.Printf("[WARN] failed to marshal action struct: %+v", err)
+		utils.LogConversionError(ctx, "Failed to marshal exception action", err, action)
 	}
 
 	if id, ok := exceptionMap["id"]; ok {
@@ -75,7 +75,7 @@ func mapToExceptionObjectInput(exceptionMap map[string]any) models.ExceptionObje
 		inputMatch := ParseSchemaMatchToInput(matchExpression[0])
 		matchBytes, err := json.Marshal(inputMatch)
 		if err != nil {
-			fmt.Printf("[WARN] failed to marshal MatchExpression struct: %+v", err)
+			utils.LogConversionError(ctx, "Failed to marshal exception match expression", err, inputMatch)
 		}
 
 		ret.Match = string(matchBytes)
@@ -84,12 +84,14 @@ func mapToExceptionObjectInput(exceptionMap map[string]any) models.ExceptionObje
 	return ret
 }
 
-func CreateExceptionBehaviorInputFromResourceData(d *schema.ResourceData) (models.CreateExceptionBehaviorInput, error) {
+func CreateExceptionBehaviorInputFromResourceData(ctx context.Context, d *schema.ResourceData) (models.CreateExceptionBehaviorInput, error) {
 	var res models.CreateExceptionBehaviorInput
 
 	res.Name = d.Get("name").(string)
 	res.Visibility = d.Get("visibility").(string)
-	res.Exceptions = utils.Map(utils.MustResourceDataCollectionToSlice[map[string]any](d, "exception"), mapToExceptionObjectInput)
+	res.Exceptions = utils.Map(utils.MustResourceDataCollectionToSlice[map[string]any](d, "exception"), func(exceptionMap map[string]any) models.ExceptionObjectInput {
+		return mapToExceptionObjectInput(ctx, exceptionMap)
+	})
 
 	return res, nil
 }
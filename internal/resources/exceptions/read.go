@@ -34,6 +34,10 @@ func GetExceptionBehavior(ctx context.Context, c *api.Client, id string) (models
 		return models.ExceptionBehavior{}, fmt.Errorf("failed to get ExceptionBehavior: %w", err)
 	}
 
+	if res == nil {
+		return models.ExceptionBehavior{}, api.ErrorNotFound
+	}
+
 	behavior, err := utils.UnmarshalAs[models.ExceptionBehavior](res)
 	if err != nil {
 		return models.ExceptionBehavior{}, fmt.Errorf("failed to convert response to ExceptionBehavior struct. Error: %w", err)
@@ -46,7 +50,11 @@ func ReadExceptionBehaviorToResourceData(behavior models.ExceptionBehavior, d *s
 	d.SetId(behavior.ID)
 	d.Set("name", behavior.Name)
 	d.Set("visibility", behavior.Visibility)
-	schemaExceptions := behavior.Exceptions.ToSchema()
+	schemaExceptions, err := behavior.Exceptions.ToSchema()
+	if err != nil {
+		return err
+	}
+
 	schemaExceptionsMap, err := utils.UnmarshalAs[[]map[string]any](schemaExceptions)
 	if err != nil {
 		return fmt.Errorf("failed to convert exceptions to slice of map: %w", err)
@@ -10,11 +10,13 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-func parseSchemaExceptions(exceptionsFromResourceData any) models.ExceptionObjectInputs {
-	return utils.Map(utils.MustSchemaCollectionToSlice[map[string]any](exceptionsFromResourceData), mapToExceptionObjectInput)
+func parseSchemaExceptions(ctx context.Context, exceptionsFromResourceData any) models.ExceptionObjectInputs {
+	return utils.Map(utils.MustSchemaCollectionToSlice[map[string]any](exceptionsFromResourceData), func(exceptionMap map[string]any) models.ExceptionObjectInput {
+		return mapToExceptionObjectInput(ctx, exceptionMap)
+	})
 }
 
-func UpdateExceptionBehaviorInputFromResourceData(d *schema.ResourceData) (models.UpdateExceptionBehaviorInput, error) {
+func UpdateExceptionBehaviorInputFromResourceData(ctx context.Context, d *schema.ResourceData) (models.UpdateExceptionBehaviorInput, error) {
 	var res models.UpdateExceptionBehaviorInput
 	if _, newName, hasChange := utils.MustGetChange[string](d, "name"); hasChange {
 		res.Name = newName
@@ -24,9 +26,9 @@ func UpdateExceptionBehaviorInputFromResourceData(d *schema.ResourceData) (model
 		res.Visibility = newVisibility
 	}
 
-	if oldExceptions, newExceptions, hasChange := utils.GetChangeWithParse(d, "exception", parseSchemaExceptions); hasChange {
+	if oldExceptions, newExceptions, hasChange := utils.GetChangeWithParse(d, "exception", func(exceptions any) models.ExceptionObjectInputs { return parseSchemaExceptions(ctx, exceptions) }); hasChange {
 		exceptionsToAdd, exceptionsToRemove := utils.SlicesDiff(oldExceptions, newExceptions)
-		res.AddExceptions = utils.Map(exceptionsToAdd, utils.MustUnmarshalAs[models.AddExceptionObjectInput, models.ExceptionObjectInput])
+		res.AddExceptions = utils.MustUnmarshalSliceAs[models.AddExceptionObjectInput](ctx, exceptionsToAdd)
 		res.RemoveExceptions = utils.Map(exceptionsToRemove, func(toRemove models.ExceptionObjectInput) string { return toRemove.ID })
 		res.UpdateExceptions = models.ExceptionObjectActionsUpdate{}
 	}
@@ -2,7 +2,7 @@ package resources
 
 import (
 	"context"
-	"strings"
+	"errors"
 
 	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/api"
 	models "github.com/CheckPointSW/terraform-provider-infinity-next/internal/models/kubernetes-profile"
@@ -35,6 +35,7 @@ func ResourceKubernetesProfile() *schema.Resource {
 		ReadContext:   resourceKubernetesProfileRead,
 		UpdateContext: resourceKubernetesProfileUpdate,
 		DeleteContext: resourceKubernetesProfileDelete,
+		Timeouts:      utils.ResourceTimeouts(utils.DefaultTimeout),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -115,16 +116,16 @@ func resourceKubernetesProfileCreate(ctx context.Context, d *schema.ResourceData
 
 	profile, err := kubernetesprofile.NewKubernetesProfile(ctx, c, createInput)
 	if err != nil {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
 		return utils.DiagError("unable to perform KubernetesProfile Create", err, diags)
 	}
 
-	isValid, err := c.PublishChanges()
+	isValid, err := c.PublishChanges(ctx)
 	if err != nil || !isValid {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -132,7 +133,7 @@ func resourceKubernetesProfileCreate(ctx context.Context, d *schema.ResourceData
 	}
 
 	if err = kubernetesprofile.ReadKubernetesProfileToResourceData(profile, d); err != nil {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -150,6 +151,10 @@ func resourceKubernetesProfileRead(ctx context.Context, d *schema.ResourceData,
 	id := d.Id()
 
 	profile, err := kubernetesprofile.GetKubernetesProfile(ctx, c, id)
+	if errors.Is(err, api.ErrorNotFound) {
+		return utils.DiagNotFound(d, "KubernetesProfile", diags)
+	}
+
 	if err != nil {
 		return utils.DiagError("unable to perform KubernetesProfile Read", err, diags)
 	}
@@ -173,16 +178,16 @@ func resourceKubernetesProfileUpdate(ctx context.Context, d *schema.ResourceData
 
 	result, err := kubernetesprofile.UpdateKubernetesProfile(ctx, c, d.Id(), updateInput)
 	if err != nil || !result {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
 		return utils.DiagError("unable to perform KubernetesProfile Update", err, diags)
 	}
 
-	isValid, err := c.PublishChanges()
+	isValid, err := c.PublishChanges(ctx)
 	if err != nil || !isValid {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -209,7 +214,8 @@ func resourceKubernetesProfileDelete(ctx context.Context, d *schema.ResourceData
 	result, err := kubernetesprofile.DeleteKubernetesProfile(ctx, c, ID)
 	if err != nil || !result {
 		// Check if the error is due to the profile being used by other resources
-		if err != nil && strings.Contains(err.Error(), errorMsgPointedObjects) {
+		var inUseErr *api.ObjectInUseError
+		if errors.As(err, &inUseErr) {
 			// Get KubernetesProfile to check if it is used by other resources
 			profile, err2 := kubernetesprofile.GetKubernetesProfile(ctx, c, ID)
 			if err2 != nil {
@@ -226,14 +232,14 @@ func resourceKubernetesProfileDelete(ctx context.Context, d *schema.ResourceData
 			// Retry delete after removing references
 			result, err := kubernetesprofile.DeleteKubernetesProfile(ctx, c, ID)
 			if err != nil || !result {
-				if _, discardErr := c.DiscardChanges(); discardErr != nil {
+				if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 					diags = utils.DiagError("failed to discard changes", discardErr, diags)
 				}
 
 				return utils.DiagError("unable to perform KubernetesProfile Delete after updating references", err, diags)
 			}
 		} else {
-			if _, discardErr := c.DiscardChanges(); discardErr != nil {
+			if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 				diags = utils.DiagError("failed to discard changes", discardErr, diags)
 			}
 
@@ -242,9 +248,9 @@ func resourceKubernetesProfileDelete(ctx context.Context, d *schema.ResourceData
 
 	}
 
-	isValid, err := c.PublishChanges()
+	isValid, err := c.PublishChanges(ctx)
 	if err != nil || !isValid {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -268,7 +274,7 @@ func handleKubernetesProfileReferences(ctx context.Context, usedBy models.Displa
 
 			updated, err := webapiasset.UpdateWebAPIAsset(ctx, c, usedByResource.ID, webAPIAsset)
 			if err != nil || !updated {
-				if _, discardErr := c.DiscardChanges(); discardErr != nil {
+				if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 					diags = utils.DiagError("failed to discard changes", discardErr, diags)
 				}
 
@@ -282,7 +288,7 @@ func handleKubernetesProfileReferences(ctx context.Context, usedBy models.Displa
 
 			updated, err := webappasset.UpdateWebApplicationAsset(ctx, c, usedByResource.ID, webAppAsset)
 			if err != nil || !updated {
-				if _, discardErr := c.DiscardChanges(); discardErr != nil {
+				if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 					diags = utils.DiagError("failed to discard changes", discardErr, diags)
 				}
 
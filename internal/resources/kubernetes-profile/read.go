@@ -70,6 +70,10 @@ func GetKubernetesProfile(ctx context.Context, c *api.Client, id string) (models
 		return models.KubernetesProfile{}, fmt.Errorf("failed to get KubernetesProfile: %w", err)
 	}
 
+	if res == nil {
+		return models.KubernetesProfile{}, api.ErrorNotFound
+	}
+
 	profile, err := utils.UnmarshalAs[models.KubernetesProfile](res)
 	if err != nil {
 		return models.KubernetesProfile{}, fmt.Errorf("failed to convert response to KubernetesProfile struct. Error: %w", err)
@@ -0,0 +1,315 @@
+package resources_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/apitest"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources"
+	logtrigger "github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources/log-trigger"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestLogTriggerLifecycle(t *testing.T) {
+	server := apitest.NewServer(t)
+	c := server.Client(t)
+	r := resources.ResourceLogTrigger()
+
+	state := apitest.Apply(t, r, nil, map[string]any{"name": "trigger", "verbosity": "Standard"}, c)
+	trigger := server.Object(state.ID)
+	if trigger == nil || trigger.TypeName != "LogTrigger" || trigger.Fields["verbosity"] != "Standard" {
+		t.Fatalf("unexpected trigger after create: %#v", trigger)
+	}
+
+	state = apitest.Apply(t, r, state, map[string]any{"name": "trigger", "verbosity": "Extended", "log_to_agent": true}, c)
+	trigger = server.Object(state.ID)
+	if trigger.Fields["verbosity"] != "Extended" || trigger.Fields["logToAgent"] != true {
+		t.Fatalf("unexpected trigger after update: %#v", trigger.Fields)
+	}
+
+	if state.Attributes["verbosity"] != "Extended" || state.Attributes["log_to_agent"] != "true" {
+		t.Fatalf("unexpected state after update: %#v", state.Attributes)
+	}
+
+	apitest.Destroy(t, r, state, c)
+	if server.Object(state.ID) != nil {
+		t.Fatal("trigger still exists after destroy")
+	}
+}
+
+func TestWebApplicationAssetLifecycle(t *testing.T) {
+	server := apitest.NewServer(t)
+	c := server.Client(t)
+
+	profileResource := resources.ResourceKubernetesProfile()
+	profileState := apitest.Apply(t, profileResource, nil, map[string]any{"name": "profile", "profile_sub_type": "AppSecDefault"}, c)
+
+	practiceResource := resources.ResourceWebAppPractice()
+	practiceState := apitest.Apply(t, practiceResource, nil, map[string]any{"name": "practice"}, c)
+
+	triggerResource := resources.ResourceLogTrigger()
+	triggerState := apitest.Apply(t, triggerResource, nil, map[string]any{"name": "trigger"}, c)
+
+	assetResource := resources.ResourceWebAppAsset()
+	assetConfig := map[string]any{
+		"name":     "asset",
+		"urls":     []any{"http://host/path1", "http://host/path2"},
+		"profiles": []any{profileState.ID},
+		"practice": []any{map[string]any{
+			"id":        practiceState.ID,
+			"main_mode": "Prevent",
+			"triggers":  []any{triggerState.ID},
+		}},
+	}
+
+	assetState := apitest.Apply(t, assetResource, nil, assetConfig, c)
+	asset := server.Object(assetState.ID)
+	if asset == nil || len(asset.Fields["URLs"].([]any)) != 2 {
+		t.Fatalf("unexpected asset after create: %#v", asset)
+	}
+
+	if assetState.Attributes["practice.#"] != "1" || assetState.Attributes["profiles.#"] != "1" {
+		t.Fatalf("unexpected asset state after create: %#v", assetState.Attributes)
+	}
+
+	assetConfig["urls"] = []any{"http://host/path1", "http://host/path2", "http://host/path3"}
+	assetState = apitest.Apply(t, assetResource, assetState, assetConfig, c)
+	var urls []string
+	for _, url := range server.Object(assetState.ID).Fields["URLs"].([]any) {
+		urls = append(urls, url.(map[string]any)["URL"].(string))
+	}
+
+	if len(urls) != 3 || urls[2] != "http://host/path3" {
+		t.Fatalf("unexpected asset urls after update: %v", urls)
+	}
+
+	// the trigger and practice are in use by the asset, their delete must remove them from it first
+	usedBy, err := logtrigger.UsedByLogTrigger(context.Background(), c, triggerState.ID)
+	if err != nil || len(usedBy) != 1 || usedBy[0].Container != assetState.ID {
+		t.Fatalf("unexpected trigger used by: %#v, %v", usedBy, err)
+	}
+
+	apitest.Destroy(t, triggerResource, triggerState, c)
+	apitest.Destroy(t, practiceResource, practiceState, c)
+	if practices := server.Object(assetState.ID).Fields["practices"].([]any); len(practices) != 0 {
+		t.Fatalf("practice was not removed from the asset: %#v", practices)
+	}
+
+	apitest.Destroy(t, assetResource, assetState, c)
+	apitest.Destroy(t, profileResource, profileState, c)
+	if objects := server.Objects(); len(objects) != 0 {
+		t.Fatalf("objects left after destroy: %d", len(objects))
+	}
+}
+
+// TestResourceLifecycles creates, updates, refreshes and destroys each of the other resources
+// the state after the update is read back from the fake, and must plan no changes against the updated configuration
+// so blocks that are sets, whose attributes are keyed by hash, are checked by the plan
+func TestResourceLifecycles(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		r        *schema.Resource
+		typeName string
+		create   map[string]any
+		update   map[string]any
+		want     map[string]string
+	}{
+		{
+			name:     "appsec gateway profile",
+			r:        resources.ResourceAppSecGatewayProfile(),
+			typeName: "CloudGuardAppSecGatewayProfile",
+			create:   map[string]any{"name": "gateway", "profile_sub_type": "Aws", "max_number_of_agents": 10, "certificate_type": "Vault"},
+			update: map[string]any{
+				"name": "gateway", "profile_sub_type": "Aws", "max_number_of_agents": 100, "certificate_type": "Gateway",
+				"additional_settings": map[string]any{"Key1": "Value1"},
+			},
+			want: map[string]string{"max_number_of_agents": "100", "certificate_type": "Gateway", "additional_settings.Key1": "Value1"},
+		},
+		{
+			name:     "docker profile",
+			r:        resources.ResourceDockerProfile(),
+			typeName: "DockerProfile",
+			create:   map[string]any{"name": "docker", "max_number_of_agents": 10},
+			update:   map[string]any{"name": "docker", "max_number_of_agents": 100, "defined_applications_only": true},
+			want:     map[string]string{"max_number_of_agents": "100", "defined_applications_only": "true"},
+		},
+		{
+			name:     "embedded profile",
+			r:        resources.ResourceEmbeddedProfile(),
+			typeName: "EmbeddedProfile",
+			create:   map[string]any{"name": "embedded", "max_number_of_agents": 10},
+			update:   map[string]any{"name": "embedded", "max_number_of_agents": 100, "additional_settings": map[string]any{"Key1": "Value1"}},
+			want:     map[string]string{"max_number_of_agents": "100", "additional_settings.Key1": "Value1"},
+		},
+		{
+			name:     "kubernetes profile",
+			r:        resources.ResourceKubernetesProfile(),
+			typeName: "KubernetesProfile",
+			create:   map[string]any{"name": "kubernetes", "profile_sub_type": "AccessControl", "max_number_of_agents": 10},
+			update:   map[string]any{"name": "kubernetes-renamed", "profile_sub_type": "AccessControl", "max_number_of_agents": 100},
+			want:     map[string]string{"name": "kubernetes-renamed", "max_number_of_agents": "100"},
+		},
+		{
+			name:     "web api asset",
+			r:        resources.ResourceWebAPIAsset(),
+			typeName: "WebAPIAsset",
+			create:   map[string]any{"name": "api", "urls": []any{"http://host/api/path1"}},
+			update: map[string]any{
+				"name":         "api",
+				"urls":         []any{"http://host/api/path1", "http://host/api/path2"},
+				"upstream_url": "http://upstream",
+				"mtls":         []any{map[string]any{"filename": "ca.pem", "data": "certificate", "type": "client", "certificate_type": ".pem", "enable": true}},
+			},
+			want: map[string]string{"urls.#": "2", "upstream_url": "http://upstream", "mtls.#": "1"},
+		},
+		{
+			name:     "web api practice",
+			r:        resources.ResourceWebAPIPractice(),
+			typeName: "WebAPIPractice",
+			create:   map[string]any{"name": "api-practice"},
+			update: map[string]any{
+				"name":       "api-practice",
+				"visibility": "Local",
+				"ips":        []any{map[string]any{"performance_impact": "MediumOrLower", "severity_level": "MediumOrAbove", "protections_from_year": "2016", "high_confidence": "AccordingToPractice", "medium_confidence": "AccordingToPractice", "low_confidence": "Detect"}},
+			},
+			want: map[string]string{"visibility": "Local", "ips.#": "1"},
+		},
+		{
+			name:     "web app practice",
+			r:        resources.ResourceWebAppPractice(),
+			typeName: "WebApplicationPractice",
+			create:   map[string]any{"name": "app-practice"},
+			update:   map[string]any{"name": "app-practice-renamed", "visibility": "Local"},
+			want:     map[string]string{"name": "app-practice-renamed", "visibility": "Local"},
+		},
+		{
+			name:     "rate limit practice",
+			r:        resources.ResourceRateLimitPractice(),
+			typeName: "RateLimitPractice",
+			create: map[string]any{
+				"name": "rate-limit",
+				"rule": []any{map[string]any{"uri": "/api/v1/test", "scope": "Minute", "limit": 100, "action": "Detect"}},
+			},
+			update: map[string]any{
+				"name": "rate-limit",
+				"rule": []any{
+					map[string]any{"uri": "/api/v1/test", "scope": "Minute", "limit": 100, "action": "Detect"},
+					map[string]any{"uri": "/api/v2/test", "scope": "Second", "limit": 50, "action": "Prevent", "comment": "second rule"},
+				},
+			},
+			want: map[string]string{"rule.#": "2"},
+		},
+		{
+			name:     "trusted sources",
+			r:        resources.ResourceTrustedSources(),
+			typeName: "TrustedSourceBehavior",
+			create:   map[string]any{"name": "trusted", "min_num_of_sources": 1},
+			update:   map[string]any{"name": "trusted", "min_num_of_sources": 2, "sources_identifiers": []any{"identifier1", "identifier2"}},
+			want:     map[string]string{"min_num_of_sources": "2", "sources_identifiers.#": "2"},
+		},
+		{
+			name:     "exceptions",
+			r:        resources.ResourceExceptions(),
+			typeName: "ExceptionBehavior",
+			create: map[string]any{
+				"name":      "exceptions",
+				"exception": []any{map[string]any{"match": []any{map[string]any{"key": "hostName", "value": []any{"www.example.com"}}}, "action": "drop"}},
+			},
+			update: map[string]any{
+				"name":      "exceptions",
+				"exception": []any{map[string]any{"match": []any{map[string]any{"key": "hostName", "value": []any{"www.example.com"}}}, "action": "accept"}},
+			},
+			want: map[string]string{"exception.#": "1"},
+		},
+		{
+			name:     "web user response",
+			r:        resources.ResourceWebUserResponse(),
+			typeName: "WebUserResponseBehavior",
+			create:   map[string]any{"name": "response", "mode": "BlockPage", "http_response_code": 403},
+			update:   map[string]any{"name": "response", "mode": "Redirect", "redirect_url": "http://redirect", "http_response_code": 403},
+			want:     map[string]string{"mode": "Redirect", "redirect_url": "http://redirect"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			server := apitest.NewServer(t)
+			c := server.Client(t)
+
+			state := apitest.Apply(t, tc.r, nil, tc.create, c)
+			if obj := server.Object(state.ID); obj == nil || obj.TypeName != tc.typeName || obj.Name() != tc.create["name"] {
+				t.Fatalf("unexpected object after create: %#v", obj)
+			}
+
+			state = apitest.Apply(t, tc.r, state, tc.update, c)
+			state = apitest.Refresh(t, tc.r, state, c)
+			for attr, want := range tc.want {
+				if got := state.Attributes[attr]; got != want {
+					t.Errorf("%s = %q after update, want %q", attr, got, want)
+				}
+			}
+
+			// the refreshed state matches the configuration
+			if diff, err := tc.r.SimpleDiff(context.Background(), state, terraform.NewResourceConfigRaw(tc.update), c); err != nil || (diff != nil && !diff.Empty()) {
+				t.Errorf("expected an empty plan after update, got %v: %#v", err, diff)
+			}
+
+			apitest.Destroy(t, tc.r, state, c)
+			if server.Object(state.ID) != nil {
+				t.Fatal("object still exists after destroy")
+			}
+		})
+	}
+}
+
+func TestAccessTokenLifecycle(t *testing.T) {
+	server := apitest.NewServer(t)
+	c := server.Client(t)
+	r := resources.ResourceAccessToken()
+
+	state := apitest.Apply(t, r, nil, map[string]any{}, c)
+	if state.ID == "" || state.Attributes["value"] != c.GetToken() {
+		t.Fatalf("unexpected state after create: %#v", state.Attributes)
+	}
+
+	apitest.Destroy(t, r, state, c)
+	if len(server.Objects()) != 0 {
+		t.Fatal("access token created objects")
+	}
+}
+
+func TestDeletedOutsideTerraform(t *testing.T) {
+	server := apitest.NewServer(t)
+	c := server.Client(t)
+
+	for _, tc := range []struct {
+		name   string
+		r      *schema.Resource
+		config map[string]any
+	}{
+		{name: "log trigger", r: resources.ResourceLogTrigger(), config: map[string]any{"name": "trigger"}},
+		{name: "web app practice", r: resources.ResourceWebAppPractice(), config: map[string]any{"name": "practice"}},
+		{name: "web app asset", r: resources.ResourceWebAppAsset(), config: map[string]any{"name": "asset", "urls": []any{"http://host/path"}}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			state := apitest.Apply(t, tc.r, nil, tc.config, c)
+			server.RemoveObject(state.ID)
+
+			diags := tc.r.ReadContext(context.Background(), tc.r.Data(state), c)
+			if diags.HasError() || len(diags) != 1 || diags[0].Severity != diag.Warning || !strings.Contains(diags[0].Summary, tc.config["name"].(string)) {
+				t.Fatalf("expected a not found warning, got %#v", diags)
+			}
+
+			if state := apitest.Refresh(t, tc.r, state, c); state != nil && state.ID != "" {
+				t.Fatalf("object deleted outside of terraform was not removed from the state: %#v", state)
+			}
+
+			// the object is created again on the next apply
+			state = apitest.Apply(t, tc.r, nil, tc.config, c)
+			if server.Object(state.ID) == nil {
+				t.Fatal("object was not created again")
+			}
+		})
+	}
+}
@@ -2,7 +2,7 @@ package resources
 
 import (
 	"context"
-	"strings"
+	"errors"
 
 	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/api"
 	models "github.com/CheckPointSW/terraform-provider-infinity-next/internal/models/log-trigger"
@@ -22,6 +22,7 @@ func ResourceLogTrigger() *schema.Resource {
 		ReadContext:   resourceLogTriggerRead,
 		UpdateContext: resourceLogTriggerUpdate,
 		DeleteContext: resourceLogTriggerDelete,
+		Timeouts:      utils.ResourceTimeouts(utils.DefaultTimeout),
 
 		Schema: map[string]*schema.Schema{
 			"id": {
@@ -192,16 +193,16 @@ func resourceLogTriggerCreate(ctx context.Context, d *schema.ResourceData, meta
 
 	logTrigger, err := logtrigger.NewLogTrigger(ctx, c, input)
 	if err != nil {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
 		return utils.DiagError("Unable to perform LogTrigger Create", err, diags)
 	}
 
-	isValid, err := c.PublishChanges()
+	isValid, err := c.PublishChanges(ctx)
 	if err != nil || !isValid {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -209,7 +210,7 @@ func resourceLogTriggerCreate(ctx context.Context, d *schema.ResourceData, meta
 	}
 
 	if err := logtrigger.ReadLogTriggerToResourceData(logTrigger, d); err != nil {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -224,8 +225,12 @@ func resourceLogTriggerRead(ctx context.Context, d *schema.ResourceData, meta an
 
 	c := meta.(*api.Client)
 	logTrigger, err := logtrigger.GetLogTrigger(ctx, c, d.Id())
+	if errors.Is(err, api.ErrorNotFound) {
+		return utils.DiagNotFound(d, "LogTrigger", diags)
+	}
+
 	if err != nil {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -233,7 +238,7 @@ func resourceLogTriggerRead(ctx context.Context, d *schema.ResourceData, meta an
 	}
 
 	if err := logtrigger.ReadLogTriggerToResourceData(logTrigger, d); err != nil {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -254,16 +259,16 @@ func resourceLogTriggerUpdate(ctx context.Context, d *schema.ResourceData, meta
 
 	result, err := logtrigger.UpdateLogTrigger(ctx, c, d.Id(), updateInput)
 	if err != nil || !result {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
 		return utils.DiagError("Unable to perform LogTrigger Update", err, diags)
 	}
 
-	isValid, err := c.PublishChanges()
+	isValid, err := c.PublishChanges(ctx)
 	if err != nil || !isValid {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -272,7 +277,7 @@ func resourceLogTriggerUpdate(ctx context.Context, d *schema.ResourceData, meta
 
 	logTrigger, err := logtrigger.GetLogTrigger(ctx, c, d.Id())
 	if err != nil {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -280,7 +285,7 @@ func resourceLogTriggerUpdate(ctx context.Context, d *schema.ResourceData, meta
 	}
 
 	if err := logtrigger.ReadLogTriggerToResourceData(logTrigger, d); err != nil {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -298,7 +303,8 @@ func resourceLogTriggerDelete(ctx context.Context, d *schema.ResourceData, meta
 	result, err := logtrigger.DeleteLogTrigger(ctx, c, ID)
 	if err != nil || !result {
 		// If the error is due to the log trigger being used by other objects, discard changes and return
-		if err != nil && strings.Contains(err.Error(), errorMsgPointedObjects) {
+		var inUseErr *api.ObjectInUseError
+		if errors.As(err, &inUseErr) {
 			usedBy, err2 := logtrigger.UsedByLogTrigger(ctx, c, ID)
 			if err2 != nil {
 				diags = utils.DiagError("Unable to perform LogTrigger UsedBy", err2, diags)
@@ -314,14 +320,14 @@ func resourceLogTriggerDelete(ctx context.Context, d *schema.ResourceData, meta
 			// Retry the delete operation
 			result, err = logtrigger.DeleteLogTrigger(ctx, c, ID)
 			if err != nil || !result {
-				if _, discardErr := c.DiscardChanges(); discardErr != nil {
+				if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 					diags = utils.DiagError("failed to discard changes", discardErr, diags)
 				}
 
 				return utils.DiagError("Unable to perform LogTrigger Delete after updating references", err, diags)
 			}
 		} else {
-			if _, discardErr := c.DiscardChanges(); discardErr != nil {
+			if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 				diags = utils.DiagError("failed to discard changes", discardErr, diags)
 			}
 
@@ -329,9 +335,9 @@ func resourceLogTriggerDelete(ctx context.Context, d *schema.ResourceData, meta
 		}
 	}
 
-	isValid, err := c.PublishChanges()
+	isValid, err := c.PublishChanges(ctx)
 	if err != nil || !isValid {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -349,7 +355,7 @@ func handleLogTriggerReferences(ctx context.Context, triggersUsedBy models.Trigg
 		for _, practice := range triggerUsedBy.Practices {
 			result, err := logtrigger.UpdatePracticeTriggers(ctx, c, triggerID, practice, triggerUsedBy.Container)
 			if err != nil || !result {
-				if _, discardErr := c.DiscardChanges(); discardErr != nil {
+				if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 					diags = utils.DiagError("failed to discard changes", discardErr, diags)
 				}
 
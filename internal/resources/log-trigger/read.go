@@ -83,6 +83,10 @@ func GetLogTrigger(ctx context.Context, c *api.Client, id string) (models.LogTri
 		return models.LogTrigger{}, err
 	}
 
+	if res == nil {
+		return models.LogTrigger{}, api.ErrorNotFound
+	}
+
 	logTrigger, err := utils.UnmarshalAs[models.LogTrigger](res)
 	if err != nil {
 		return models.LogTrigger{}, fmt.Errorf("failed to cinvert response to LogTrigger struct. Error: %w", err)
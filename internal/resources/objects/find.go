@@ -0,0 +1,93 @@
+package objects
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/api"
+	models "github.com/CheckPointSW/terraform-provider-infinity-next/internal/models/objects"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/utils"
+)
+
+// FindAssetIDByName returns the ID of the asset of the given type with the exact given name
+func FindAssetIDByName(ctx context.Context, c *api.Client, name, assetType string) (string, error) {
+	assets, err := GetAssets(ctx, c)
+	if err != nil {
+		return "", err
+	}
+
+	matches := utils.Filter(assets, func(asset models.DisplayAsset) bool {
+		return asset.Name == name && asset.AssetType == assetType
+	})
+
+	return singleMatchID(matches, "asset", name, func(asset models.DisplayAsset) string { return asset.ID })
+}
+
+// FindProfileIDByName returns the ID of the profile of the given type with the exact given name
+func FindProfileIDByName(ctx context.Context, c *api.Client, name, profileType string) (string, error) {
+	profiles, err := GetProfiles(ctx, c)
+	if err != nil {
+		return "", err
+	}
+
+	matches := utils.Filter(profiles, func(profile models.DisplayProfile) bool {
+		return profile.Name == name && profile.ProfileType == profileType
+	})
+
+	return singleMatchID(matches, "profile", name, func(profile models.DisplayProfile) string { return profile.ID })
+}
+
+// FindPracticeIDByName returns the ID of the practice of the given type with the exact given name
+func FindPracticeIDByName(ctx context.Context, c *api.Client, name, practiceType string) (string, error) {
+	practices, err := GetPractices(ctx, c)
+	if err != nil {
+		return "", err
+	}
+
+	matches := utils.Filter(practices, func(practice models.DisplayPractice) bool {
+		return practice.Name == name && practice.PracticeType == practiceType
+	})
+
+	return singleMatchID(matches, "practice", name, func(practice models.DisplayPractice) string { return practice.ID })
+}
+
+// FindBehaviorIDByName returns the ID of the behavior of the given type with the exact given name
+func FindBehaviorIDByName(ctx context.Context, c *api.Client, name, behaviorType string) (string, error) {
+	behaviors, err := GetBehaviors(ctx, c)
+	if err != nil {
+		return "", err
+	}
+
+	matches := utils.Filter(behaviors, func(behavior models.DisplayBehavior) bool {
+		return behavior.Name == name && behavior.BehaviorType == behaviorType
+	})
+
+	return singleMatchID(matches, "behavior", name, func(behavior models.DisplayBehavior) string { return behavior.ID })
+}
+
+// FindTriggerIDByName returns the ID of the trigger of the given type with the exact given name
+func FindTriggerIDByName(ctx context.Context, c *api.Client, name, triggerType string) (string, error) {
+	triggers, err := GetTriggers(ctx, c)
+	if err != nil {
+		return "", err
+	}
+
+	matches := utils.Filter(triggers, func(trigger models.DisplayTrigger) bool {
+		return trigger.Name == name && trigger.TriggerType == triggerType
+	})
+
+	return singleMatchID(matches, "trigger", name, func(trigger models.DisplayTrigger) string { return trigger.ID })
+}
+
+// singleMatchID returns the ID of the only object in matches
+// returns an error if there are no matches or more than one match
+func singleMatchID[T any](matches []T, objectKind, name string, idFunc func(T) string) (string, error) {
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("%s with name %q: %w", objectKind, name, api.ErrorNotFound)
+	case 1:
+		return idFunc(matches[0]), nil
+	default:
+		return "", fmt.Errorf("found %d objects of kind %s with name %q, use id instead", len(matches), objectKind, name)
+	}
+}
@@ -0,0 +1,59 @@
+package objects
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/api"
+	models "github.com/CheckPointSW/terraform-provider-infinity-next/internal/models/objects"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/utils"
+)
+
+// pendingChangesQueries are the queries of the statuses of all objects, by the kind of the objects
+// assets are returned under the assets field of the getAssets response
+var pendingChangesQueries = []struct {
+	kind        string
+	responseKey string
+	query       string
+}{
+	{kind: "Asset", responseKey: "getAssets", query: `{ getAssets { assets { id name objectStatus modifiedBy } } }`},
+	{kind: "Profile", responseKey: "getProfiles", query: `{ getProfiles { id name objectStatus modifiedBy } }`},
+	{kind: "Practice", responseKey: "getPractices", query: `{ getPractices { id name objectStatus modifiedBy } }`},
+	{kind: "Behavior", responseKey: "getBehaviors", query: `{ getBehaviors { id name objectStatus modifiedBy } }`},
+	{kind: "Trigger", responseKey: "getTriggers", query: `{ getTriggers { id name objectStatus modifiedBy } }`},
+}
+
+// GetPendingChanges returns all objects with changes in the session that were not published yet, with the user that
+// last changed them
+func GetPendingChanges(ctx context.Context, c *api.Client) ([]api.PendingChange, error) {
+	var ret []api.PendingChange
+	for _, q := range pendingChangesQueries {
+		res, err := c.MakeGraphQLRequest(ctx, q.query, q.responseKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the status of %s objects: %w", q.kind, err)
+		}
+
+		if resMap, ok := res.(map[string]any); ok && q.responseKey == "getAssets" {
+			res = resMap["assets"]
+		}
+
+		objs, err := utils.UnmarshalAs[models.StatusObjects](res)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert response to StatusObjects struct. Error: %w", err)
+		}
+
+		for _, obj := range objs {
+			if obj.ObjectStatus != "" && obj.ObjectStatus != models.ObjectStatusPublished {
+				ret = append(ret, api.PendingChange{
+					ID:         obj.ID,
+					Name:       obj.Name,
+					Kind:       q.kind,
+					Status:     obj.ObjectStatus,
+					ModifiedBy: obj.ModifiedBy,
+				})
+			}
+		}
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,150 @@
+package objects
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/api"
+	models "github.com/CheckPointSW/terraform-provider-infinity-next/internal/models/objects"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/utils"
+)
+
+// GetAssets returns all assets
+// the list queries are sent without search or filter arguments, the callers filter the objects they return
+func GetAssets(ctx context.Context, c *api.Client) (models.DisplayAssets, error) {
+	res, err := c.MakeGraphQLRequest(ctx, `
+		query getAssets {
+			getAssets {
+				status
+				assets {
+					id
+					name
+					assetType
+					state
+					upstreamURL
+					tags {
+						key
+						value
+					}
+				}
+			}
+		}
+	`, "getAssets")
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assets: %w", err)
+	}
+
+	assets, err := utils.UnmarshalAs[models.DisplayAssetsResponse](res)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert response to DisplayAssetsResponse struct. Error: %w", err)
+	}
+
+	return assets.Assets, nil
+}
+
+// GetProfiles returns all profiles
+func GetProfiles(ctx context.Context, c *api.Client) (models.DisplayProfiles, error) {
+	res, err := c.MakeGraphQLRequest(ctx, `
+		query getProfiles {
+			getProfiles {
+				id
+				name
+				profileType
+				... on CloudGuardAppSecGatewayProfile {
+					profileSubType
+				}
+				... on KubernetesProfile {
+					profileSubType
+				}
+			}
+		}
+	`, "getProfiles")
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get profiles: %w", err)
+	}
+
+	profiles, err := utils.UnmarshalAs[models.DisplayProfiles](res)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert response to DisplayProfiles struct. Error: %w", err)
+	}
+
+	return profiles, nil
+}
+
+// GetPractices returns all practices
+func GetPractices(ctx context.Context, c *api.Client) (models.DisplayPractices, error) {
+	res, err := c.MakeGraphQLRequest(ctx, `
+		query getPractices {
+			getPractices {
+				id
+				name
+				practiceType
+				category
+				visibility
+				default
+			}
+		}
+	`, "getPractices")
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get practices: %w", err)
+	}
+
+	practices, err := utils.UnmarshalAs[models.DisplayPractices](res)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert response to DisplayPractices struct. Error: %w", err)
+	}
+
+	return practices, nil
+}
+
+// GetBehaviors returns all behaviors
+func GetBehaviors(ctx context.Context, c *api.Client) (models.DisplayBehaviors, error) {
+	res, err := c.MakeGraphQLRequest(ctx, `
+		query getBehaviors {
+			getBehaviors {
+				id
+				name
+				behaviorType
+				visibility
+			}
+		}
+	`, "getBehaviors")
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get behaviors: %w", err)
+	}
+
+	behaviors, err := utils.UnmarshalAs[models.DisplayBehaviors](res)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert response to DisplayBehaviors struct. Error: %w", err)
+	}
+
+	return behaviors, nil
+}
+
+// GetTriggers returns all triggers
+func GetTriggers(ctx context.Context, c *api.Client) (models.DisplayTriggers, error) {
+	res, err := c.MakeGraphQLRequest(ctx, `
+		query getTriggers {
+			getTriggers {
+				id
+				name
+				triggerType
+			}
+		}
+	`, "getTriggers")
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get triggers: %w", err)
+	}
+
+	triggers, err := utils.UnmarshalAs[models.DisplayTriggers](res)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert response to DisplayTriggers struct. Error: %w", err)
+	}
+
+	return triggers, nil
+}
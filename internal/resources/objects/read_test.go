@@ -0,0 +1,41 @@
+package objects_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/api"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/apitest"
+	models "github.com/CheckPointSW/terraform-provider-infinity-next/internal/models/objects"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources/objects"
+)
+
+func TestFindAssetIDByName(t *testing.T) {
+	server := apitest.NewServer(t)
+	c := server.Client(t)
+	ctx := context.Background()
+
+	// the list query is sent without arguments, the assets are matched by their exact name and type by the client
+	var gotArgs map[string]any
+	server.Handle("getAssets", func(s *apitest.Server, args map[string]any) (any, error) {
+		gotArgs = args
+		return map[string]any{"status": "Done", "assets": []any{
+			map[string]any{"id": "web-app", "name": "shop", "assetType": models.AssetTypeWebApplication},
+			map[string]any{"id": "web-api", "name": "shop", "assetType": models.AssetTypeWebAPI},
+			map[string]any{"id": "other", "name": "shop-2", "assetType": models.AssetTypeWebApplication},
+		}}, nil
+	})
+
+	if got, err := objects.FindAssetIDByName(ctx, c, "shop", models.AssetTypeWebApplication); err != nil || got != "web-app" {
+		t.Fatalf("FindAssetIDByName() = %q, %v, want web-app", got, err)
+	}
+
+	if len(gotArgs) != 0 {
+		t.Errorf("getAssets was sent with arguments %v", gotArgs)
+	}
+
+	if _, err := objects.FindAssetIDByName(ctx, c, "sho", models.AssetTypeWebApplication); !errors.Is(err, api.ErrorNotFound) {
+		t.Fatalf("expected a not found error for a partial name, got %v", err)
+	}
+}
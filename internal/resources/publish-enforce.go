@@ -2,6 +2,7 @@ package resources
 
 import (
 	"context"
+	"time"
 
 	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/api"
 	publishenforce "github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources/publish-enforce"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func ResourcePublishEnforce() *schema.Resource {
@@ -21,6 +23,7 @@ func ResourcePublishEnforce() *schema.Resource {
 		ReadContext:   resourcePublishEnforceRead,
 		UpdateContext: resourcePublishEnforceCreateOrUpdate,
 		DeleteContext: resourcePublishEnforceDelete,
+		Timeouts:      utils.ResourceTimeouts(publishenforce.DefaultTimeout),
 
 		Schema: map[string]*schema.Schema{
 			"publish": {
@@ -57,48 +60,185 @@ func ResourcePublishEnforce() *schema.Resource {
 				Optional:    true,
 				Default:     false,
 			},
+			"poll_interval": {
+				Type: schema.TypeString,
+				Description: "How often the status of the publish and enforce tasks is checked while waiting for them to finish. For example: 300ms, 5s.\n" +
+					"The tasks are waited for up to the create or update timeout of the resource",
+				Optional:         true,
+				Default:          publishenforce.DefaultPollInterval.String(),
+				ValidateDiagFunc: utils.ValidateDuration,
+			},
+			"mode": {
+				Type: schema.TypeString,
+				Description: "When to publish and enforce. `always` runs them on every apply and always shows a diff, since publish and enforce are reset to false.\n" +
+					"`on_change` runs them only when the resource is created or `triggers` change, so the plan is empty otherwise",
+				Optional:         true,
+				Default:          publishenforce.ModeAlways,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(publishenforce.Modes, false)),
+			},
+			"triggers": {
+				Type: schema.TypeMap,
+				Description: "Arbitrary values that run publish and enforce when they change, in `on_change` mode. " +
+					"For example the IDs of the resources or a hash of their configuration",
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"last_publish_task_id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the task of the last publish",
+				Computed:    true,
+			},
+			"last_enforce_task_id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the task of the last enforce",
+				Computed:    true,
+			},
+			"last_run_at": {
+				Type:        schema.TypeString,
+				Description: "The time the last publish or enforce finished, in RFC 3339 format",
+				Computed:    true,
+			},
+			"publish_warnings": {
+				Type:        schema.TypeList,
+				Description: "The validation warnings of the last publish, they are also reported as warnings of the apply",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"enforced_profile_ids": {
+				Type:        schema.TypeList,
+				Description: "The IDs of the profiles of the last enforce. Without profile_ids this is an approximation: all profiles are enforced and the API doesn't report which, so it lists the profiles that existed just before the enforce",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 		},
+		CustomizeDiff: resourcePublishEnforceCustomizeDiff,
+	}
+}
+
+// resourcePublishEnforceCustomizeDiff marks the results of publish and enforce as unknown when they are triggered
+func resourcePublishEnforceCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta any) error {
+	if d.Get("mode").(string) == publishenforce.ModeOnChange && d.Id() != "" && !d.HasChange("triggers") {
+		return nil
+	}
+
+	shouldPublish, shouldEnforce := d.Get("publish").(bool), d.Get("enforce").(bool)
+	var computed []string
+	if shouldPublish {
+		computed = append(computed, "last_publish_task_id", "publish_warnings")
+	}
+
+	if shouldEnforce {
+		computed = append(computed, "last_enforce_task_id", "enforced_profile_ids")
 	}
+
+	if shouldPublish || shouldEnforce {
+		computed = append(computed, "last_run_at")
+	}
+
+	for _, key := range computed {
+		if err := d.SetNewComputed(key); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 // resourcePublishEnforceCreateOrUpdate handles both create and update operations
 // since they perform identical logic: trigger publish/enforce based on new values
+// in on_change mode an update runs them only if the triggers changed
 func resourcePublishEnforceCreateOrUpdate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
 	var diags diag.Diagnostics
 	c := meta.(*api.Client)
+	onChange := d.Get("mode").(string) == publishenforce.ModeOnChange
+	if onChange && d.Id() != "" && !d.HasChange("triggers") {
+		return diags
+	}
+
 	if d.Id() == "" {
 		d.SetId("publish-enforce")
 	}
 
+	// keep the previous triggers if publish or enforce fail, so they run again on the next apply
+	d.Partial(true)
+
+	// results that were never set are shown as unknown in every plan, so they are set empty until the first run
+	for _, key := range []string{"publish_warnings", "enforced_profile_ids"} {
+		if _, ok := d.GetOk(key); !ok {
+			d.Set(key, []string{})
+		}
+	}
+
 	shouldPublish := publishenforce.ShouldPublishFromResourceData(d)
 	shouldEnforce := publishenforce.ShouldEnforceFromResourceData(d)
 
 	// Execute publish if requested (same as `inext publish`)
 	if shouldPublish {
-		publishOpts := publishenforce.GetPublishOptionsFromResourceData(d)
-		if err := publishenforce.ExecutePublish(ctx, c, publishOpts); err != nil {
+		publishOpts, err := publishenforce.GetPublishOptionsFromResourceData(d)
+		if err != nil {
+			return utils.DiagError("failed to publish changes", err, diags)
+		}
+
+		publishResult, err := publishenforce.ExecutePublish(ctx, c, publishOpts)
+		if err != nil {
 			return utils.DiagError("failed to publish changes", err, diags)
 		}
+
+		warnings := publishResult.PublishValidationWarnings()
+		for _, warning := range warnings {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "Publish validation warning",
+				Detail:   warning,
+			})
+		}
+
+		d.Set("last_publish_task_id", publishResult.ID)
+		d.Set("publish_warnings", warnings)
 	}
 
 	if shouldEnforce {
-		profileIDs := publishenforce.GetProfileIDsFromResourceData(d)
-		if err := publishenforce.ExecuteEnforce(ctx, c, profileIDs); err != nil {
+		enforceOpts, err := publishenforce.GetEnforceOptionsFromResourceData(d)
+		if err != nil {
+			return utils.DiagError("failed to enforce policy", err, diags)
+		}
+
+		enforceResult, err := publishenforce.ExecuteEnforce(ctx, c, enforceOpts)
+		if err != nil {
 			return utils.DiagError("failed to enforce policy", err, diags)
 		}
+
+		d.Set("last_enforce_task_id", enforceResult.TaskID)
+		d.Set("enforced_profile_ids", enforceResult.ProfileIDs)
 	}
 
-	d.Set("publish", false)
-	d.Set("enforce", false)
+	if shouldPublish || shouldEnforce {
+		d.Set("last_run_at", time.Now().UTC().Format(time.RFC3339))
+	}
+
+	d.Partial(false)
+	if !onChange {
+		d.Set("publish", false)
+		d.Set("enforce", false)
+	}
 
 	return diags
 }
 
 func resourcePublishEnforceRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
 	// This is a trigger-only resource, nothing to read from the API
-	// Always report state as false so that config with true triggers an update
-	d.Set("publish", false)
-	d.Set("enforce", false)
+	// In always mode report state as false so that config with true triggers an update
+	if d.Get("mode").(string) != publishenforce.ModeOnChange {
+		d.Set("publish", false)
+		d.Set("enforce", false)
+	}
+
 	return nil
 }
 
@@ -2,7 +2,9 @@ package publishenforce
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -17,11 +19,28 @@ const (
 	taskStatusInProgress = "InProgress"
 	taskStatusSucceeded  = "Succeeded"
 	taskStatusFailed     = "Failed"
-	enforceTimeout       = 5 * time.Minute
-	pollInterval         = 300 * time.Millisecond
 	maxTransientErrors   = 5
+
+	// DefaultTimeout is the default timeout of the inext_publish_enforce resource, publish and enforce of large
+	// policies can take several minutes each
+	DefaultTimeout = 20 * time.Minute
+
+	// DefaultTaskTimeout is how long a task is waited for when the context has no deadline, e.g. when publishing automatically
+	DefaultTaskTimeout = 5 * time.Minute
+
+	// DefaultPollInterval is how often the status of a publish or enforce task is checked
+	DefaultPollInterval = 300 * time.Millisecond
 )
 
+// EnforceOptions contains optional parameters for the enforce operation
+type EnforceOptions struct {
+	// ProfileIDs are the profiles to enforce, all profiles if empty
+	ProfileIDs []string
+
+	// PollInterval is how often the task is checked, DefaultPollInterval if zero
+	PollInterval time.Duration
+}
+
 // ShouldEnforceFromResourceData reads the enforce value from ResourceData
 // Returns true if enforce should be executed
 func ShouldEnforceFromResourceData(d *schema.ResourceData) bool {
@@ -39,34 +58,91 @@ func GetProfileIDsFromResourceData(d *schema.ResourceData) []string {
 	return utils.MustSliceAs[string](newVal)
 }
 
+// GetEnforceOptionsFromResourceData reads enforce options from ResourceData
+func GetEnforceOptionsFromResourceData(d *schema.ResourceData) (*EnforceOptions, error) {
+	pollInterval, err := GetPollIntervalFromResourceData(d)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EnforceOptions{ProfileIDs: GetProfileIDsFromResourceData(d), PollInterval: pollInterval}, nil
+}
+
+// GetPollIntervalFromResourceData reads the poll_interval value from ResourceData
+func GetPollIntervalFromResourceData(d *schema.ResourceData) (time.Duration, error) {
+	pollInterval, err := time.ParseDuration(d.Get("poll_interval").(string))
+	if err != nil {
+		return 0, fmt.Errorf("invalid poll_interval: %w", err)
+	}
+
+	return pollInterval, nil
+}
+
 // ExecuteEnforce triggers an enforce operation and waits for completion (same as `inext enforce`)
-// If profileIDs is empty, all profiles will be enforced; otherwise only the specified profiles
-func ExecuteEnforce(ctx context.Context, c *api.Client, profileIDs []string) error {
-	result, err := EnforcePolicy(ctx, c, profileIDs)
+// If opts has no profile IDs, all profiles will be enforced; otherwise only the specified profiles
+// the task is waited for until the deadline of ctx, or DefaultTaskTimeout if it has none
+func ExecuteEnforce(ctx context.Context, c *api.Client, opts *EnforceOptions) (*models.EnforceResult, error) {
+	if opts == nil {
+		opts = &EnforceOptions{}
+	}
+
+	profileIDs := opts.ProfileIDs
+	if len(profileIDs) == 0 {
+		var err error
+		if profileIDs, err = getProfileIDs(ctx, c); err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := EnforcePolicy(ctx, c, opts.ProfileIDs)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if result.ID == "" {
-		return fmt.Errorf("enforce policy returned empty task ID")
+		return nil, fmt.Errorf("enforce policy returned empty task ID")
 	}
 
 	// Poll for task completion
-	taskResult, err := waitForTaskCompletion(ctx, c, result.ID)
+	taskResult, err := waitForTaskCompletion(ctx, c, result.ID, opts.PollInterval)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	switch taskResult.Status {
 	case taskStatusSucceeded:
-		return nil
-	case taskStatusFailed:
-		return fmt.Errorf("enforce policy task %s failed", result.ID)
+		return &models.EnforceResult{TaskID: result.ID, ProfileIDs: profileIDs}, nil
 	default:
-		return fmt.Errorf("enforce policy task %s done with unknown status %s", result.ID, taskResult.Status)
+		return nil, &TaskError{Operation: "enforce policy", TaskID: result.ID, Status: taskResult.Status}
 	}
 }
 
+// getProfileIDs returns the IDs of all profiles, which are enforced when no profiles are specified
+// the task of the enforce doesn't report the profiles it enforced, so these are read before the enforce
+func getProfileIDs(ctx context.Context, c *api.Client) ([]string, error) {
+	res, err := c.MakeGraphQLRequest(ctx, `{ getProfiles { id } }`, "getProfiles")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get profiles: %w", err)
+	}
+
+	profiles, ok := res.([]any)
+	if !ok {
+		return nil, fmt.Errorf("unexpected response type: %T", res)
+	}
+
+	ret := make([]string, 0, len(profiles))
+	for _, profile := range profiles {
+		if profileMap, ok := profile.(map[string]any); ok {
+			if id, ok := profileMap["id"].(string); ok {
+				ret = append(ret, id)
+			}
+		}
+	}
+
+	sort.Strings(ret)
+	return ret, nil
+}
+
 // EnforcePolicy triggers an enforce operation
 // If profileIDs is empty, all profiles will be enforced; otherwise only the specified profiles
 func EnforcePolicy(ctx context.Context, c *api.Client, profileIDs []string) (*models.EnforcePolicyResult, error) {
@@ -107,57 +183,60 @@ func EnforcePolicy(ctx context.Context, c *api.Client, profileIDs []string) (*mo
 }
 
 // waitForTaskCompletion polls the task until completion or timeout and returns the full task result
-func waitForTaskCompletion(ctx context.Context, c *api.Client, taskID string) (*models.TaskResult, error) {
-	errch := make(chan error, 1)
-	resultch := make(chan *models.TaskResult, 1)
-
-	go func() {
-		consecutiveErrors := 0
-		var lastErr error
-
-		for {
-			result, err := getTask(ctx, c, taskID)
-			if err != nil {
-				consecutiveErrors++
-				lastErr = err
-				// Retry on transient errors
-				if consecutiveErrors >= maxTransientErrors {
-					errch <- fmt.Errorf("failed after %d consecutive errors, last error: %w", consecutiveErrors, lastErr)
-					return
-				}
+// the timeout is the deadline of ctx, or DefaultTaskTimeout if it has none
+func waitForTaskCompletion(ctx context.Context, c *api.Client, taskID string, pollInterval time.Duration) (*models.TaskResult, error) {
+	if pollInterval <= 0 {
+		pollInterval = DefaultPollInterval
+	}
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, DefaultTaskTimeout)
+		defer cancel()
+	}
 
-				time.Sleep(pollInterval * 3)
-				continue
+	consecutiveErrors := 0
+	for {
+		wait := pollInterval
+		result, err := getTask(ctx, c, taskID)
+		switch {
+		case ctx.Err() != nil:
+			return &models.TaskResult{}, taskTimeoutError(ctx, taskID)
+		case err != nil:
+			// Retry on transient errors
+			consecutiveErrors++
+			if consecutiveErrors >= maxTransientErrors {
+				return &models.TaskResult{}, fmt.Errorf("failed after %d consecutive errors, last error: %w", consecutiveErrors, err)
 			}
 
-			// Reset error counter on success
+			wait = pollInterval * 3
+		case result.Status != taskStatusInProgress:
+			return result, nil
+		default:
 			consecutiveErrors = 0
+		}
 
-			if result.Status != taskStatusInProgress {
-				resultch <- result
-				return
-			}
-
-			time.Sleep(pollInterval)
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return &models.TaskResult{}, taskTimeoutError(ctx, taskID)
+		case <-timer.C:
 		}
-	}()
-
-	// Timeout for the polling routine to finish
-	select {
-	case err := <-errch:
-		return &models.TaskResult{}, err
-	case result := <-resultch:
-		return result, nil
-	case <-time.After(enforceTimeout):
-		return &models.TaskResult{}, fmt.Errorf("enforce policy task did not finish after %v, quitting", enforceTimeout)
-	case <-ctx.Done():
-		return &models.TaskResult{}, ctx.Err()
 	}
 }
 
+func taskTimeoutError(ctx context.Context, taskID string) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("task %s did not finish before the timeout, increase the timeout of the operation: %w", taskID, ctx.Err())
+	}
+
+	return ctx.Err()
+}
+
 // getTask queries the full result of a task including publish validation data
 func getTask(ctx context.Context, c *api.Client, taskID string) (*models.TaskResult, error) {
-	query := fmt.Sprintf(`query {getTask(id: "%s") {id status taskData {publishData {isValid errors {message}}}}}`, taskID)
+	query := fmt.Sprintf(`query {getTask(id: "%s") {id status taskData {publishData {isValid errors {message} warnings {message}}}}}`, taskID)
 
 	response, err := c.MakeGraphQLRequest(ctx, query, "getTask")
 	if err != nil {
@@ -204,6 +283,16 @@ func getTask(ctx context.Context, c *api.Client, taskID string) (*models.TaskRes
 				}
 			}
 
+			if warnings, ok := publishData["warnings"].([]any); ok {
+				for _, w := range warnings {
+					if warningMap, ok := w.(map[string]any); ok {
+						if msg, ok := warningMap["message"].(string); ok {
+							pd.Warnings = append(pd.Warnings, models.ValidationMessage{Message: msg})
+						}
+					}
+				}
+			}
+
 			result.TaskData = &models.TaskData{PublishData: pd}
 		}
 	}
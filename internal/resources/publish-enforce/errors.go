@@ -0,0 +1,35 @@
+package publishenforce
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValidationError is returned when the changes fail the publish validation, use errors.As to check for it
+type ValidationError struct {
+	TaskID string
+
+	// Errors and Warnings are the messages of the publish validation
+	Errors   []string
+	Warnings []string
+}
+
+func (e *ValidationError) Error() string {
+	return strings.Join(e.Errors, "; ")
+}
+
+// TaskError is returned when a publish or enforce task failed or is done with an unknown status
+type TaskError struct {
+	// Operation is what the task does, e.g. publish or enforce policy
+	Operation string
+	TaskID    string
+	Status    string
+}
+
+func (e *TaskError) Error() string {
+	if e.Status == taskStatusFailed {
+		return fmt.Sprintf("%s task %s failed", e.Operation, e.TaskID)
+	}
+
+	return fmt.Sprintf("%s task %s done with unknown status %s", e.Operation, e.TaskID, e.Status)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/api"
 	models "github.com/CheckPointSW/terraform-provider-infinity-next/internal/models/publish-enforce"
@@ -16,39 +17,70 @@ import (
 type PublishOptions struct {
 	ProfileTypes        []string
 	SkipNginxValidation bool
+
+	// PollInterval is how often the task is checked, DefaultPollInterval if zero
+	PollInterval time.Duration
 }
 
 // ExecutePublish triggers an async publish operation and waits for completion (same as `inext publish`)
-func ExecutePublish(ctx context.Context, c *api.Client, opts *PublishOptions) error {
+// returns the result of the publish task, which holds its validation warnings
+// the task is waited for until the deadline of ctx, or DefaultTaskTimeout if it has none
+// the unpublished changes of other users in the session are handled first according to the session conflict policy of c
+func ExecutePublish(ctx context.Context, c *api.Client, opts *PublishOptions) (*models.TaskResult, error) {
+	if err := c.CheckSessionConflicts(ctx); err != nil {
+		return nil, err
+	}
+
 	result, err := AsyncPublishChanges(ctx, c, opts)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if result.ID == "" {
-		return fmt.Errorf("async publish returned empty task ID")
+		return nil, fmt.Errorf("async publish returned empty task ID")
 	}
 
 	// Poll for task completion
-	taskResult, err := waitForTaskCompletion(ctx, c, result.ID)
+	var pollInterval time.Duration
+	if opts != nil {
+		pollInterval = opts.PollInterval
+	}
+
+	taskResult, err := waitForTaskCompletion(ctx, c, result.ID, pollInterval)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	switch taskResult.Status {
 	case taskStatusSucceeded:
 		if taskResult.HasPublishValidationErrors() {
-			return fmt.Errorf("publish task %s succeeded but validation failed: %s", result.ID, strings.Join(taskResult.PublishValidationErrors(), "; "))
+			return nil, fmt.Errorf("publish task %s succeeded but validation failed: %w", result.ID, &ValidationError{
+				TaskID:   result.ID,
+				Errors:   taskResult.PublishValidationErrors(),
+				Warnings: taskResult.PublishValidationWarnings(),
+			})
 		}
 
-		return nil
-	case taskStatusFailed:
-		return fmt.Errorf("publish task %s failed", result.ID)
+		taskResult.ID = result.ID
+		return taskResult, nil
 	default:
-		return fmt.Errorf("publish task %s done with unknown status %s", result.ID, taskResult.Status)
+		return nil, &TaskError{Operation: "publish", TaskID: result.ID, Status: taskResult.Status}
 	}
 }
 
+// Modes of the inext_publish_enforce resource
+const (
+	// ModeAlways publishes and enforces on every apply, publish and enforce are reset to false after each run
+	// so the plan always shows them as changed
+	ModeAlways = "always"
+
+	// ModeOnChange publishes and enforces only when the resource is created or its triggers change
+	ModeOnChange = "on_change"
+)
+
+// Modes are the valid values of the mode of the inext_publish_enforce resource
+var Modes = []string{ModeAlways, ModeOnChange}
+
 // ShouldPublishFromResourceData reads the publish value from ResourceData
 // Returns true if publish should be executed
 func ShouldPublishFromResourceData(d *schema.ResourceData) bool {
@@ -56,8 +88,13 @@ func ShouldPublishFromResourceData(d *schema.ResourceData) bool {
 }
 
 // GetPublishOptionsFromResourceData reads publish options from ResourceData
-func GetPublishOptionsFromResourceData(d *schema.ResourceData) *PublishOptions {
-	opts := &PublishOptions{}
+func GetPublishOptionsFromResourceData(d *schema.ResourceData) (*PublishOptions, error) {
+	pollInterval, err := GetPollIntervalFromResourceData(d)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &PublishOptions{PollInterval: pollInterval}
 
 	if v, ok := d.GetOk("profile_types"); ok {
 		profileTypes := v.([]any)
@@ -68,7 +105,7 @@ func GetPublishOptionsFromResourceData(d *schema.ResourceData) *PublishOptions {
 		opts.SkipNginxValidation = v.(bool)
 	}
 
-	return opts
+	return opts, nil
 }
 
 // AsyncPublishChanges triggers an async publish operation for the session
@@ -0,0 +1,97 @@
+package publishenforce_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/apitest"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources"
+	publishenforce "github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources/publish-enforce"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestPublishEnforce(t *testing.T) {
+	server := apitest.NewServer(t)
+	server.TaskPolls = 2
+	c := server.Client(t)
+
+	triggerID := server.AddObject("LogTrigger", map[string]any{"name": "trigger"})
+	profileID := server.AddObject("KubernetesProfile", map[string]any{"name": "profile"})
+	server.WarnNextPublish("unused trigger")
+	r := resources.ResourcePublishEnforce()
+	state := apitest.Apply(t, r, nil, map[string]any{"publish": true, "enforce": true}, c)
+	if server.PublishedObject(triggerID) == nil {
+		t.Fatal("trigger was not published")
+	}
+
+	operations := strings.Join(server.Operations(), ",")
+	if !strings.Contains(operations, "asyncPublishChanges,getTask,getTask,getTask,getProfiles,enforcePolicy") {
+		t.Fatalf("unexpected operations: %s", operations)
+	}
+
+	attrs := state.Attributes
+	if attrs["last_publish_task_id"] == "" || attrs["last_enforce_task_id"] == "" || attrs["last_run_at"] == "" ||
+		attrs["publish_warnings.0"] != "unused trigger" || attrs["enforced_profile_ids.0"] != profileID {
+		t.Fatalf("unexpected state after publish and enforce: %#v", attrs)
+	}
+
+	server.FailNextPublish("invalid policy")
+	if _, err := apitest.TryApply(r, state, map[string]any{"publish": true}, c); err == nil || !strings.Contains(err.Error(), "invalid policy") {
+		t.Fatalf("expected a publish validation error, got %v", err)
+	}
+
+	// validation failures are typed, so the CLI can tell them from other failures
+	server.FailNextPublish("invalid policy")
+	var validationErr *publishenforce.ValidationError
+	if _, err := publishenforce.ExecutePublish(context.Background(), c, nil); !errors.As(err, &validationErr) || validationErr.Errors[0] != "invalid policy" {
+		t.Fatalf("expected a validation error, got %v", err)
+	}
+
+	// the task is waited for up to the timeout of the resource
+	server.TaskPolls = 1000
+	config := map[string]any{"enforce": true, "poll_interval": "10ms", "timeouts": map[string]any{"update": "200ms"}}
+	if _, err := apitest.TryApply(r, state, config, c); err == nil || !strings.Contains(err.Error(), "did not finish before the timeout") {
+		t.Fatalf("expected a timeout, got %v", err)
+	}
+}
+
+func TestPublishEnforceOnChange(t *testing.T) {
+	server := apitest.NewServer(t)
+	c := server.Client(t)
+	r := resources.ResourcePublishEnforce()
+	publishes := func() int {
+		return strings.Count(strings.Join(server.Operations(), ","), "asyncPublishChanges")
+	}
+
+	config := map[string]any{"publish": true, "mode": "on_change", "triggers": map[string]any{"asset": "1"}}
+	state := apitest.Apply(t, r, nil, config, c)
+	if publishes() != 1 {
+		t.Fatalf("expected a publish on create, got %d", publishes())
+	}
+
+	// the plan is empty while the triggers don't change
+	state = apitest.Refresh(t, r, state, c)
+	diff, err := r.SimpleDiff(context.Background(), state, terraform.NewResourceConfigRaw(config), c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff != nil && !diff.Empty() {
+		t.Fatalf("expected an empty plan, got %#v", diff.Attributes)
+	}
+
+	// a failed publish keeps the previous triggers, so it runs again on the next apply
+	config["triggers"] = map[string]any{"asset": "2"}
+	server.FailNextPublish("invalid policy")
+	failedState, err := apitest.TryApply(r, state, config, c)
+	if err == nil || failedState.Attributes["triggers.asset"] != "1" {
+		t.Fatalf("expected a failed publish with the previous triggers, got %v: %#v", err, failedState.Attributes)
+	}
+
+	state = apitest.Apply(t, r, failedState, config, c)
+	if publishes() != 3 || state.Attributes["triggers.asset"] != "2" || state.Attributes["publish"] != "true" {
+		t.Fatalf("expected a publish after the triggers changed, got %d publishes: %#v", publishes(), state.Attributes)
+	}
+}
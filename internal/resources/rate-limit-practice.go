@@ -2,7 +2,7 @@ package resources
 
 import (
 	"context"
-	"strings"
+	"errors"
 
 	models "github.com/CheckPointSW/terraform-provider-infinity-next/internal/models/rate-limit-practice"
 	webAppAssetModels "github.com/CheckPointSW/terraform-provider-infinity-next/internal/models/web-app-asset"
@@ -36,6 +36,7 @@ func ResourceRateLimitPractice() *schema.Resource {
 		ReadContext:   resourceRateLimitPracticeRead,
 		UpdateContext: resourceRateLimitPracticeUpdate,
 		DeleteContext: resourceRateLimitPracticeDelete,
+		Timeouts:      utils.ResourceTimeouts(utils.DefaultTimeout),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -125,16 +126,16 @@ func resourceRateLimitPracticeCreate(ctx context.Context, d *schema.ResourceData
 
 	practice, err := ratelimitpractice.NewRateLimitPracticePractice(ctx, c, createInput)
 	if err != nil {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
 		return utils.DiagError("unable to perform RateLimitPractice Create", err, diags)
 	}
 
-	isValid, err := c.PublishChanges()
+	isValid, err := c.PublishChanges(ctx)
 	if err != nil || !isValid {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -142,7 +143,7 @@ func resourceRateLimitPracticeCreate(ctx context.Context, d *schema.ResourceData
 	}
 
 	if err := ratelimitpractice.ReadRateLimitPracticeToResourceData(practice, d); err != nil {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -157,7 +158,11 @@ func resourceRateLimitPracticeRead(ctx context.Context, d *schema.ResourceData,
 	c := meta.(*api.Client)
 	id := d.Id()
 
-	practice, err := ratelimitpractice.GetRateLimitPractice(ctx, c, id, false)
+	practice, err := ratelimitpractice.GetRateLimitPractice(ctx, c, id, true)
+	if errors.Is(err, api.ErrorNotFound) {
+		return utils.DiagNotFound(d, "RateLimitPractice", diags)
+	}
+
 	if err != nil {
 		return utils.DiagError("unable to get rate limit practice to perform RateLimitPractice Read", err, diags)
 	}
@@ -180,16 +185,16 @@ func resourceRateLimitPracticeUpdate(ctx context.Context, d *schema.ResourceData
 
 	result, err := ratelimitpractice.UpdateRateLimitPractice(ctx, c, d.Id(), updateInput)
 	if err != nil || !result {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
 		return utils.DiagError("unable to perform RateLimitPractice Update", err, diags)
 	}
 
-	isValid, err := c.PublishChanges()
+	isValid, err := c.PublishChanges(ctx)
 	if err != nil || !isValid {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -198,7 +203,7 @@ func resourceRateLimitPracticeUpdate(ctx context.Context, d *schema.ResourceData
 
 	practice, err := ratelimitpractice.GetRateLimitPractice(ctx, c, d.Id(), true)
 	if err != nil {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes after publish", discardErr, diags)
 		}
 
@@ -206,7 +211,7 @@ func resourceRateLimitPracticeUpdate(ctx context.Context, d *schema.ResourceData
 	}
 
 	if err := ratelimitpractice.ReadRateLimitPracticeToResourceData(practice, d); err != nil {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -223,7 +228,8 @@ func resourceRateLimitPracticeDelete(ctx context.Context, d *schema.ResourceData
 	result, err := ratelimitpractice.DeleteRateLimitPractice(ctx, c, d.Id())
 	if err != nil || !result {
 		// Check if the error is due to the rate limit practice being used by other resources
-		if err != nil && strings.Contains(err.Error(), "can't be deleted since it is pointed from other objects") {
+		var inUseErr *api.ObjectInUseError
+		if errors.As(err, &inUseErr) {
 			// Get the resources that are using the rate limit practice
 			usedBy, err2 := ratelimitpractice.UsedByRateLimitPractice(ctx, c, d.Id())
 			if err2 != nil {
@@ -241,7 +247,7 @@ func resourceRateLimitPracticeDelete(ctx context.Context, d *schema.ResourceData
 				// Retry to delete the rate limit practice
 				result, err := ratelimitpractice.DeleteRateLimitPractice(ctx, c, d.Id())
 				if err != nil || !result {
-					if _, discardErr := c.DiscardChanges(); discardErr != nil {
+					if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 						diags = utils.DiagError("failed to discard changes", discardErr, diags)
 					}
 
@@ -251,7 +257,7 @@ func resourceRateLimitPracticeDelete(ctx context.Context, d *schema.ResourceData
 			}
 
 		} else {
-			if _, discardErr := c.DiscardChanges(); discardErr != nil {
+			if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 				diags = utils.DiagError("failed to discard changes", discardErr, diags)
 			}
 
@@ -259,9 +265,9 @@ func resourceRateLimitPracticeDelete(ctx context.Context, d *schema.ResourceData
 		}
 	}
 
-	isValid, err := c.PublishChanges()
+	isValid, err := c.PublishChanges(ctx)
 	if err != nil || !isValid {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -289,7 +295,7 @@ func handleRateLimitPracticeReferences(ctx context.Context, usedBy models.Displa
 
 			updated, err := webappasset.UpdateWebApplicationAsset(ctx, c, usedByResource.ID, webAppAsset)
 			if err != nil || !updated {
-				if _, discardErr := c.DiscardChanges(); discardErr != nil {
+				if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 					diags = utils.DiagError("failed to discard changes", discardErr, diags)
 				}
 
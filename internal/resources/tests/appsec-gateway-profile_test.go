@@ -262,3 +262,41 @@ resource "inext_appsec_gateway_profile" %[1]q {
 }
 `, name)
 }
+
+func TestAccAppsecGatewayProfileDataSource(t *testing.T) {
+	nameAttribute := acctest.GenerateResourceName()
+	resourceName := "inext_appsec_gateway_profile." + nameAttribute
+	dataSourceByIDName := "data.inext_appsec_gateway_profile.by_id"
+	dataSourceByNameName := "data.inext_appsec_gateway_profile.by_name"
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      acctest.CheckResourceDestroyed([]string{resourceName}),
+		Steps: []resource.TestStep{
+			{
+				Config: appsecGatewayProfileDataSourceConfig(nameAttribute),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceByIDName, "id", resourceName, "id"),
+					resource.TestCheckResourceAttrPair(dataSourceByIDName, "name", resourceName, "name"),
+					resource.TestCheckResourceAttrPair(dataSourceByIDName, "profile_sub_type", resourceName, "profile_sub_type"),
+					resource.TestCheckResourceAttrPair(dataSourceByIDName, "max_number_of_agents", resourceName, "max_number_of_agents"),
+					resource.TestCheckResourceAttrPair(dataSourceByIDName, "certificate_type", resourceName, "certificate_type"),
+					resource.TestCheckResourceAttrPair(dataSourceByNameName, "id", resourceName, "id"),
+					resource.TestCheckResourceAttrPair(dataSourceByNameName, "profile_sub_type", resourceName, "profile_sub_type"),
+				),
+			},
+		},
+	})
+}
+
+func appsecGatewayProfileDataSourceConfig(name string) string {
+	return appsecGatewayProfileBasicConfig(name) + fmt.Sprintf(`
+data "inext_appsec_gateway_profile" "by_id" {
+	id = inext_appsec_gateway_profile.%[1]s.id
+}
+
+data "inext_appsec_gateway_profile" "by_name" {
+	name = inext_appsec_gateway_profile.%[1]s.name
+}
+`, name)
+}
@@ -0,0 +1,25 @@
+package tests
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/acctest"
+)
+
+func TestCassettesSanitized(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join(acctest.CassettesDir, "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(paths) == 0 {
+		t.Skip("no cassettes recorded, record them against a tenant with make testacc-record")
+	}
+
+	for _, path := range paths {
+		if err := acctest.CheckCassette(path); err != nil {
+			t.Error(err)
+		}
+	}
+}
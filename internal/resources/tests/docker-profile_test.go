@@ -162,3 +162,39 @@ resource "inext_docker_profile" %[1]q {
 }
 `, name)
 }
+
+func TestAccDockerProfileDataSource(t *testing.T) {
+	nameAttribute := acctest.GenerateResourceName()
+	resourceName := "inext_docker_profile." + nameAttribute
+	dataSourceByIDName := "data.inext_docker_profile.by_id"
+	dataSourceByNameName := "data.inext_docker_profile.by_name"
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      acctest.CheckResourceDestroyed([]string{resourceName}),
+		Steps: []resource.TestStep{
+			{
+				Config: dockerProfileDataSourceConfig(nameAttribute),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceByIDName, "id", resourceName, "id"),
+					resource.TestCheckResourceAttrPair(dataSourceByIDName, "name", resourceName, "name"),
+					resource.TestCheckResourceAttrPair(dataSourceByIDName, "max_number_of_agents", resourceName, "max_number_of_agents"),
+					resource.TestCheckResourceAttrPair(dataSourceByNameName, "id", resourceName, "id"),
+					resource.TestCheckResourceAttrPair(dataSourceByNameName, "max_number_of_agents", resourceName, "max_number_of_agents"),
+				),
+			},
+		},
+	})
+}
+
+func dockerProfileDataSourceConfig(name string) string {
+	return dockerProfileBasicConfig(name) + fmt.Sprintf(`
+data "inext_docker_profile" "by_id" {
+	id = inext_docker_profile.%[1]s.id
+}
+
+data "inext_docker_profile" "by_name" {
+	name = inext_docker_profile.%[1]s.name
+}
+`, name)
+}
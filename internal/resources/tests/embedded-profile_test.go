@@ -208,3 +208,39 @@ resource "inext_embedded_profile" %[1]q {
 }
 `, name)
 }
+
+func TestAccEmbeddedProfileDataSource(t *testing.T) {
+	nameAttribute := acctest.GenerateResourceName()
+	resourceName := "inext_embedded_profile." + nameAttribute
+	dataSourceByIDName := "data.inext_embedded_profile.by_id"
+	dataSourceByNameName := "data.inext_embedded_profile.by_name"
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      acctest.CheckResourceDestroyed([]string{resourceName}),
+		Steps: []resource.TestStep{
+			{
+				Config: embeddedProfileDataSourceConfig(nameAttribute),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceByIDName, "id", resourceName, "id"),
+					resource.TestCheckResourceAttrPair(dataSourceByIDName, "name", resourceName, "name"),
+					resource.TestCheckResourceAttrPair(dataSourceByIDName, "max_number_of_agents", resourceName, "max_number_of_agents"),
+					resource.TestCheckResourceAttrPair(dataSourceByNameName, "id", resourceName, "id"),
+					resource.TestCheckResourceAttrPair(dataSourceByNameName, "max_number_of_agents", resourceName, "max_number_of_agents"),
+				),
+			},
+		},
+	})
+}
+
+func embeddedProfileDataSourceConfig(name string) string {
+	return embeddedProfileBasicConfig(name) + fmt.Sprintf(`
+data "inext_embedded_profile" "by_id" {
+	id = inext_embedded_profile.%[1]s.id
+}
+
+data "inext_embedded_profile" "by_name" {
+	name = inext_embedded_profile.%[1]s.name
+}
+`, name)
+}
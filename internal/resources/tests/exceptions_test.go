@@ -406,3 +406,37 @@ resource "inext_exceptions" %[1]q {
 }
 `, name)
 }
+
+func TestAccExceptionDataSource(t *testing.T) {
+	nameAttribute := acctest.GenerateResourceName()
+	resourceName := "inext_exceptions." + nameAttribute
+	dataSourceByIDName := "data.inext_exceptions.by_id"
+	dataSourceByNameName := "data.inext_exceptions.by_name"
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      acctest.CheckResourceDestroyed([]string{resourceName}),
+		Steps: []resource.TestStep{
+			{
+				Config: exceptionDataSourceConfig(nameAttribute),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceByIDName, "id", resourceName, "id"),
+					resource.TestCheckResourceAttrPair(dataSourceByIDName, "name", resourceName, "name"),
+					resource.TestCheckResourceAttrPair(dataSourceByNameName, "id", resourceName, "id"),
+				),
+			},
+		},
+	})
+}
+
+func exceptionDataSourceConfig(name string) string {
+	return exceptionsBasicConfig(name) + fmt.Sprintf(`
+data "inext_exceptions" "by_id" {
+	id = inext_exceptions.%[1]s.id
+}
+
+data "inext_exceptions" "by_name" {
+	name = inext_exceptions.%[1]s.name
+}
+`, name)
+}
@@ -170,3 +170,77 @@ resource "inext_kubernetes_profile" %[1]q {
 }
 `, name)
 }
+
+func TestAccKubernetesProfileDataSource(t *testing.T) {
+	nameAttribute := acctest.GenerateResourceName()
+	resourceName := "inext_kubernetes_profile." + nameAttribute
+	dataSourceName := "data.inext_kubernetes_profile.by_name"
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      acctest.CheckResourceDestroyed([]string{resourceName}),
+		Steps: []resource.TestStep{
+			{
+				Config: kubernetesProfileDataSourceConfig(nameAttribute),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceName, "id", resourceName, "id"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "profile_sub_type", resourceName, "profile_sub_type"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "max_number_of_agents", resourceName, "max_number_of_agents"),
+					resource.TestCheckResourceAttr(dataSourceName, "profile_type", "Kubernetes"),
+				),
+			},
+		},
+	})
+}
+
+func kubernetesProfileDataSourceConfig(name string) string {
+	return fmt.Sprintf(`
+resource "inext_kubernetes_profile" %[1]q {
+	name             = %[1]q
+	profile_sub_type = "AccessControl"
+}
+
+data "inext_kubernetes_profile" "by_name" {
+	name = inext_kubernetes_profile.%[1]s.name
+}
+`, name)
+}
+
+func TestAccProfilesDataSource(t *testing.T) {
+	nameAttribute := acctest.GenerateResourceName()
+	resourceName := "inext_kubernetes_profile." + nameAttribute
+	dataSourceName := "data.inext_profiles.kubernetes"
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      acctest.CheckResourceDestroyed([]string{resourceName}),
+		Steps: []resource.TestStep{
+			{
+				Config: profilesDataSourceConfig(nameAttribute),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "ids.#", "1"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "ids.0", resourceName, "id"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "profiles.0.id", resourceName, "id"),
+					resource.TestCheckResourceAttr(dataSourceName, "profiles.0.name", nameAttribute),
+					resource.TestCheckResourceAttr(dataSourceName, "profiles.0.profile_type", "Kubernetes"),
+					resource.TestCheckResourceAttr(dataSourceName, "profiles.0.profile_sub_type", "AccessControl"),
+				),
+			},
+		},
+	})
+}
+
+func profilesDataSourceConfig(name string) string {
+	return fmt.Sprintf(`
+resource "inext_kubernetes_profile" %[1]q {
+	name             = %[1]q
+	profile_sub_type = "AccessControl"
+}
+
+data "inext_profiles" "kubernetes" {
+	name_regex       = "^${inext_kubernetes_profile.%[1]s.name}$"
+	profile_type     = ["Kubernetes"]
+	profile_sub_type = ["AccessControl"]
+}
+`, name)
+}
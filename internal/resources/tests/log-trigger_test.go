@@ -122,3 +122,44 @@ resource "inext_log_trigger" %[1]q {
 }
 `, name)
 }
+
+func TestAccLogTriggerDataSource(t *testing.T) {
+	nameAttribute := acctest.GenerateResourceName()
+	resourceName := "inext_log_trigger." + nameAttribute
+	dataSourceByIDName := "data.inext_log_trigger.by_id"
+	dataSourceByNameName := "data.inext_log_trigger.by_name"
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      acctest.CheckResourceDestroyed([]string{resourceName}),
+		Steps: []resource.TestStep{
+			{
+				Config: logTriggerDataSourceConfig(nameAttribute),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceByIDName, "id", resourceName, "id"),
+					resource.TestCheckResourceAttrPair(dataSourceByIDName, "name", resourceName, "name"),
+					resource.TestCheckResourceAttrPair(dataSourceByIDName, "verbosity", resourceName, "verbosity"),
+					resource.TestCheckResourceAttrPair(dataSourceByNameName, "id", resourceName, "id"),
+					resource.TestCheckResourceAttrPair(dataSourceByNameName, "syslog_protocol", resourceName, "syslog_protocol"),
+				),
+			},
+		},
+	})
+}
+
+func logTriggerDataSourceConfig(name string) string {
+	return fmt.Sprintf(`
+resource "inext_log_trigger" %[1]q {
+	name      = %[1]q
+	verbosity = "Extended"
+}
+
+data "inext_log_trigger" "by_id" {
+	id = inext_log_trigger.%[1]s.id
+}
+
+data "inext_log_trigger" "by_name" {
+	name = inext_log_trigger.%[1]s.name
+}
+`, name)
+}
@@ -159,3 +159,39 @@ func rateLimitPracticeUpdateConfig(name string) string {
 		}
 	}`, name)
 }
+
+func TestAccRateLimitPracticeDataSource(t *testing.T) {
+	nameAttribute := acctest.GenerateResourceName()
+	resourceName := "inext_rate_limit_practice." + nameAttribute
+	dataSourceByIDName := "data.inext_rate_limit_practice.by_id"
+	dataSourceByNameName := "data.inext_rate_limit_practice.by_name"
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      acctest.CheckResourceDestroyed([]string{resourceName}),
+		Steps: []resource.TestStep{
+			{
+				Config: rateLimitPracticeDataSourceConfig(nameAttribute),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceByIDName, "id", resourceName, "id"),
+					resource.TestCheckResourceAttrPair(dataSourceByIDName, "name", resourceName, "name"),
+					resource.TestCheckResourceAttrPair(dataSourceByIDName, "rule.#", resourceName, "rule.#"),
+					resource.TestCheckResourceAttrPair(dataSourceByNameName, "id", resourceName, "id"),
+					resource.TestCheckResourceAttrPair(dataSourceByNameName, "rule.#", resourceName, "rule.#"),
+				),
+			},
+		},
+	})
+}
+
+func rateLimitPracticeDataSourceConfig(name string) string {
+	return rateLimitPracticeBasicConfig(name) + fmt.Sprintf(`
+data "inext_rate_limit_practice" "by_id" {
+	id = inext_rate_limit_practice.%[1]s.id
+}
+
+data "inext_rate_limit_practice" "by_name" {
+	name = inext_rate_limit_practice.%[1]s.name
+}
+`, name)
+}
@@ -145,3 +145,72 @@ resource "inext_trusted_sources" %[1]q {
 }
 `, name)
 }
+
+func TestAccTrustedSourcesDataSource(t *testing.T) {
+	nameAttribute := acctest.GenerateResourceName()
+	resourceName := "inext_trusted_sources." + nameAttribute
+	dataSourceByIDName := "data.inext_trusted_sources.by_id"
+	dataSourceByNameName := "data.inext_trusted_sources.by_name"
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      acctest.CheckResourceDestroyed([]string{resourceName}),
+		Steps: []resource.TestStep{
+			{
+				Config: trustedSourcesDataSourceConfig(nameAttribute),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceByIDName, "id", resourceName, "id"),
+					resource.TestCheckResourceAttrPair(dataSourceByIDName, "name", resourceName, "name"),
+					resource.TestCheckResourceAttrPair(dataSourceByIDName, "min_num_of_sources", resourceName, "min_num_of_sources"),
+					resource.TestCheckResourceAttrPair(dataSourceByNameName, "id", resourceName, "id"),
+					resource.TestCheckResourceAttrPair(dataSourceByNameName, "min_num_of_sources", resourceName, "min_num_of_sources"),
+				),
+			},
+		},
+	})
+}
+
+func trustedSourcesDataSourceConfig(name string) string {
+	return trustedSourcesBasicConfig(name) + fmt.Sprintf(`
+data "inext_trusted_sources" "by_id" {
+	id = inext_trusted_sources.%[1]s.id
+}
+
+data "inext_trusted_sources" "by_name" {
+	name = inext_trusted_sources.%[1]s.name
+}
+`, name)
+}
+
+func TestAccBehaviorsDataSource(t *testing.T) {
+	trustedSourcesName := acctest.GenerateResourceName()
+	webUserResponseName := acctest.GenerateResourceName()
+	trustedSourcesResourceName := "inext_trusted_sources." + trustedSourcesName
+	webUserResponseResourceName := "inext_web_user_response." + webUserResponseName
+	dataSourceName := "data.inext_behaviors.trusted_sources"
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      acctest.CheckResourceDestroyed([]string{trustedSourcesResourceName, webUserResponseResourceName}),
+		Steps: []resource.TestStep{
+			{
+				Config: behaviorsDataSourceConfig(trustedSourcesName, webUserResponseName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "ids.#", "1"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "ids.0", trustedSourcesResourceName, "id"),
+					resource.TestCheckResourceAttr(dataSourceName, "behaviors.0.name", trustedSourcesName),
+					resource.TestCheckResourceAttr(dataSourceName, "behaviors.0.behavior_type", "TrustedSource"),
+				),
+			},
+		},
+	})
+}
+
+func behaviorsDataSourceConfig(trustedSourcesName, webUserResponseName string) string {
+	return trustedSourcesBasicConfig(trustedSourcesName) + webUserResponseBasicConfig(webUserResponseName) + fmt.Sprintf(`
+data "inext_behaviors" "trusted_sources" {
+	name_regex    = "^(${inext_trusted_sources.%[1]s.name}|${inext_web_user_response.%[2]s.name})$"
+	behavior_type = ["TrustedSource"]
+}
+`, trustedSourcesName, webUserResponseName)
+}
@@ -1009,3 +1009,39 @@ resource "inext_exceptions" %[10]q {
 `, assetName, profileName, trustedSourcesName, practiceName, logTriggerName, exceptionsName,
 		anotherProfileName, anotherTrustedSourcesName, anotherLogTriggerName, anotherExcpetionsName)
 }
+
+func TestAccWebAPIAssetDataSource(t *testing.T) {
+	nameAttribute := acctest.GenerateResourceName()
+	resourceName := "inext_web_api_asset." + nameAttribute
+	dataSourceByIDName := "data.inext_web_api_asset.by_id"
+	dataSourceByNameName := "data.inext_web_api_asset.by_name"
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      acctest.CheckResourceDestroyed([]string{resourceName}),
+		Steps: []resource.TestStep{
+			{
+				Config: webAPIAssetDataSourceConfig(nameAttribute),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceByIDName, "id", resourceName, "id"),
+					resource.TestCheckResourceAttrPair(dataSourceByIDName, "name", resourceName, "name"),
+					resource.TestCheckResourceAttrPair(dataSourceByIDName, "urls.#", resourceName, "urls.#"),
+					resource.TestCheckResourceAttrPair(dataSourceByNameName, "id", resourceName, "id"),
+					resource.TestCheckResourceAttrPair(dataSourceByNameName, "urls.#", resourceName, "urls.#"),
+				),
+			},
+		},
+	})
+}
+
+func webAPIAssetDataSourceConfig(name string) string {
+	return webAPIAssetBasicConfig(name) + fmt.Sprintf(`
+data "inext_web_api_asset" "by_id" {
+	id = inext_web_api_asset.%[1]s.id
+}
+
+data "inext_web_api_asset" "by_name" {
+	name = inext_web_api_asset.%[1]s.name
+}
+`, name)
+}
@@ -432,3 +432,40 @@ resource "inext_web_api_practice" %[1]q {
 }
 `, name, filename, data)
 }
+
+func TestAccWebAPIPracticeDataSource(t *testing.T) {
+	nameAttribute := acctest.GenerateResourceName()
+	resourceName := "inext_web_api_practice." + nameAttribute
+	dataSourceByIDName := "data.inext_web_api_practice.by_id"
+	dataSourceByNameName := "data.inext_web_api_practice.by_name"
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      acctest.CheckResourceDestroyed([]string{resourceName}),
+		Steps: []resource.TestStep{
+			{
+				Config: webAPIPracticeDataSourceConfig(nameAttribute),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceByIDName, "id", resourceName, "id"),
+					resource.TestCheckResourceAttrPair(dataSourceByIDName, "name", resourceName, "name"),
+					resource.TestCheckResourceAttrPair(dataSourceByIDName, "ips.#", resourceName, "ips.#"),
+					resource.TestCheckResourceAttrPair(dataSourceByIDName, "api_attacks.#", resourceName, "api_attacks.#"),
+					resource.TestCheckResourceAttrPair(dataSourceByNameName, "id", resourceName, "id"),
+					resource.TestCheckResourceAttrPair(dataSourceByNameName, "ips.#", resourceName, "ips.#"),
+				),
+			},
+		},
+	})
+}
+
+func webAPIPracticeDataSourceConfig(name string) string {
+	return webAPIPracticeBasicConfig(name) + fmt.Sprintf(`
+data "inext_web_api_practice" "by_id" {
+	id = inext_web_api_practice.%[1]s.id
+}
+
+data "inext_web_api_practice" "by_name" {
+	name = inext_web_api_practice.%[1]s.name
+}
+`, name)
+}
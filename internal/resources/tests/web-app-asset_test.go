@@ -1003,3 +1003,102 @@ resource "inext_exceptions" %[10]q {
 `, assetName, profileName, trustedSourcesName, practiceName, logTriggerName, exceptionsName,
 		anotherProfileName, anotherTrustedSourcesName, anotherLogTriggerName, anotherExcpetionsName)
 }
+
+func TestAccWebApplicationAssetDataSource(t *testing.T) {
+	nameAttribute := acctest.GenerateResourceName()
+	resourceName := "inext_web_app_asset." + nameAttribute
+	dataSourceByIDName := "data.inext_web_app_asset.by_id"
+	dataSourceByNameName := "data.inext_web_app_asset.by_name"
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      acctest.CheckResourceDestroyed([]string{resourceName}),
+		Steps: []resource.TestStep{
+			{
+				Config: webApplicationAssetDataSourceConfig(nameAttribute),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceByIDName, "id", resourceName, "id"),
+					resource.TestCheckResourceAttrPair(dataSourceByIDName, "name", resourceName, "name"),
+					resource.TestCheckResourceAttrPair(dataSourceByIDName, "urls.#", resourceName, "urls.#"),
+					resource.TestCheckResourceAttrPair(dataSourceByNameName, "id", resourceName, "id"),
+					resource.TestCheckResourceAttrPair(dataSourceByNameName, "urls.#", resourceName, "urls.#"),
+				),
+			},
+		},
+	})
+}
+
+func webApplicationAssetDataSourceConfig(name string) string {
+	return webApplicationAssetBasicConfig(name) + fmt.Sprintf(`
+data "inext_web_app_asset" "by_id" {
+	id = inext_web_app_asset.%[1]s.id
+}
+
+data "inext_web_app_asset" "by_name" {
+	name = inext_web_app_asset.%[1]s.name
+}
+`, name)
+}
+
+func TestAccWebAppAssetsDataSource(t *testing.T) {
+	activeName := acctest.GenerateResourceName()
+	inactiveName := acctest.GenerateResourceName()
+	activeResourceName := "inext_web_app_asset." + activeName
+	inactiveResourceName := "inext_web_app_asset." + inactiveName
+	byStateName := "data.inext_web_app_assets.by_state"
+	byTagsName := "data.inext_web_app_assets.by_tags"
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      acctest.CheckResourceDestroyed([]string{activeResourceName, inactiveResourceName}),
+		Steps: []resource.TestStep{
+			{
+				Config: webAppAssetsDataSourceConfig(activeName, inactiveName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(byStateName, "ids.#", "1"),
+					resource.TestCheckResourceAttrPair(byStateName, "ids.0", inactiveResourceName, "id"),
+					resource.TestCheckResourceAttr(byStateName, "assets.0.name", inactiveName),
+					resource.TestCheckResourceAttr(byStateName, "assets.0.state", "InActive"),
+					resource.TestCheckResourceAttr(byTagsName, "ids.#", "1"),
+					resource.TestCheckResourceAttrPair(byTagsName, "ids.0", activeResourceName, "id"),
+					resource.TestCheckResourceAttr(byTagsName, "assets.0.tags.env", "prod"),
+				),
+			},
+		},
+	})
+}
+
+func webAppAssetsDataSourceConfig(activeName, inactiveName string) string {
+	return fmt.Sprintf(`
+resource "inext_web_app_asset" %[1]q {
+	name = %[1]q
+	urls = ["http://host/%[1]s/path1"]
+	tags {
+		key   = "env"
+		value = "prod"
+	}
+}
+
+resource "inext_web_app_asset" %[2]q {
+	name  = %[2]q
+	urls  = ["http://host/%[2]s/path1"]
+	state = "InActive"
+	tags {
+		key   = "env"
+		value = "dev"
+	}
+}
+
+data "inext_web_app_assets" "by_state" {
+	name_regex = "^(${inext_web_app_asset.%[1]s.name}|${inext_web_app_asset.%[2]s.name})$"
+	state      = ["InActive"]
+}
+
+data "inext_web_app_assets" "by_tags" {
+	name_regex = "^(${inext_web_app_asset.%[1]s.name}|${inext_web_app_asset.%[2]s.name})$"
+	tags = {
+		env = "prod"
+	}
+}
+`, activeName, inactiveName)
+}
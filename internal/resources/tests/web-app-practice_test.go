@@ -474,3 +474,73 @@ resource "inext_web_app_practice" %[1]q {
 }
 `, name)
 }
+
+func TestAccWebAppPracticeDataSource(t *testing.T) {
+	nameAttribute := acctest.GenerateResourceName()
+	resourceName := "inext_web_app_practice." + nameAttribute
+	dataSourceByIDName := "data.inext_web_app_practice.by_id"
+	dataSourceByNameName := "data.inext_web_app_practice.by_name"
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      acctest.CheckResourceDestroyed([]string{resourceName}),
+		Steps: []resource.TestStep{
+			{
+				Config: webAppPracticeDataSourceConfig(nameAttribute),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceByIDName, "id", resourceName, "id"),
+					resource.TestCheckResourceAttrPair(dataSourceByIDName, "name", resourceName, "name"),
+					resource.TestCheckResourceAttrPair(dataSourceByIDName, "web_attacks.#", resourceName, "web_attacks.#"),
+					resource.TestCheckResourceAttrPair(dataSourceByNameName, "id", resourceName, "id"),
+					resource.TestCheckResourceAttrPair(dataSourceByNameName, "web_attacks.#", resourceName, "web_attacks.#"),
+				),
+			},
+		},
+	})
+}
+
+func webAppPracticeDataSourceConfig(name string) string {
+	return webAppPracticeBasicConfig(name) + fmt.Sprintf(`
+data "inext_web_app_practice" "by_id" {
+	id = inext_web_app_practice.%[1]s.id
+}
+
+data "inext_web_app_practice" "by_name" {
+	name = inext_web_app_practice.%[1]s.name
+}
+`, name)
+}
+
+func TestAccPracticesDataSource(t *testing.T) {
+	webAppPracticeName := acctest.GenerateResourceName()
+	rateLimitPracticeName := acctest.GenerateResourceName()
+	webAppPracticeResourceName := "inext_web_app_practice." + webAppPracticeName
+	rateLimitPracticeResourceName := "inext_rate_limit_practice." + rateLimitPracticeName
+	dataSourceName := "data.inext_practices.rate_limit"
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      acctest.CheckResourceDestroyed([]string{webAppPracticeResourceName, rateLimitPracticeResourceName}),
+		Steps: []resource.TestStep{
+			{
+				Config: practicesDataSourceConfig(webAppPracticeName, rateLimitPracticeName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "ids.#", "1"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "ids.0", rateLimitPracticeResourceName, "id"),
+					resource.TestCheckResourceAttr(dataSourceName, "practices.0.name", rateLimitPracticeName),
+					resource.TestCheckResourceAttr(dataSourceName, "practices.0.practice_type", "RateLimit"),
+				),
+			},
+		},
+	})
+}
+
+func practicesDataSourceConfig(webAppPracticeName, rateLimitPracticeName string) string {
+	return webAppPracticeBasicConfig(webAppPracticeName) + "\n" + rateLimitPracticeBasicConfig(rateLimitPracticeName) + fmt.Sprintf(`
+
+data "inext_practices" "rate_limit" {
+	name_regex    = "^(${inext_web_app_practice.%[1]s.name}|${inext_rate_limit_practice.%[2]s.name})$"
+	practice_type = ["RateLimit"]
+}
+`, webAppPracticeName, rateLimitPracticeName)
+}
@@ -138,3 +138,40 @@ resource "inext_web_user_response" %[1]q {
 }
 `, name)
 }
+
+func TestAccWebUserResponseDataSource(t *testing.T) {
+	nameAttribute := acctest.GenerateResourceName()
+	resourceName := "inext_web_user_response." + nameAttribute
+	dataSourceByIDName := "data.inext_web_user_response.by_id"
+	dataSourceByNameName := "data.inext_web_user_response.by_name"
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      acctest.CheckResourceDestroyed([]string{resourceName}),
+		Steps: []resource.TestStep{
+			{
+				Config: webUserResponseDataSourceConfig(nameAttribute),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceByIDName, "id", resourceName, "id"),
+					resource.TestCheckResourceAttrPair(dataSourceByIDName, "name", resourceName, "name"),
+					resource.TestCheckResourceAttrPair(dataSourceByIDName, "mode", resourceName, "mode"),
+					resource.TestCheckResourceAttrPair(dataSourceByIDName, "http_response_code", resourceName, "http_response_code"),
+					resource.TestCheckResourceAttrPair(dataSourceByNameName, "id", resourceName, "id"),
+					resource.TestCheckResourceAttrPair(dataSourceByNameName, "mode", resourceName, "mode"),
+				),
+			},
+		},
+	})
+}
+
+func webUserResponseDataSourceConfig(name string) string {
+	return webUserResponseBasicConfig(name) + fmt.Sprintf(`
+data "inext_web_user_response" "by_id" {
+	id = inext_web_user_response.%[1]s.id
+}
+
+data "inext_web_user_response" "by_name" {
+	name = inext_web_user_response.%[1]s.name
+}
+`, name)
+}
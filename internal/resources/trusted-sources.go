@@ -2,7 +2,7 @@ package resources
 
 import (
 	"context"
-	"strings"
+	"errors"
 
 	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/api"
 	models "github.com/CheckPointSW/terraform-provider-infinity-next/internal/models/trusted-sources"
@@ -29,6 +29,7 @@ func ResourceTrustedSources() *schema.Resource {
 		ReadContext:   resourceTrustedSourcesRead,
 		UpdateContext: resourceTrustedSourcesUpdate,
 		DeleteContext: resourceTrustedSourcesDelete,
+		Timeouts:      utils.ResourceTimeouts(utils.DefaultTimeout),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -91,16 +92,16 @@ func resourceTrustedSourcesCreate(ctx context.Context, d *schema.ResourceData, m
 
 	behavior, err := trustedsources.NewTrustedSourceBehavior(ctx, c, createInput)
 	if err != nil {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
 		return utils.DiagError("unable to perform TrustedSourceBehavior Create", err, diags)
 	}
 
-	isValid, err := c.PublishChanges()
+	isValid, err := c.PublishChanges(ctx)
 	if err != nil || !isValid {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -108,7 +109,7 @@ func resourceTrustedSourcesCreate(ctx context.Context, d *schema.ResourceData, m
 	}
 
 	if err := trustedsources.ReadTrustedSourceBehaviorToResourceData(behavior, d); err != nil {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -123,8 +124,12 @@ func resourceTrustedSourcesRead(ctx context.Context, d *schema.ResourceData, met
 	c := meta.(*api.Client)
 
 	behavior, err := trustedsources.GetTrustedSourceBehavior(ctx, c, d.Id())
+	if errors.Is(err, api.ErrorNotFound) {
+		return utils.DiagNotFound(d, "TrustedSourceBehavior", diags)
+	}
+
 	if err != nil {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -132,7 +137,7 @@ func resourceTrustedSourcesRead(ctx context.Context, d *schema.ResourceData, met
 	}
 
 	if err := trustedsources.ReadTrustedSourceBehaviorToResourceData(behavior, d); err != nil {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -153,16 +158,16 @@ func resourceTrustedSourcesUpdate(ctx context.Context, d *schema.ResourceData, m
 
 	result, err := trustedsources.UpdateTrustedSourceBehavior(ctx, c, d.Id(), updateInput)
 	if err != nil || !result {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
 		return utils.DiagError("unable to perform TrustedSourceBehavior Update", err, diags)
 	}
 
-	isValid, err := c.PublishChanges()
+	isValid, err := c.PublishChanges(ctx)
 	if err != nil || !isValid {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -171,7 +176,7 @@ func resourceTrustedSourcesUpdate(ctx context.Context, d *schema.ResourceData, m
 
 	behavior, err := trustedsources.GetTrustedSourceBehavior(ctx, c, d.Id())
 	if err != nil {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -179,7 +184,7 @@ func resourceTrustedSourcesUpdate(ctx context.Context, d *schema.ResourceData, m
 	}
 
 	if err := trustedsources.ReadTrustedSourceBehaviorToResourceData(behavior, d); err != nil {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -196,7 +201,8 @@ func resourceTrustedSourcesDelete(ctx context.Context, d *schema.ResourceData, m
 	result, err := trustedsources.DeleteTrustedSourceBehavior(ctx, c, d.Id())
 	if err != nil || !result {
 		// Check if the error is due to the trusted source behavior being used by other resources
-		if err != nil && strings.Contains(err.Error(), errorMsgPointedObjects) {
+		var inUseErr *api.ObjectInUseError
+		if errors.As(err, &inUseErr) {
 			// Get the resources that are using the trusted source behavior
 			usedBy, err2 := trustedsources.UsedByTrustedSourceBehavior(ctx, c, d.Id())
 			if err2 != nil {
@@ -214,7 +220,7 @@ func resourceTrustedSourcesDelete(ctx context.Context, d *schema.ResourceData, m
 				// Retry to delete the trusted source behavior
 				result, err := trustedsources.DeleteTrustedSourceBehavior(ctx, c, d.Id())
 				if err != nil || !result {
-					if _, discardErr := c.DiscardChanges(); discardErr != nil {
+					if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 						diags = utils.DiagError("failed to discard changes", discardErr, diags)
 					}
 
@@ -224,7 +230,7 @@ func resourceTrustedSourcesDelete(ctx context.Context, d *schema.ResourceData, m
 			}
 
 		} else {
-			if _, discardErr := c.DiscardChanges(); discardErr != nil {
+			if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 				diags = utils.DiagError("failed to discard changes", discardErr, diags)
 			}
 
@@ -232,9 +238,9 @@ func resourceTrustedSourcesDelete(ctx context.Context, d *schema.ResourceData, m
 		}
 	}
 
-	isValid, err := c.PublishChanges()
+	isValid, err := c.PublishChanges(ctx)
 	if err != nil || !isValid {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -262,7 +268,7 @@ func handleTrustedSourceReferences(ctx context.Context, usedBy models.DisplayObj
 
 			updated, err := webapiasset.UpdateWebAPIAsset(ctx, c, usedByResource.ID, webAPIAsset)
 			if err != nil || !updated {
-				if _, discardErr := c.DiscardChanges(); discardErr != nil {
+				if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 					diags = utils.DiagError("failed to discard changes", discardErr, diags)
 				}
 
@@ -276,7 +282,7 @@ func handleTrustedSourceReferences(ctx context.Context, usedBy models.DisplayObj
 
 			updated, err := webappasset.UpdateWebApplicationAsset(ctx, c, usedByResource.ID, webAppAsset)
 			if err != nil || !updated {
-				if _, discardErr := c.DiscardChanges(); discardErr != nil {
+				if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 					diags = utils.DiagError("failed to discard changes", discardErr, diags)
 				}
 
@@ -31,6 +31,10 @@ func GetTrustedSourceBehavior(ctx context.Context, c *api.Client, id string) (mo
 		return models.TrustedSourceBehavior{}, fmt.Errorf("failed to get TrustedSourceBehavior: %w", err)
 	}
 
+	if res == nil {
+		return models.TrustedSourceBehavior{}, api.ErrorNotFound
+	}
+
 	behavior, err := utils.UnmarshalAs[models.TrustedSourceBehavior](res)
 	if err != nil {
 		return models.TrustedSourceBehavior{}, fmt.Errorf("failed to convert response to TrustedSourceBehavior struct. Error: %w", err)
@@ -2,6 +2,7 @@ package resources
 
 import (
 	"context"
+	"errors"
 
 	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/api"
 	webapiasset "github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources/web-api-asset"
@@ -54,6 +55,7 @@ func ResourceWebAPIAsset() *schema.Resource {
 		ReadContext:   resourceWebApiAssetRead,
 		UpdateContext: resourceWebApiAssetUpdate,
 		DeleteContext: resourceWebApiAssetDelete,
+		Timeouts:      utils.ResourceTimeouts(utils.DefaultTimeout),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -434,23 +436,23 @@ func resourceWebApiAssetCreate(ctx context.Context, d *schema.ResourceData, meta
 
 	c := meta.(*api.Client)
 
-	createInput, err := webapiasset.CreateWebAPIAssetInputFromResourceData(d)
+	createInput, err := webapiasset.CreateWebAPIAssetInputFromResourceData(ctx, d)
 	if err != nil {
 		return utils.DiagError("unable to perform WebAPIAsset Create", err, diags)
 	}
 
 	asset, err := webapiasset.NewWebAPIAsset(ctx, c, createInput)
 	if err != nil {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("Failed to discard changes", discardErr, diags)
 		}
 
 		return utils.DiagError("Unable to perform WebAPIAsset Create", err, diags)
 	}
 
-	isValid, err := c.PublishChanges()
+	isValid, err := c.PublishChanges(ctx)
 	if err != nil || !isValid {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("Failed to discard changes", discardErr, diags)
 		}
 
@@ -458,7 +460,7 @@ func resourceWebApiAssetCreate(ctx context.Context, d *schema.ResourceData, meta
 	}
 
 	if err := webapiasset.ReadWebAPIAssetToResourceData(asset, d); err != nil {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -474,6 +476,10 @@ func resourceWebApiAssetRead(ctx context.Context, d *schema.ResourceData, meta a
 	c := meta.(*api.Client)
 
 	asset, err := webapiasset.GetWebAPIAsset(ctx, c, d.Id())
+	if errors.Is(err, api.ErrorNotFound) {
+		return utils.DiagNotFound(d, "WebAPIAsset", diags)
+	}
+
 	if err != nil {
 		return utils.DiagError("unable to perform get WebAPIAsset", err, diags)
 	}
@@ -490,23 +496,23 @@ func resourceWebApiAssetUpdate(ctx context.Context, d *schema.ResourceData, meta
 
 	c := meta.(*api.Client)
 
-	updateInput, err := webapiasset.UpdateWebAPIAssetInputFromResourceData(d)
+	updateInput, err := webapiasset.UpdateWebAPIAssetInputFromResourceData(ctx, d)
 	if err != nil {
 		return utils.DiagError("unable to perform WebAPIAsset update", err, diags)
 	}
 
 	result, err := webapiasset.UpdateWebAPIAsset(ctx, c, d.Id(), updateInput)
 	if err != nil || !result {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
 		return utils.DiagError("unable to perform WebAPIAsset Update", err, diags)
 	}
 
-	isValid, err := c.PublishChanges()
+	isValid, err := c.PublishChanges(ctx)
 	if err != nil || !isValid {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -533,16 +539,16 @@ func resourceWebApiAssetDelete(ctx context.Context, d *schema.ResourceData, meta
 	ID := d.Id()
 	result, err := webapiasset.DeleteWebAPIAsset(ctx, c, ID)
 	if err != nil || !result {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
 		return utils.DiagError("unable to perform WebAPIAsset Delete", err, diags)
 	}
 
-	isValid, err := c.PublishChanges()
+	isValid, err := c.PublishChanges(ctx)
 	if err != nil || !isValid {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
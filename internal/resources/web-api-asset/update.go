@@ -2,6 +2,7 @@ package webapiasset
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -21,10 +22,12 @@ func UpdateWebAPIAsset(ctx context.Context, c *api.Client, id any, input models.
 				`, "updateWebAPIAsset", vars)
 
 	if err != nil {
-		if dependencyError := api.CheckDependencyErrorResponse(err); dependencyError != nil {
-			input = fixUpdateInputByDependencyError(input, dependencyError)
-			vars = map[string]any{"assetInput": input, "id": id}
-			return UpdateWebAPIAsset(ctx, c, id, input)
+		var dependencyErr *api.DependencyMissingError
+		if errors.As(err, &dependencyErr) {
+			fixedInput, fixed := fixUpdateInputByDependencyError(input, dependencyErr)
+			if fixed {
+				return UpdateWebAPIAsset(ctx, c, id, fixedInput)
+			}
 		}
 
 		return false, err
@@ -38,7 +41,7 @@ func UpdateWebAPIAsset(ctx context.Context, c *api.Client, id any, input models.
 	return value, err
 }
 
-func UpdateWebAPIAssetInputFromResourceData(d *schema.ResourceData) (models.UpdateWebAPIAssetInput, error) {
+func UpdateWebAPIAssetInputFromResourceData(ctx context.Context, d *schema.ResourceData) (models.UpdateWebAPIAssetInput, error) {
 	var updateInput models.UpdateWebAPIAssetInput
 
 	if _, newName, hasChange := utils.GetChangeWithParse(d, "name", utils.MustValueAs[string]); hasChange {
@@ -84,7 +87,7 @@ func UpdateWebAPIAssetInputFromResourceData(d *schema.ResourceData) (models.Upda
 		practiceWrappersInputsToAdd, practiceWrappersInputsToRemove := utils.SlicesDiff(oldPracticeWrappers, newPracticeWrappers)
 		practiceWrappersInputsToAdd = utils.Filter(practiceWrappersInputsToAdd, validatePracticeWrapperInput)
 		practiceWrappersInputsToRemove = utils.Filter(practiceWrappersInputsToRemove, validatePracticeWrapperInput)
-		practiceWrappersToAdd := utils.Map(practiceWrappersInputsToAdd, utils.MustUnmarshalAs[models.AddPracticeWrapper, models.PracticeWrapperInput])
+		practiceWrappersToAdd := utils.MustUnmarshalSliceAs[models.AddPracticeWrapper](ctx, practiceWrappersInputsToAdd)
 		practicesIDsToRemove := utils.Map(practiceWrappersInputsToRemove, func(wrapper models.PracticeWrapperInput) string { return wrapper.PracticeID })
 		updateInput.AddPracticeWrappers = practiceWrappersToAdd
 		updateInput.RemovePracticeWrappers = practicesIDsToRemove
@@ -127,7 +130,7 @@ func UpdateWebAPIAssetInputFromResourceData(d *schema.ResourceData) (models.Upda
 		}
 	}
 
-	if oldMTLSs, newMTLSs, hasChange := utils.GetChangeWithParse(d, "mtls", parsemTLSs); hasChange {
+	if oldMTLSs, newMTLSs, hasChange := utils.GetChangeWithParse(d, "mtls", func(mTLSs any) models.MTLSSchemas { return parsemTLSs(ctx, mTLSs) }); hasChange {
 		newMTLSsIndicators := newMTLSs.ToIndicatorMap()
 		for _, old := range oldMTLSs {
 			if _, ok := newMTLSsIndicators[old.Type]; !ok {
@@ -305,7 +308,7 @@ func UpdateWebAPIAssetInputFromResourceData(d *schema.ResourceData) (models.Upda
 		}
 	}
 
-	if oldBlocks, newBlocks, hasChange := utils.GetChangeWithParse(d, "additional_instructions_blocks", parseBlocks); hasChange {
+	if oldBlocks, newBlocks, hasChange := utils.GetChangeWithParse(d, "additional_instructions_blocks", func(blocks any) models.BlockSchemas { return parseBlocks(ctx, blocks) }); hasChange {
 		newBlocksIndicatorMap := newBlocks.ToIndicatorMap()
 		for _, old := range oldBlocks {
 			if _, ok := newBlocksIndicatorMap[old.Type]; !ok {
@@ -442,7 +445,7 @@ func UpdateWebAPIAssetInputFromResourceData(d *schema.ResourceData) (models.Upda
 			tagsInputsToAdd, tagsInputsToRemove := utils.SlicesDiff(oldTags, newTags)
 			tagsInputsToAdd = utils.Filter(tagsInputsToAdd, validateTag)
 			tagsInputsToRemove = utils.Filter(tagsInputsToRemove, validateTag)
-			tagsToAdd := utils.Map(tagsInputsToAdd, utils.MustUnmarshalAs[models.AddTag, models.TagInput])
+			tagsToAdd := utils.MustUnmarshalSliceAs[models.AddTag](ctx, tagsInputsToAdd)
 			tagsToRemove := utils.Map(tagsInputsToRemove, func(tag models.TagInput) string { return tag.ID })
 			updateInput.AddTags = tagsToAdd
 			updateInput.RemoveTags = tagsToRemove
@@ -497,12 +500,16 @@ func validateTag(tag models.TagInput) bool {
 	return tag.Key != "" && tag.Value != ""
 }
 
-func parsemTLSs(mTLSsFromResourceData any) models.MTLSSchemas {
-	return utils.Map(utils.MustSchemaCollectionToSlice[map[string]any](mTLSsFromResourceData), mapToMTLSInput)
+func parsemTLSs(ctx context.Context, mTLSsFromResourceData any) models.MTLSSchemas {
+	return utils.Map(utils.MustSchemaCollectionToSlice[map[string]any](mTLSsFromResourceData), func(mTLSMap map[string]any) models.MTLSSchema {
+		return mapToMTLSInput(ctx, mTLSMap)
+	})
 }
 
-func parseBlocks(blocksFromResourceData any) models.BlockSchemas {
-	return utils.Map(utils.MustSchemaCollectionToSlice[map[string]any](blocksFromResourceData), mapToBlocksInput)
+func parseBlocks(ctx context.Context, blocksFromResourceData any) models.BlockSchemas {
+	return utils.Map(utils.MustSchemaCollectionToSlice[map[string]any](blocksFromResourceData), func(blocksMap map[string]any) models.BlockSchema {
+		return mapToBlocksInput(ctx, blocksMap)
+	})
 }
 
 func parseCustomHeaders(customHeadersFromResourceData any) models.CustomHeadersSchemas {
@@ -512,28 +519,27 @@ func parseCustomHeaders(customHeadersFromResourceData any) models.CustomHeadersS
 // fixUpdateInputByDependencyError is used to fix the update input by removing the profile, practice or behavior that caused the dependency error
 // it will make it so that the corresponding "remove" field wouldn't include the profile, practice or behavior that caused the dependency error
 // this is used to avoid the dependency error when updating the asset
-func fixUpdateInputByDependencyError(input models.UpdateWebAPIAssetInput, dependencyError *api.DependencyErrorResponse) models.UpdateWebAPIAssetInput {
-	if dependencyError == nil {
-		return input
+// returns false if the input was not changed, in which case retrying the update would fail the same way
+func fixUpdateInputByDependencyError(input models.UpdateWebAPIAssetInput, dependencyErr *api.DependencyMissingError) (models.UpdateWebAPIAssetInput, bool) {
+	if dependencyErr == nil || dependencyErr.ID == "" {
+		return input, false
 	}
 
-	if dependencyError.ProfileID != "" {
+	removedCount := len(input.RemoveProfiles) + len(input.RemovePracticeWrappers) + len(input.RemoveBehaviors)
+	switch dependencyErr.Kind {
+	case api.DependencyKindProfile:
 		input.RemoveProfiles = utils.Filter(input.RemoveProfiles, func(profile string) bool {
-			return profile != dependencyError.ProfileID
+			return profile != dependencyErr.ID
 		})
-	}
-
-	if dependencyError.PracticeID != "" {
+	case api.DependencyKindPractice:
 		input.RemovePracticeWrappers = utils.Filter(input.RemovePracticeWrappers, func(practice string) bool {
-			return practice != dependencyError.PracticeID
+			return practice != dependencyErr.ID
 		})
-	}
-
-	if dependencyError.BehaviorID != "" {
+	case api.DependencyKindBehavior:
 		input.RemoveBehaviors = utils.Filter(input.RemoveBehaviors, func(behavior string) bool {
-			return behavior != dependencyError.BehaviorID
+			return behavior != dependencyErr.ID
 		})
 	}
 
-	return input
+	return input, removedCount != len(input.RemoveProfiles)+len(input.RemovePracticeWrappers)+len(input.RemoveBehaviors)
 }
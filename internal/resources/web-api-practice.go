@@ -2,7 +2,7 @@ package resources
 
 import (
 	"context"
-	"strings"
+	"errors"
 
 	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/api"
 	webAPIAssetModels "github.com/CheckPointSW/terraform-provider-infinity-next/internal/models/web-api-asset"
@@ -49,6 +49,7 @@ func ResourceWebAPIPractice() *schema.Resource {
 		ReadContext:   resourceWebAPIPracticeRead,
 		UpdateContext: resourceWebAPIPracticeUpdate,
 		DeleteContext: resourceWebAPIPracticeDelete,
+		Timeouts:      utils.ResourceTimeouts(utils.DefaultTimeout),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -352,23 +353,23 @@ func resourceWebAPIPracticeCreate(ctx context.Context, d *schema.ResourceData, m
 
 	c := meta.(*api.Client)
 
-	createInput, err := webapipractice.CreateWebAPIPracticeInputFromResourceData(d)
+	createInput, err := webapipractice.CreateWebAPIPracticeInputFromResourceData(ctx, d)
 	if err != nil {
 		return utils.DiagError("unable to perform WebAPIPractice Create", err, diags)
 	}
 
 	practice, err := webapipractice.NewWebAPIPractice(ctx, c, createInput)
 	if err != nil {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
 		return utils.DiagError("unable to perform WebAPIPractice Create", err, diags)
 	}
 
-	isValid, err := c.PublishChanges()
+	isValid, err := c.PublishChanges(ctx)
 	if err != nil || !isValid {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -376,7 +377,7 @@ func resourceWebAPIPracticeCreate(ctx context.Context, d *schema.ResourceData, m
 	}
 
 	if err := webapipractice.ReadWebAPIPracticeToResourceData(practice, d); err != nil {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -392,6 +393,10 @@ func resourceWebAPIPracticeRead(ctx context.Context, d *schema.ResourceData, met
 	id := d.Id()
 
 	practice, err := webapipractice.GetWebAPIPractice(ctx, c, id)
+	if errors.Is(err, api.ErrorNotFound) {
+		return utils.DiagNotFound(d, "WebAPIPractice", diags)
+	}
+
 	if err != nil {
 		return utils.DiagError("unable to perform WebAPIPractice Read", err, diags)
 	}
@@ -408,23 +413,23 @@ func resourceWebAPIPracticeUpdate(ctx context.Context, d *schema.ResourceData, m
 
 	c := meta.(*api.Client)
 
-	updateInput, err := webapipractice.UpdateWebAPIPracticeInputFromResourceData(d)
+	updateInput, err := webapipractice.UpdateWebAPIPracticeInputFromResourceData(ctx, d)
 	if err != nil {
 		return utils.DiagError("unable to perform WebAPIPractice Update", err, diags)
 	}
 
 	result, err := webapipractice.UpdateWebAPIPractice(ctx, c, d.Id(), updateInput)
 	if err != nil || !result {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
 		return utils.DiagError("unable to perform WebAPIPractice Update", err, diags)
 	}
 
-	isValid, err := c.PublishChanges()
+	isValid, err := c.PublishChanges(ctx)
 	if err != nil || !isValid {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -433,7 +438,7 @@ func resourceWebAPIPracticeUpdate(ctx context.Context, d *schema.ResourceData, m
 
 	practice, err := webapipractice.GetWebAPIPractice(ctx, c, d.Id())
 	if err != nil {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -441,7 +446,7 @@ func resourceWebAPIPracticeUpdate(ctx context.Context, d *schema.ResourceData, m
 	}
 
 	if err := webapipractice.ReadWebAPIPracticeToResourceData(practice, d); err != nil {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -458,7 +463,8 @@ func resourceWebAPIPracticeDelete(ctx context.Context, d *schema.ResourceData, m
 	result, err := webapipractice.DeleteWebAPIPractice(ctx, c, d.Id())
 	if err != nil || !result {
 		// Check if the error is due to the web api practice being used by other resources
-		if err != nil && strings.Contains(err.Error(), errorMsgPointedObjects) {
+		var inUseErr *api.ObjectInUseError
+		if errors.As(err, &inUseErr) {
 			// Get the resources that are using the web api practice
 			usedBy, err2 := webapipractice.UsedByWebAPIPractice(ctx, c, d.Id())
 			if err2 != nil {
@@ -476,7 +482,7 @@ func resourceWebAPIPracticeDelete(ctx context.Context, d *schema.ResourceData, m
 				// Retry to delete the web api practice
 				result, err := webapipractice.DeleteWebAPIPractice(ctx, c, d.Id())
 				if err != nil || !result {
-					if _, discardErr := c.DiscardChanges(); discardErr != nil {
+					if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 						diags = utils.DiagError("failed to discard changes", discardErr, diags)
 					}
 
@@ -486,7 +492,7 @@ func resourceWebAPIPracticeDelete(ctx context.Context, d *schema.ResourceData, m
 			}
 
 		} else {
-			if _, discardErr := c.DiscardChanges(); discardErr != nil {
+			if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 				diags = utils.DiagError("failed to discard changes", discardErr, diags)
 			}
 
@@ -494,9 +500,9 @@ func resourceWebAPIPracticeDelete(ctx context.Context, d *schema.ResourceData, m
 		}
 	}
 
-	isValid, err := c.PublishChanges()
+	isValid, err := c.PublishChanges(ctx)
 	if err != nil || !isValid {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -524,7 +530,7 @@ func handleWebAPIPracticeReferences(ctx context.Context, usedBy models.DisplayOb
 
 			updated, err := webapiasset.UpdateWebAPIAsset(ctx, c, usedByResource.ID, webAPIAsset)
 			if err != nil || !updated {
-				if _, discardErr := c.DiscardChanges(); discardErr != nil {
+				if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 					diags = utils.DiagError("failed to discard changes", discardErr, diags)
 				}
 
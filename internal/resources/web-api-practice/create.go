@@ -10,7 +10,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-func CreateWebAPIPracticeInputFromResourceData(d *schema.ResourceData) (models.CreateWebAPIPracticeInput, error) {
+func CreateWebAPIPracticeInputFromResourceData(ctx context.Context, d *schema.ResourceData) (models.CreateWebAPIPracticeInput, error) {
 	var res models.CreateWebAPIPracticeInput
 
 	res.Name = d.Get("name").(string)
@@ -25,7 +25,9 @@ func CreateWebAPIPracticeInputFromResourceData(d *schema.ResourceData) (models.C
 		res.APIAttacks = apiAttacksSlice[0]
 	}
 
-	schemaValidationSlice := utils.Map(utils.MustResourceDataCollectionToSlice[any](d, "schema_validation"), mapToSchemaValidationInput)
+	schemaValidationSlice := utils.Map(utils.MustResourceDataCollectionToSlice[any](d, "schema_validation"), func(schemaValidation any) models.SchemaValidationInput {
+		return mapToSchemaValidationInput(ctx, schemaValidation)
+	})
 	if len(schemaValidationSlice) > 0 {
 		res.SchemaValidation = schemaValidationSlice[0]
 	}
@@ -156,10 +158,10 @@ func mapToAPIAttacksInput(apiAttacksMap map[string]any) models.APIAttacksInput {
 	return res
 }
 
-func mapToSchemaValidationInput(schemaValidationFromResourceData any) models.SchemaValidationInput {
+func mapToSchemaValidationInput(ctx context.Context, schemaValidationFromResourceData any) models.SchemaValidationInput {
 	schemaValidation, err := utils.UnmarshalAs[models.FileSchema](schemaValidationFromResourceData)
 	if err != nil {
-		fmt.Printf("Failed to convert input schema validation to FileSchema struct. Error: %+v", err)
+		utils.LogConversionError(ctx, "Failed to convert input schema validation to FileSchema struct", err, schemaValidationFromResourceData)
 		return models.SchemaValidationInput{}
 	}
 
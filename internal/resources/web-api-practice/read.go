@@ -188,6 +188,10 @@ func GetWebAPIPractice(ctx context.Context, c *api.Client, id string) (models.We
 		return models.WebAPIPractice{}, fmt.Errorf("failed to get WebAPIPractice: %w", err)
 	}
 
+	if res == nil {
+		return models.WebAPIPractice{}, api.ErrorNotFound
+	}
+
 	practice, err := utils.UnmarshalAs[models.WebAPIPractice](res)
 	if err != nil {
 		return models.WebAPIPractice{}, fmt.Errorf("failed to convert response to WebAPIPractice struct. Error: %w", err)
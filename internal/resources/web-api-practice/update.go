@@ -10,7 +10,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-func UpdateWebAPIPracticeInputFromResourceData(d *schema.ResourceData) (models.UpdatePracticeInput, error) {
+func UpdateWebAPIPracticeInputFromResourceData(ctx context.Context, d *schema.ResourceData) (models.UpdatePracticeInput, error) {
 	var updateInput models.UpdatePracticeInput
 
 	if _, newName, hasChange := utils.MustGetChange[string](d, "name"); hasChange {
@@ -21,7 +21,7 @@ func UpdateWebAPIPracticeInputFromResourceData(d *schema.ResourceData) (models.U
 		updateInput.Visibility = newVisibility
 	}
 
-	if oldIPSSlice, newIPSSlice, hasChange := utils.GetChangeWithParse(d, "ips", parseSchemaIPS); hasChange && len(newIPSSlice) > 0 {
+	if oldIPSSlice, newIPSSlice, hasChange := utils.GetChangeWithParse(d, "ips", func(schemaIPS any) []models.UpdateIPSInput { return parseSchemaIPS(ctx, schemaIPS) }); hasChange && len(newIPSSlice) > 0 {
 		if len(oldIPSSlice) > 0 {
 			newIPSSlice[0].ID = oldIPSSlice[0].ID
 		}
@@ -29,7 +29,9 @@ func UpdateWebAPIPracticeInputFromResourceData(d *schema.ResourceData) (models.U
 		updateInput.IPS = newIPSSlice[0]
 	}
 
-	if oldAPIAttacks, newAPIAttacks, hasChange := utils.GetChangeWithParse(d, "api_attacks", parseSchemaAPIAttacks); hasChange && len(newAPIAttacks) > 0 {
+	if oldAPIAttacks, newAPIAttacks, hasChange := utils.GetChangeWithParse(d, "api_attacks", func(schemaAPIAttacks any) []models.UpdateAPIAttacksInput {
+		return parseSchemaAPIAttacks(ctx, schemaAPIAttacks)
+	}); hasChange && len(newAPIAttacks) > 0 {
 		if len(oldAPIAttacks) > 0 {
 			newAPIAttacks[0].ID = oldAPIAttacks[0].ID
 		}
@@ -37,7 +39,9 @@ func UpdateWebAPIPracticeInputFromResourceData(d *schema.ResourceData) (models.U
 		updateInput.APIAttacks = newAPIAttacks[0]
 	}
 
-	if oldSchemaValidation, newSchemaValidation, hasChange := utils.GetChangeWithParse(d, "schema_validation", parseSchemaValidation); hasChange && len(newSchemaValidation) > 0 {
+	if oldSchemaValidation, newSchemaValidation, hasChange := utils.GetChangeWithParse(d, "schema_validation", func(validation any) []models.UpdateSchemaValidationInput {
+		return parseSchemaValidation(ctx, validation)
+	}); hasChange && len(newSchemaValidation) > 0 {
 		if len(oldSchemaValidation) > 0 {
 			newSchemaValidation[0].ID = oldSchemaValidation[0].ID
 		}
@@ -45,7 +49,9 @@ func UpdateWebAPIPracticeInputFromResourceData(d *schema.ResourceData) (models.U
 		updateInput.SchemaValidation = newSchemaValidation[0]
 	}
 
-	if oldFileSecuritySlice, newFileSecuritySlice, hasChange := utils.GetChangeWithParse(d, "file_security", parseSchemaFileSecurity); hasChange && len(newFileSecuritySlice) > 0 {
+	if oldFileSecuritySlice, newFileSecuritySlice, hasChange := utils.GetChangeWithParse(d, "file_security", func(schemaFileSecurity any) []models.UpdateWebAPIFileSecurityInput {
+		return parseSchemaFileSecurity(ctx, schemaFileSecurity)
+	}); hasChange && len(newFileSecuritySlice) > 0 {
 		if len(oldFileSecuritySlice) > 0 {
 			newFileSecuritySlice[0].ID = oldFileSecuritySlice[0].ID
 		}
@@ -78,22 +84,24 @@ func UpdateWebAPIPractice(ctx context.Context, c *api.Client, id string, input m
 	return value, err
 }
 
-func parseSchemaIPS(schemaIPS any) []models.UpdateIPSInput {
+func parseSchemaIPS(ctx context.Context, schemaIPS any) []models.UpdateIPSInput {
 	input := utils.Map(utils.MustSchemaCollectionToSlice[map[string]any](schemaIPS), mapToIPSInput)
-	return utils.Map(input, utils.MustUnmarshalAs[models.UpdateIPSInput, models.IPSInput])
+	return utils.MustUnmarshalSliceAs[models.UpdateIPSInput](ctx, input)
 }
 
-func parseSchemaAPIAttacks(schemaAPIAttacks any) []models.UpdateAPIAttacksInput {
+func parseSchemaAPIAttacks(ctx context.Context, schemaAPIAttacks any) []models.UpdateAPIAttacksInput {
 	input := utils.Map(utils.MustSchemaCollectionToSlice[map[string]any](schemaAPIAttacks), mapToAPIAttacksInput)
-	return utils.Map(input, utils.MustUnmarshalAs[models.UpdateAPIAttacksInput, models.APIAttacksInput])
+	return utils.MustUnmarshalSliceAs[models.UpdateAPIAttacksInput](ctx, input)
 }
 
-func parseSchemaValidation(validation any) []models.UpdateSchemaValidationInput {
-	input := utils.Map(utils.MustSchemaCollectionToSlice[any](validation), mapToSchemaValidationInput)
-	return utils.Map(input, utils.MustUnmarshalAs[models.UpdateSchemaValidationInput, models.SchemaValidationInput])
+func parseSchemaValidation(ctx context.Context, validation any) []models.UpdateSchemaValidationInput {
+	input := utils.Map(utils.MustSchemaCollectionToSlice[any](validation), func(schemaValidation any) models.SchemaValidationInput {
+		return mapToSchemaValidationInput(ctx, schemaValidation)
+	})
+	return utils.MustUnmarshalSliceAs[models.UpdateSchemaValidationInput](ctx, input)
 }
 
-func parseSchemaFileSecurity(schemaFileSecurity any) []models.UpdateWebAPIFileSecurityInput {
+func parseSchemaFileSecurity(ctx context.Context, schemaFileSecurity any) []models.UpdateWebAPIFileSecurityInput {
 	input := utils.Map(utils.MustSchemaCollectionToSlice[map[string]any](schemaFileSecurity), mapToFileSecurityInput)
-	return utils.Map(input, utils.MustUnmarshalAs[models.UpdateWebAPIFileSecurityInput, models.WebAPIFileSecurityInput])
+	return utils.MustUnmarshalSliceAs[models.UpdateWebAPIFileSecurityInput](ctx, input)
 }
@@ -2,6 +2,7 @@ package resources
 
 import (
 	"context"
+	"errors"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
@@ -45,6 +46,7 @@ func ResourceWebAppAsset() *schema.Resource {
 		ReadContext:   resourceWebAppAssetRead,
 		UpdateContext: resourceWebAppAssetUpdate,
 		DeleteContext: resourceWebAppAssetDelete,
+		Timeouts:      utils.ResourceTimeouts(utils.DefaultTimeout),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -428,23 +430,23 @@ func resourceWebAppAssetCreate(ctx context.Context, d *schema.ResourceData, meta
 
 	c := meta.(*api.Client)
 
-	createInput, err := webappasset.CreateWebApplicationAssetInputFromResourceData(d)
+	createInput, err := webappasset.CreateWebApplicationAssetInputFromResourceData(ctx, d)
 	if err != nil {
 		return utils.DiagError("unable to perform WebAppAsset Create", err, diags)
 	}
 
 	asset, err := webappasset.NewWebApplicationAsset(ctx, c, createInput)
 	if err != nil {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
 		return utils.DiagError("unable to perform WebAppAsset Create", err, diags)
 	}
 
-	isValid, err := c.PublishChanges()
+	isValid, err := c.PublishChanges(ctx)
 	if err != nil || !isValid {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -452,7 +454,7 @@ func resourceWebAppAssetCreate(ctx context.Context, d *schema.ResourceData, meta
 	}
 
 	if err := webappasset.ReadWebApplicationAssetToResourceData(asset, d); err != nil {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -468,6 +470,10 @@ func resourceWebAppAssetRead(ctx context.Context, d *schema.ResourceData, meta a
 	c := meta.(*api.Client)
 
 	asset, err := webappasset.GetWebApplicationAsset(ctx, c, d.Id())
+	if errors.Is(err, api.ErrorNotFound) {
+		return utils.DiagNotFound(d, "WebAppAsset", diags)
+	}
+
 	if err != nil {
 		return utils.DiagError("unable to perform WebAppAsset Read", err, diags)
 	}
@@ -489,23 +495,23 @@ func resourceWebAppAssetUpdate(ctx context.Context, d *schema.ResourceData, meta
 		return utils.DiagError("unable to perform get WebApplicationAsset for updating", err, diags)
 	}
 
-	updateInput, err := webappasset.UpdateWebApplicationAssetInputFromResourceData(d, oldAsset)
+	updateInput, err := webappasset.UpdateWebApplicationAssetInputFromResourceData(ctx, d, oldAsset)
 	if err != nil {
 		return utils.DiagError("unable to perform WebAppAsset Update", err, diags)
 	}
 
 	result, err := webappasset.UpdateWebApplicationAsset(ctx, c, d.Id(), updateInput)
 	if err != nil || !result {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
 		return utils.DiagError("unable to perform WebAppAsset Update", err, diags)
 	}
 
-	isValid, err := c.PublishChanges()
+	isValid, err := c.PublishChanges(ctx)
 	if err != nil || !isValid {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -514,7 +520,7 @@ func resourceWebAppAssetUpdate(ctx context.Context, d *schema.ResourceData, meta
 
 	asset, err := webappasset.GetWebApplicationAsset(ctx, c, d.Id())
 	if err != nil {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -522,7 +528,7 @@ func resourceWebAppAssetUpdate(ctx context.Context, d *schema.ResourceData, meta
 	}
 
 	if err := webappasset.ReadWebApplicationAssetToResourceData(asset, d); err != nil {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -539,16 +545,16 @@ func resourceWebAppAssetDelete(ctx context.Context, d *schema.ResourceData, meta
 	ID := d.Id()
 	result, err := webappasset.DeleteWebApplicationAsset(ctx, c, ID)
 	if err != nil || !result {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
 		return utils.DiagError("unable to perform WebAppAsset Delete", err, diags)
 	}
 
-	isValid, err := c.PublishChanges()
+	isValid, err := c.PublishChanges(ctx)
 	if err != nil || !isValid {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -39,7 +39,7 @@ const (
 	customHeaderData      = "setHeader"
 )
 
-func CreateWebApplicationAssetInputFromResourceData(d *schema.ResourceData) (models.CreateWebApplicationAssetInput, error) {
+func CreateWebApplicationAssetInputFromResourceData(ctx context.Context, d *schema.ResourceData) (models.CreateWebApplicationAssetInput, error) {
 	var res models.CreateWebApplicationAssetInput
 
 	res.Name = d.Get("name").(string)
@@ -55,12 +55,16 @@ func CreateWebApplicationAssetInputFromResourceData(d *schema.ResourceData) (mod
 	res.IsSharesURLs = &isSharesURLs
 
 	var mtls models.MTLSSchemas
-	mtls = utils.Map(utils.MustResourceDataCollectionToSlice[map[string]any](d, "mtls"), mapToMTLSInput)
+	mtls = utils.Map(utils.MustResourceDataCollectionToSlice[map[string]any](d, "mtls"), func(mTLSMap map[string]any) models.MTLSSchema {
+		return mapToMTLSInput(ctx, mTLSMap)
+	})
 
 	res.ProxySettings = mapMTLSToProxySettingInputs(mtls, res.ProxySettings)
 
 	var additionalBlocks models.BlockSchemas
-	additionalBlocks = utils.Map(utils.MustResourceDataCollectionToSlice[map[string]any](d, "additional_instructions_blocks"), mapToBlocksInput)
+	additionalBlocks = utils.Map(utils.MustResourceDataCollectionToSlice[map[string]any](d, "additional_instructions_blocks"), func(blocksMap map[string]any) models.BlockSchema {
+		return mapToBlocksInput(ctx, blocksMap)
+	})
 
 	res.ProxySettings = mapBlocksToProxySettingInputs(additionalBlocks, res.ProxySettings)
 
@@ -229,10 +233,10 @@ func convertBooleanToString(value bool) string {
 	return "false"
 }
 
-func mapToMTLSInput(mTLSMap map[string]any) models.MTLSSchema {
+func mapToMTLSInput(ctx context.Context, mTLSMap map[string]any) models.MTLSSchema {
 	mTLSFile, err := utils.UnmarshalAs[models.MTLSSchema](mTLSMap)
 	if err != nil {
-		fmt.Printf("Failed to convert input schema validation to MTLSSchema struct. Error: %+v", err)
+		utils.LogConversionError(ctx, "Failed to convert input to MTLSSchema struct", err, mTLSMap)
 		return models.MTLSSchema{}
 	}
 
@@ -279,10 +283,10 @@ func mapMTLSToProxySettingInputs(mTLS models.MTLSSchemas, proxySettings models.P
 	return proxySettings
 }
 
-func mapToBlocksInput(blocksMap map[string]any) models.BlockSchema {
+func mapToBlocksInput(ctx context.Context, blocksMap map[string]any) models.BlockSchema {
 	blockFile, err := utils.UnmarshalAs[models.BlockSchema](blocksMap)
 	if err != nil {
-		fmt.Printf("Failed to convert input schema validation to BlockSchema struct. Error: %+v", err)
+		utils.LogConversionError(ctx, "Failed to convert input to BlockSchema struct", err, blocksMap)
 		return models.BlockSchema{}
 	}
 
@@ -2,6 +2,7 @@ package webappasset
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -11,7 +12,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-func UpdateWebApplicationAssetInputFromResourceData(d *schema.ResourceData, asset models.WebApplicationAsset) (models.UpdateWebApplicationAssetInput, error) {
+func UpdateWebApplicationAssetInputFromResourceData(ctx context.Context, d *schema.ResourceData, asset models.WebApplicationAsset) (models.UpdateWebApplicationAssetInput, error) {
 	var updateInput models.UpdateWebApplicationAssetInput
 
 	if _, newName, hasChange := utils.GetChangeWithParse(d, "name", utils.MustValueAs[string]); hasChange {
@@ -57,7 +58,7 @@ func UpdateWebApplicationAssetInputFromResourceData(d *schema.ResourceData, asse
 		practiceWrappersInputsToAdd, practiceWrappersInputsToRemove := utils.SlicesDiff(oldPracticeWrappers, newPracticeWrappers)
 		practiceWrappersInputsToAdd = utils.Filter(practiceWrappersInputsToAdd, validatePracticeWrapperInput)
 		practiceWrappersInputsToRemove = utils.Filter(practiceWrappersInputsToRemove, validatePracticeWrapperInput)
-		practiceWrappersToAdd := utils.Map(practiceWrappersInputsToAdd, utils.MustUnmarshalAs[models.AddPracticeWrapper, models.PracticeWrapperInput])
+		practiceWrappersToAdd := utils.MustUnmarshalSliceAs[models.AddPracticeWrapper](ctx, practiceWrappersInputsToAdd)
 		practicesIDsToRemove := utils.Map(practiceWrappersInputsToRemove, func(wrapper models.PracticeWrapperInput) string { return wrapper.PracticeID })
 		updateInput.AddPracticeWrappers = practiceWrappersToAdd
 		updateInput.RemovePracticeWrappers = practicesIDsToRemove
@@ -100,7 +101,7 @@ func UpdateWebApplicationAssetInputFromResourceData(d *schema.ResourceData, asse
 		}
 	}
 
-	if oldMTLSs, newMTLSs, hasChange := utils.GetChangeWithParse(d, "mtls", parsemTLSs); hasChange {
+	if oldMTLSs, newMTLSs, hasChange := utils.GetChangeWithParse(d, "mtls", func(mTLSs any) models.MTLSSchemas { return parsemTLSs(ctx, mTLSs) }); hasChange {
 		newMTLSsIndicators := newMTLSs.ToIndicatorMap()
 		for _, old := range oldMTLSs {
 			if _, ok := newMTLSsIndicators[old.Type]; !ok {
@@ -279,7 +280,7 @@ func UpdateWebApplicationAssetInputFromResourceData(d *schema.ResourceData, asse
 		}
 	}
 
-	if oldBlocks, newBlocks, hasChange := utils.GetChangeWithParse(d, "additional_instructions_blocks", parseBlocks); hasChange {
+	if oldBlocks, newBlocks, hasChange := utils.GetChangeWithParse(d, "additional_instructions_blocks", func(blocks any) models.BlockSchemas { return parseBlocks(ctx, blocks) }); hasChange {
 		newBlocksIndicatorMap := newBlocks.ToIndicatorMap()
 		for _, old := range oldBlocks {
 			if _, ok := newBlocksIndicatorMap[old.Type]; !ok {
@@ -458,10 +459,12 @@ func UpdateWebApplicationAsset(ctx context.Context, c *api.Client, id any, input
 				`, "updateWebApplicationAsset", vars)
 
 	if err != nil {
-		if dependencyError := api.CheckDependencyErrorResponse(err); dependencyError != nil {
-			input = fixUpdateInputByDependencyError(input, dependencyError)
-			vars = map[string]any{"assetInput": input, "id": id}
-			return UpdateWebApplicationAsset(ctx, c, id, input)
+		var dependencyErr *api.DependencyMissingError
+		if errors.As(err, &dependencyErr) {
+			fixedInput, fixed := fixUpdateInputByDependencyError(input, dependencyErr)
+			if fixed {
+				return UpdateWebApplicationAsset(ctx, c, id, fixedInput)
+			}
 		}
 
 		return false, err
@@ -478,30 +481,29 @@ func UpdateWebApplicationAsset(ctx context.Context, c *api.Client, id any, input
 // fixUpdateInputByDependencyError is used to fix the update input by removing the profile, practice or behavior that caused the dependency error
 // it will make it so that the corresponding "remove" field wouldn't include the profile, practice or behavior that caused the dependency error
 // this is used to avoid the dependency error when updating the asset
-func fixUpdateInputByDependencyError(input models.UpdateWebApplicationAssetInput, dependencyError *api.DependencyErrorResponse) models.UpdateWebApplicationAssetInput {
-	if dependencyError == nil {
-		return input
+// returns false if the input was not changed, in which case retrying the update would fail the same way
+func fixUpdateInputByDependencyError(input models.UpdateWebApplicationAssetInput, dependencyErr *api.DependencyMissingError) (models.UpdateWebApplicationAssetInput, bool) {
+	if dependencyErr == nil || dependencyErr.ID == "" {
+		return input, false
 	}
 
-	if dependencyError.ProfileID != "" {
+	removedCount := len(input.RemoveProfiles) + len(input.RemovePracticeWrappers) + len(input.RemoveBehaviors)
+	switch dependencyErr.Kind {
+	case api.DependencyKindProfile:
 		input.RemoveProfiles = utils.Filter(input.RemoveProfiles, func(profile string) bool {
-			return profile != dependencyError.ProfileID
+			return profile != dependencyErr.ID
 		})
-	}
-
-	if dependencyError.PracticeID != "" {
+	case api.DependencyKindPractice:
 		input.RemovePracticeWrappers = utils.Filter(input.RemovePracticeWrappers, func(practice string) bool {
-			return practice != dependencyError.PracticeID
+			return practice != dependencyErr.ID
 		})
-	}
-
-	if dependencyError.BehaviorID != "" {
+	case api.DependencyKindBehavior:
 		input.RemoveBehaviors = utils.Filter(input.RemoveBehaviors, func(behavior string) bool {
-			return behavior != dependencyError.BehaviorID
+			return behavior != dependencyErr.ID
 		})
 	}
 
-	return input
+	return input, removedCount != len(input.RemoveProfiles)+len(input.RemovePracticeWrappers)+len(input.RemoveBehaviors)
 }
 
 // parseSchemaSourceIdentifiers converts the source identifiers (type schema.TypeSet) to a slice of map[string]any
@@ -543,12 +545,16 @@ func parseSchemaTags(tagsFromResourceData any) models.TagsInputs {
 	return utils.Map(utils.MustSchemaCollectionToSlice[map[string]any](tagsFromResourceData), mapToTagsInputs)
 }
 
-func parsemTLSs(mTLSsFromResourceData any) models.MTLSSchemas {
-	return utils.Map(utils.MustSchemaCollectionToSlice[map[string]any](mTLSsFromResourceData), mapToMTLSInput)
+func parsemTLSs(ctx context.Context, mTLSsFromResourceData any) models.MTLSSchemas {
+	return utils.Map(utils.MustSchemaCollectionToSlice[map[string]any](mTLSsFromResourceData), func(mTLSMap map[string]any) models.MTLSSchema {
+		return mapToMTLSInput(ctx, mTLSMap)
+	})
 }
 
-func parseBlocks(blocksFromResourceData any) models.BlockSchemas {
-	return utils.Map(utils.MustSchemaCollectionToSlice[map[string]any](blocksFromResourceData), mapToBlocksInput)
+func parseBlocks(ctx context.Context, blocksFromResourceData any) models.BlockSchemas {
+	return utils.Map(utils.MustSchemaCollectionToSlice[map[string]any](blocksFromResourceData), func(blocksMap map[string]any) models.BlockSchema {
+		return mapToBlocksInput(ctx, blocksMap)
+	})
 }
 
 func parseCustomHeaders(customHeadersFromResourceData any) models.CustomHeadersSchemas {
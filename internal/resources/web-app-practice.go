@@ -2,7 +2,7 @@ package resources
 
 import (
 	"context"
-	"strings"
+	"errors"
 
 	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/api"
 	webAppAssetModels "github.com/CheckPointSW/terraform-provider-infinity-next/internal/models/web-app-asset"
@@ -36,8 +36,6 @@ const (
 	waapModeLearn    = "Learn"
 	waapModePrevent  = "Prevent"
 	waapModePractice = "AccordingToPractice"
-
-	errorMsgPointedObjects = "can't be deleted since it is pointed from other objects"
 )
 
 func ResourceWebAppPractice() *schema.Resource {
@@ -62,6 +60,7 @@ func ResourceWebAppPractice() *schema.Resource {
 		ReadContext:   resourceWebAppPracticeRead,
 		UpdateContext: resourceWebAppPracticeUpdate,
 		DeleteContext: resourceWebAppPracticeDelete,
+		Timeouts:      utils.ResourceTimeouts(utils.DefaultTimeout),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -405,16 +404,16 @@ func resourceWebAppPracticeCreate(ctx context.Context, d *schema.ResourceData, m
 
 	practice, err := webapppractice.NewWebApplicationPractice(ctx, c, createInput)
 	if err != nil {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
 		return utils.DiagError("unable to perform WebAppPractice Create", err, diags)
 	}
 
-	isValid, err := c.PublishChanges()
+	isValid, err := c.PublishChanges(ctx)
 	if err != nil || !isValid {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -422,7 +421,7 @@ func resourceWebAppPracticeCreate(ctx context.Context, d *schema.ResourceData, m
 	}
 
 	if err := webapppractice.ReadWebApplicationPracticeToResourceData(practice, d); err != nil {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -440,6 +439,10 @@ func resourceWebAppPracticeRead(ctx context.Context, d *schema.ResourceData, met
 	id := d.Id()
 
 	practice, err := webapppractice.GetWebApplicationPractice(ctx, c, id)
+	if errors.Is(err, api.ErrorNotFound) {
+		return utils.DiagNotFound(d, "WebAppPractice", diags)
+	}
+
 	if err != nil {
 		return utils.DiagError("unable to perform WebAppPractice Read", err, diags)
 	}
@@ -456,23 +459,23 @@ func resourceWebAppPracticeUpdate(ctx context.Context, d *schema.ResourceData, m
 
 	c := meta.(*api.Client)
 
-	updateInput, err := webapppractice.UpdateWebApplicationPracticeInputFromResourceData(d)
+	updateInput, err := webapppractice.UpdateWebApplicationPracticeInputFromResourceData(ctx, d)
 	if err != nil {
 		return utils.DiagError("Failed to parse WebAppPractice Update to struct", err, diags)
 	}
 
 	result, err := webapppractice.UpdateWebApplicationPractice(ctx, c, d.Id(), updateInput)
 	if err != nil || !result {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
 		return utils.DiagError("unable to perform WebAppPractice Update", err, diags)
 	}
 
-	isValid, err := c.PublishChanges()
+	isValid, err := c.PublishChanges(ctx)
 	if err != nil || !isValid {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -481,7 +484,7 @@ func resourceWebAppPracticeUpdate(ctx context.Context, d *schema.ResourceData, m
 
 	practice, err := webapppractice.GetWebApplicationPractice(ctx, c, d.Id())
 	if err != nil {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -489,7 +492,7 @@ func resourceWebAppPracticeUpdate(ctx context.Context, d *schema.ResourceData, m
 	}
 
 	if err := webapppractice.ReadWebApplicationPracticeToResourceData(practice, d); err != nil {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -506,7 +509,8 @@ func resourceWebAppPracticeDelete(ctx context.Context, d *schema.ResourceData, m
 	result, err := webapppractice.DeleteWebApplicationPractice(ctx, c, d.Id())
 	if err != nil || !result {
 		// Check if the error is due to the web app practice being used by other resources
-		if err != nil && strings.Contains(err.Error(), errorMsgPointedObjects) {
+		var inUseErr *api.ObjectInUseError
+		if errors.As(err, &inUseErr) {
 			// Get the resources that are using the web app practice
 			usedBy, err2 := webapppractice.UsedByWebApplicationPractice(ctx, c, d.Id())
 			if err2 != nil {
@@ -524,7 +528,7 @@ func resourceWebAppPracticeDelete(ctx context.Context, d *schema.ResourceData, m
 				// Retry to delete the web app practice
 				result, err := webapppractice.DeleteWebApplicationPractice(ctx, c, d.Id())
 				if err != nil || !result {
-					if _, discardErr := c.DiscardChanges(); discardErr != nil {
+					if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 						diags = utils.DiagError("failed to discard changes", discardErr, diags)
 					}
 
@@ -532,7 +536,7 @@ func resourceWebAppPracticeDelete(ctx context.Context, d *schema.ResourceData, m
 				}
 			}
 		} else {
-			if _, discardErr := c.DiscardChanges(); discardErr != nil {
+			if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 				diags = utils.DiagError("failed to discard changes", discardErr, diags)
 			}
 
@@ -540,9 +544,9 @@ func resourceWebAppPracticeDelete(ctx context.Context, d *schema.ResourceData, m
 		}
 	}
 
-	isValid, err := c.PublishChanges()
+	isValid, err := c.PublishChanges(ctx)
 	if err != nil || !isValid {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -570,7 +574,7 @@ func handleWebAppPracticeReferences(ctx context.Context, usedBy models.DisplayOb
 
 			updated, err := webappasset.UpdateWebApplicationAsset(ctx, c, usedByResource.ID, webAppAsset)
 			if err != nil || !updated {
-				if _, discardErr := c.DiscardChanges(); discardErr != nil {
+				if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 					diags = utils.DiagError("failed to discard changes", discardErr, diags)
 				}
 
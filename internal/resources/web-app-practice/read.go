@@ -199,6 +199,10 @@ func GetWebApplicationPractice(ctx context.Context, c *api.Client, id string) (m
 		return models.WebApplicationPractice{}, fmt.Errorf("failed to get WebApplicationPractice: %w", err)
 	}
 
+	if res == nil {
+		return models.WebApplicationPractice{}, api.ErrorNotFound
+	}
+
 	practice, err := utils.UnmarshalAs[models.WebApplicationPractice](res)
 	if err != nil {
 		return models.WebApplicationPractice{}, fmt.Errorf("failed to convert response to WebApplicationPractice struct. Error: %w", err)
@@ -10,7 +10,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-func UpdateWebApplicationPracticeInputFromResourceData(d *schema.ResourceData) (models.UpdateWebApplicationPracticeInput, error) {
+func UpdateWebApplicationPracticeInputFromResourceData(ctx context.Context, d *schema.ResourceData) (models.UpdateWebApplicationPracticeInput, error) {
 	var updateInput models.UpdateWebApplicationPracticeInput
 
 	if _, newName, hasChange := utils.MustGetChange[string](d, "name"); hasChange {
@@ -21,7 +21,9 @@ func UpdateWebApplicationPracticeInputFromResourceData(d *schema.ResourceData) (
 		updateInput.Visibility = newVisibility
 	}
 
-	if oldIPSSlice, newIPSSlice, hasChange := utils.GetChangeWithParse(d, "ips", parseSchemaIPS); hasChange && len(newIPSSlice) > 0 {
+	if oldIPSSlice, newIPSSlice, hasChange := utils.GetChangeWithParse(d, "ips", func(schemaIPS any) []models.UpdateWebApplicationPracticeIPSInput {
+		return parseSchemaIPS(ctx, schemaIPS)
+	}); hasChange && len(newIPSSlice) > 0 {
 		if len(oldIPSSlice) > 0 {
 			newIPSSlice[0].ID = oldIPSSlice[0].ID
 		}
@@ -29,7 +31,9 @@ func UpdateWebApplicationPracticeInputFromResourceData(d *schema.ResourceData) (
 		updateInput.IPS = newIPSSlice[0]
 	}
 
-	if oldWebAttacks, newWebAttacks, hasChange := utils.GetChangeWithParse(d, "web_attacks", parseSchemaWebAttacks); hasChange && len(newWebAttacks) > 0 {
+	if oldWebAttacks, newWebAttacks, hasChange := utils.GetChangeWithParse(d, "web_attacks", func(schemaWebAttacks any) []models.UpdateWebApplicationPracticeWebAttacksInput {
+		return parseSchemaWebAttacks(ctx, schemaWebAttacks)
+	}); hasChange && len(newWebAttacks) > 0 {
 		if len(oldWebAttacks) > 0 {
 			newWebAttacks[0].ID = oldWebAttacks[0].ID
 		}
@@ -77,7 +81,9 @@ func UpdateWebApplicationPracticeInputFromResourceData(d *schema.ResourceData) (
 		}
 	}
 
-	if oldFileSecurity, newFileSecurity, hasChange := utils.GetChangeWithParse(d, "file_security", parseSchemaFileSecurity); hasChange && len(newFileSecurity) > 0 {
+	if oldFileSecurity, newFileSecurity, hasChange := utils.GetChangeWithParse(d, "file_security", func(schemaFileSecurity any) []models.UpdateFileSecurity {
+		return parseSchemaFileSecurity(ctx, schemaFileSecurity)
+	}); hasChange && len(newFileSecurity) > 0 {
 		if len(oldFileSecurity) > 0 {
 			newFileSecurity[0].ID = oldFileSecurity[0].ID
 		}
@@ -88,14 +94,14 @@ func UpdateWebApplicationPracticeInputFromResourceData(d *schema.ResourceData) (
 	return updateInput, nil
 }
 
-func parseSchemaIPS(schemaIPS any) []models.UpdateWebApplicationPracticeIPSInput {
+func parseSchemaIPS(ctx context.Context, schemaIPS any) []models.UpdateWebApplicationPracticeIPSInput {
 	input := utils.Map(utils.MustSchemaCollectionToSlice[map[string]any](schemaIPS), mapToIPSInput)
-	return utils.Map(input, utils.MustUnmarshalAs[models.UpdateWebApplicationPracticeIPSInput, models.WebApplicationPracticeIPSInput])
+	return utils.MustUnmarshalSliceAs[models.UpdateWebApplicationPracticeIPSInput](ctx, input)
 }
 
-func parseSchemaWebAttacks(schemaWebAttacks any) []models.UpdateWebApplicationPracticeWebAttacksInput {
+func parseSchemaWebAttacks(ctx context.Context, schemaWebAttacks any) []models.UpdateWebApplicationPracticeWebAttacksInput {
 	input := utils.Map(utils.MustSchemaCollectionToSlice[map[string]any](schemaWebAttacks), mapToWebAttacksInput)
-	return utils.Map(input, utils.MustUnmarshalAs[models.UpdateWebApplicationPracticeWebAttacksInput, models.WebApplicationPracticeWebAttacksInput])
+	return utils.MustUnmarshalSliceAs[models.UpdateWebApplicationPracticeWebAttacksInput](ctx, input)
 }
 
 func parseToSchemaWebBot(schemaWebBot any) []models.WebApplicationPracticeWebBotSchema {
@@ -134,7 +140,7 @@ func UpdateWebApplicationPractice(ctx context.Context, c *api.Client, id string,
 	return value, err
 }
 
-func parseSchemaFileSecurity(schemaFileSecurity any) []models.UpdateFileSecurity {
+func parseSchemaFileSecurity(ctx context.Context, schemaFileSecurity any) []models.UpdateFileSecurity {
 	input := utils.Map(utils.MustSchemaCollectionToSlice[map[string]any](schemaFileSecurity), mapToFileSecurityInput)
-	return utils.Map(input, utils.MustUnmarshalAs[models.UpdateFileSecurity, models.FileSecurityInput])
+	return utils.MustUnmarshalSliceAs[models.UpdateFileSecurity](ctx, input)
 }
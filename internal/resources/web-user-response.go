@@ -2,7 +2,7 @@ package resources
 
 import (
 	"context"
-	"strings"
+	"errors"
 
 	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/api"
 	webAPIAssetModels "github.com/CheckPointSW/terraform-provider-infinity-next/internal/models/web-api-asset"
@@ -28,6 +28,7 @@ func ResourceWebUserResponse() *schema.Resource {
 		ReadContext:   resourceWebUserResponseRead,
 		UpdateContext: resourceWebUserResponseUpdate,
 		DeleteContext: resourceWebUserResponseDelete,
+		Timeouts:      utils.ResourceTimeouts(utils.DefaultTimeout),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -103,16 +104,16 @@ func resourceWebUserResponseCreate(ctx context.Context, d *schema.ResourceData,
 
 	behavior, err := webuserresponse.NewWebUserResponseBehavior(ctx, c, createInput)
 	if err != nil {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
 		return utils.DiagError("unable to perform WebUserResponseBehavior Create", err, diags)
 	}
 
-	isValid, err := c.PublishChanges()
+	isValid, err := c.PublishChanges(ctx)
 	if err != nil || !isValid {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -120,7 +121,7 @@ func resourceWebUserResponseCreate(ctx context.Context, d *schema.ResourceData,
 	}
 
 	if err := webuserresponse.ReadWebUserResponseBehaviorToResourceData(behavior, d); err != nil {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -135,8 +136,12 @@ func resourceWebUserResponseRead(ctx context.Context, d *schema.ResourceData, me
 	c := meta.(*api.Client)
 
 	behavior, err := webuserresponse.GetWebUserResponseBehavior(ctx, c, d.Id())
+	if errors.Is(err, api.ErrorNotFound) {
+		return utils.DiagNotFound(d, "WebUserResponseBehavior", diags)
+	}
+
 	if err != nil {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -144,7 +149,7 @@ func resourceWebUserResponseRead(ctx context.Context, d *schema.ResourceData, me
 	}
 
 	if err := webuserresponse.ReadWebUserResponseBehaviorToResourceData(behavior, d); err != nil {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -165,16 +170,16 @@ func resourceWebUserResponseUpdate(ctx context.Context, d *schema.ResourceData,
 
 	result, err := webuserresponse.UpdateWebUserResponseBehavior(ctx, c, d.Id(), updateInput)
 	if err != nil || !result {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
 		return utils.DiagError("unable to perform WebUserResponseBehavior Update", err, diags)
 	}
 
-	isValid, err := c.PublishChanges()
+	isValid, err := c.PublishChanges(ctx)
 	if err != nil || !isValid {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -183,7 +188,7 @@ func resourceWebUserResponseUpdate(ctx context.Context, d *schema.ResourceData,
 
 	behavior, err := webuserresponse.GetWebUserResponseBehavior(ctx, c, d.Id())
 	if err != nil {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -191,7 +196,7 @@ func resourceWebUserResponseUpdate(ctx context.Context, d *schema.ResourceData,
 	}
 
 	if err := webuserresponse.ReadWebUserResponseBehaviorToResourceData(behavior, d); err != nil {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -208,7 +213,8 @@ func resourceWebUserResponseDelete(ctx context.Context, d *schema.ResourceData,
 	result, err := webuserresponse.DeleteWebUserResponseBehavior(ctx, c, d.Id())
 	if err != nil || !result {
 		// Check if the error is due to the web user response behavior being used by other resources
-		if err != nil && strings.Contains(err.Error(), errorMsgPointedObjects) {
+		var inUseErr *api.ObjectInUseError
+		if errors.As(err, &inUseErr) {
 			// Get the resources that are using the web user response behavior
 			usedBy, err2 := webuserresponse.UsedByWebUserResponse(ctx, c, d.Id())
 			if err2 != nil {
@@ -226,7 +232,7 @@ func resourceWebUserResponseDelete(ctx context.Context, d *schema.ResourceData,
 				// Retry to delete the web user response behavior
 				result, err := webuserresponse.DeleteWebUserResponseBehavior(ctx, c, d.Id())
 				if err != nil || !result {
-					if _, discardErr := c.DiscardChanges(); discardErr != nil {
+					if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 						diags = utils.DiagError("failed to discard changes", discardErr, diags)
 					}
 
@@ -236,7 +242,7 @@ func resourceWebUserResponseDelete(ctx context.Context, d *schema.ResourceData,
 			}
 
 		} else {
-			if _, discardErr := c.DiscardChanges(); discardErr != nil {
+			if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 				diags = utils.DiagError("failed to discard changes", discardErr, diags)
 			}
 
@@ -244,9 +250,9 @@ func resourceWebUserResponseDelete(ctx context.Context, d *schema.ResourceData,
 		}
 	}
 
-	isValid, err := c.PublishChanges()
+	isValid, err := c.PublishChanges(ctx)
 	if err != nil || !isValid {
-		if _, discardErr := c.DiscardChanges(); discardErr != nil {
+		if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 			diags = utils.DiagError("failed to discard changes", discardErr, diags)
 		}
 
@@ -274,7 +280,7 @@ func handleWebUserResponseReferences(ctx context.Context, usedBy models.DisplayO
 
 			updated, err := webapiasset.UpdateWebAPIAsset(ctx, c, usedByResource.ID, webAPIAsset)
 			if err != nil || !updated {
-				if _, discardErr := c.DiscardChanges(); discardErr != nil {
+				if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 					diags = utils.DiagError("failed to discard changes", discardErr, diags)
 				}
 
@@ -288,7 +294,7 @@ func handleWebUserResponseReferences(ctx context.Context, usedBy models.DisplayO
 
 			updated, err := webappasset.UpdateWebApplicationAsset(ctx, c, usedByResource.ID, webAppAsset)
 			if err != nil || !updated {
-				if _, discardErr := c.DiscardChanges(); discardErr != nil {
+				if _, discardErr := c.DiscardChanges(ctx); discardErr != nil {
 					diags = utils.DiagError("failed to discard changes", discardErr, diags)
 				}
 
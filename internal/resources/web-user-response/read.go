@@ -31,6 +31,10 @@ func GetWebUserResponseBehavior(ctx context.Context, c *api.Client, id string) (
 		return models.WebUserResponseBehavior{}, fmt.Errorf("failed to get WebUserResponseBehavior: %w", err)
 	}
 
+	if res == nil {
+		return models.WebUserResponseBehavior{}, api.ErrorNotFound
+	}
+
 	behavior, err := utils.UnmarshalAs[models.WebUserResponseBehavior](res)
 	if err != nil {
 		return models.WebUserResponseBehavior{}, fmt.Errorf("failed to convert response to WebUserResponseBehavior struct. Error: %w", err)
@@ -0,0 +1,113 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// LogSubsystem is the tflog subsystem of all API traffic, its level can be set
+	// separately from the provider with the TF_LOG_PROVIDER_INEXT_API environment variable
+	LogSubsystem = "inext_api"
+
+	// RedactedValue replaces the values of secrets in logs and recorded API traffic
+	RedactedValue = "<redacted>"
+)
+
+var (
+	// SecretRegexes match secrets that may appear in log messages and fields outside of the redacted keys
+	SecretRegexes = []*regexp.Regexp{
+		regexp.MustCompile(`Bearer\s+[\w\-.=]+`),
+		regexp.MustCompile(`eyJ[\w\-]+\.[\w\-]+\.[\w\-]+`),
+	}
+
+	// sensitiveKeys are the keys of request and response fields whose values are redacted
+	// token is the authentication token of profiles, data is the content of mTLS and schema validation files
+	sensitiveKeys = map[string]struct{}{
+		"token":                {},
+		"authentication_token": {},
+		"accessKey":            {},
+		"access_key":           {},
+		"data":                 {},
+	}
+
+	// sensitiveProxySettingKeys are the keys of proxy settings whose values are redacted
+	// these hold the contents of mTLS certificates and of additional instructions blocks
+	sensitiveProxySettingKeys = map[string]struct{}{
+		"upstreamTrustedCAFile": {},
+		"trustedCAListFile":     {},
+		"locationConfigFile":    {},
+		"serverConfigFile":      {},
+	}
+)
+
+// NewLogContext returns a context with the inext_api logging subsystem, set up to mask secrets
+func NewLogContext(ctx context.Context) context.Context {
+	ctx = tflog.NewSubsystem(ctx, LogSubsystem, tflog.WithLevelFromEnv("TF_LOG_PROVIDER_INEXT_API"), tflog.WithRootFields())
+	ctx = tflog.SubsystemMaskFieldValuesWithFieldKeys(ctx, LogSubsystem, "authorization", "token", "access_key")
+	ctx = tflog.SubsystemMaskAllFieldValuesRegexes(ctx, LogSubsystem, SecretRegexes...)
+	ctx = tflog.SubsystemMaskMessageRegexes(ctx, LogSubsystem, SecretRegexes...)
+
+	return ctx
+}
+
+// LogConversionError logs an error of converting a value from or to its schema representation
+// the value is logged as a field with its sensitive values redacted, and is never part of the message
+func LogConversionError(ctx context.Context, msg string, err error, value any) {
+	tflog.SubsystemError(NewLogContext(ctx), LogSubsystem, msg, map[string]any{
+		"error": err.Error(),
+		"value": RedactForLog(value),
+	})
+}
+
+// RedactForLog returns a copy of v with all sensitive values redacted, to be used as a log field
+// v is converted to its JSON representation first, so it may be any value that can be marshaled
+func RedactForLog(v any) any {
+	vBytes, err := json.Marshal(v)
+	if err != nil {
+		return RedactedValue
+	}
+
+	var generic any
+	if err := json.Unmarshal(vBytes, &generic); err != nil {
+		return RedactedValue
+	}
+
+	return redact(generic)
+}
+
+func redact(v any) any {
+	switch value := v.(type) {
+	case map[string]any:
+		ret := make(map[string]any, len(value))
+		for k, fieldValue := range value {
+			if _, ok := sensitiveKeys[k]; ok && fieldValue != nil {
+				ret[k] = RedactedValue
+				continue
+			}
+
+			ret[k] = redact(fieldValue)
+		}
+
+		// proxy settings are key-value pairs, where the value of some keys holds file contents
+		if key, ok := value["key"].(string); ok {
+			if _, ok := sensitiveProxySettingKeys[key]; ok && value["value"] != nil {
+				ret["value"] = RedactedValue
+			}
+		}
+
+		return ret
+	case []any:
+		ret := make([]any, len(value))
+		for i, elem := range value {
+			ret[i] = redact(elem)
+		}
+
+		return ret
+	}
+
+	return v
+}
@@ -1,13 +1,29 @@
 package utils
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// DefaultTimeout is the default time a resource is given to create, update or delete its object
+const DefaultTimeout = 10 * time.Minute
+
+// ResourceTimeouts returns the timeouts of a resource, which may be set in its timeouts block
+// the deadline is set on the context of the operation and applies to all its API requests
+func ResourceTimeouts(timeout time.Duration) *schema.ResourceTimeout {
+	return &schema.ResourceTimeout{
+		Create: schema.DefaultTimeout(timeout),
+		Update: schema.DefaultTimeout(timeout),
+		Delete: schema.DefaultTimeout(timeout),
+	}
+}
+
 // SlicesDiff excepts to slices which represents old and new values
 // THe function returns:
 // 1. a slice of all values that exist in the new and not in the old slice (a.k.a "added")
@@ -84,6 +100,24 @@ func DiagError(summary string, err error, diags diag.Diagnostics) diag.Diagnosti
 	return diags
 }
 
+// DiagNotFound removes a resource whose object no longer exists from the state, so terraform plans to create it again
+// the object was deleted outside of terraform, e.g. in the portal, and a warning naming it is returned
+func DiagNotFound(d *schema.ResourceData, objectKind string, diags diag.Diagnostics) diag.Diagnostics {
+	object := fmt.Sprintf("%s with ID %s", objectKind, d.Id())
+	if name, ok := d.Get("name").(string); ok && name != "" {
+		object = fmt.Sprintf("%s %q (ID %s)", objectKind, name, d.Id())
+	}
+
+	diags = append(diags, diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("%s not found", object),
+		Detail:   fmt.Sprintf("%s no longer exists, it may have been deleted outside of Terraform. It was removed from the state and will be created again on the next apply", object),
+	})
+
+	d.SetId("")
+	return diags
+}
+
 // SupressDiffIfExists shows diff only if resource is new
 func SupressDiffIfExists(k, old, new string, d *schema.ResourceData) bool {
 	return d.Id() != ""
@@ -145,27 +179,33 @@ func MustSliceAs[T any](slice []any) []T {
 }
 
 // MustUnmarshalAs marshals the argument and than unmarshals it to the desired type
-// ignores error if marshal/unmarshal fails (prints the error and returns the empty value of the desired type)
-func MustUnmarshalAs[T, U any](toConvert U) T {
+// ignores error if marshal/unmarshal fails (logs the error and returns the empty value of the desired type)
+// the error is logged through the inext_api subsystem, with the sensitive values of the argument redacted
+func MustUnmarshalAs[T, U any](ctx context.Context, toConvert U) T {
 	ret, err := UnmarshalAs[T](toConvert)
 	if err != nil {
-		fmt.Printf("%+v", err)
+		LogConversionError(ctx, fmt.Sprintf("Failed to convert %T to %T", toConvert, ret), err, toConvert)
 	}
 
 	return ret
 }
 
+// MustUnmarshalSliceAs converts each element of the slice to the desired type with MustUnmarshalAs
+func MustUnmarshalSliceAs[T, U any](ctx context.Context, toConvert []U) []T {
+	return Map(toConvert, func(elem U) T { return MustUnmarshalAs[T](ctx, elem) })
+}
+
 // UnmarshalAs marshals the argument and than unmarshals it to the desired type
 // returns error upon failure
 func UnmarshalAs[T, U any](toConvert U) (T, error) {
 	var ret T
 	toConvertBytes, err := json.Marshal(toConvert)
 	if err != nil {
-		return ret, fmt.Errorf("failed to marshal argument. Error: %+v. Input: %+v", err, toConvert)
+		return ret, fmt.Errorf("failed to marshal argument: %w", err)
 	}
 
 	if err := json.Unmarshal(toConvertBytes, &ret); err != nil {
-		return ret, fmt.Errorf("failed to unmarshal argument to desired type. Error: %+v. Input: %+v", err, toConvert)
+		return ret, fmt.Errorf("failed to unmarshal argument to desired type: %w", err)
 	}
 
 	return ret, nil
@@ -229,3 +269,27 @@ func MustGetChange[T any](d *schema.ResourceData, key string) (T, T, bool) {
 	oldVal, newVal := d.GetChange(key)
 	return oldVal.(T), newVal.(T), true
 }
+
+// ValidateDuration validates that a string attribute is a non-negative duration, such as 500ms or 2s
+func ValidateDuration(v any, path cty.Path) diag.Diagnostics {
+	duration, err := time.ParseDuration(v.(string))
+	if err != nil {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "Invalid duration",
+			Detail:        fmt.Sprintf("%q is not a valid duration: %v", v, err),
+			AttributePath: path,
+		}}
+	}
+
+	if duration < 0 {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "Invalid duration",
+			Detail:        fmt.Sprintf("%q must not be negative", v),
+			AttributePath: path,
+		}}
+	}
+
+	return nil
+}
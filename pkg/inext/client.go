@@ -0,0 +1,183 @@
+// Package inext is the client of the Infinity Next API used by the inext CLI
+//
+// It wraps the API client of the provider and its publish and enforce operations, so the CLI authenticates, retries
+// requests and publishes the same way as the provider and the inext_publish_enforce resource
+package inext
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/api"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/export"
+	models "github.com/CheckPointSW/terraform-provider-infinity-next/internal/models/publish-enforce"
+	publishenforce "github.com/CheckPointSW/terraform-provider-infinity-next/internal/resources/publish-enforce"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/pkg/regions"
+)
+
+// DefaultTimeout is the default timeout of an operation, the same as the default timeout of the inext_publish_enforce resource
+const DefaultTimeout = publishenforce.DefaultTimeout
+
+type (
+	// PublishOptions are the options of Publish
+	PublishOptions = publishenforce.PublishOptions
+
+	// EnforceOptions are the options of Enforce
+	EnforceOptions = publishenforce.EnforceOptions
+
+	// TaskResult is the result of a publish task, including its validation errors and warnings
+	TaskResult = models.TaskResult
+
+	// EnforceResult is the result of an enforce
+	EnforceResult = models.EnforceResult
+
+	// ExportResult is the Terraform configuration of the objects of a tenant, returned by Export
+	ExportResult = export.Result
+
+	// ExportedResource is an object of the tenant as a resource of an exported configuration
+	ExportedResource = export.Resource
+
+	// ValidationError is returned when the changes fail the publish validation
+	ValidationError = publishenforce.ValidationError
+
+	// TaskError is returned when a publish or enforce task failed
+	TaskError = publishenforce.TaskError
+
+	// UnauthorizedError is returned when the API rejects the token, e.g. when the API key lacks permissions
+	UnauthorizedError = api.UnauthorizedError
+)
+
+// ErrTokenExpired is returned when a token given without credentials has expired
+var ErrTokenExpired = api.ErrTokenExpired
+
+// AuthError is returned when authentication with the API fails, by NewClient or when the token is refreshed
+type AuthError = api.AuthError
+
+// Config configures the client, the same way as the provider block
+type Config struct {
+	// Region is the region of the API, regions.DefaultRegion if empty
+	Region string
+
+	// ClientID and AccessKey are the credentials of the API key, used when Token is empty
+	ClientID  string
+	AccessKey string
+
+	// Token is a token that was already issued for an API key, it can't be refreshed
+	Token string
+
+	// CachedToken is a token that was issued for ClientID and AccessKey earlier, it is used until it expires and then
+	// refreshed with the credentials
+	CachedToken string
+
+	// Host, GraphQLPath and AuthPath override the matching part of the endpoint of the region
+	Host        string
+	GraphQLPath string
+	AuthPath    string
+
+	// RequestTimeout is the timeout of a single request, api.DefaultRequestTimeout if zero
+	RequestTimeout time.Duration
+}
+
+// Client is an authenticated client of the Infinity Next API
+type Client struct {
+	c *api.Client
+}
+
+// NewClient returns a client that is authenticated with the token or the credentials of cfg
+func NewClient(ctx context.Context, cfg Config) (*Client, error) {
+	if cfg.Token != "" && (cfg.ClientID != "" || cfg.AccessKey != "") {
+		return nil, errors.New("must define either token or client ID and access key, not both")
+	}
+
+	if cfg.Token != "" && cfg.CachedToken != "" {
+		return nil, errors.New("must define either token or cached token, not both")
+	}
+
+	if cfg.Token == "" && (cfg.ClientID == "" || cfg.AccessKey == "") {
+		return nil, errors.New("must define client ID and access key, or token")
+	}
+
+	region := cfg.Region
+	if region == "" {
+		region = regions.DefaultRegion
+	}
+
+	endpoint, err := regions.Resolve(region, regions.Endpoint{Host: cfg.Host, GraphQLPath: cfg.GraphQLPath, AuthPath: cfg.AuthPath})
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient, err := api.NewHTTPClient(api.HTTPClientOptions{Timeout: cfg.RequestTimeout})
+	if err != nil {
+		return nil, err
+	}
+
+	c := api.NewClient()
+	c.SetHTTPClient(httpClient)
+	c.SetHost(endpoint.Host)
+	c.SetAuthPath(endpoint.AuthPath)
+	if cfg.GraphQLPath != "" {
+		c.PinEndpoint(endpoint.GraphQLPath)
+	} else {
+		c.SetEndpoint(endpoint.GraphQLPath)
+	}
+
+	// the whole session is discarded by Discard, there is no failed operation to roll back
+	c.SetDiscardOnFailure(true)
+	switch {
+	case cfg.Token != "":
+		err = c.TokenAuthentication(ctx, cfg.Token)
+	case cfg.CachedToken != "":
+		err = c.CachedTokenAuthentication(ctx, cfg.CachedToken, cfg.ClientID, cfg.AccessKey)
+	default:
+		err = c.InfinityPortalAuthentication(ctx, cfg.ClientID, cfg.AccessKey)
+	}
+
+	if err != nil {
+		var authErr *AuthError
+		if !errors.As(err, &authErr) {
+			err = &AuthError{Err: err}
+		}
+
+		return nil, err
+	}
+
+	return &Client{c: c}, nil
+}
+
+// Token returns the current token of the client and its expiry, e.g. to cache it for a later NewClient
+func (c *Client) Token() (string, time.Time) {
+	return c.c.GetToken(), c.c.GetTokenExpiry()
+}
+
+// Publish publishes the changes of the session and waits for the publish task (same as the inext_publish_enforce resource)
+// validation errors are returned as an error, validation warnings are in the result
+func (c *Client) Publish(ctx context.Context, opts *PublishOptions) (*TaskResult, error) {
+	return publishenforce.ExecutePublish(ctx, c.c, opts)
+}
+
+// Enforce enforces the policy on the given profiles, or all profiles if opts has none, and waits for the enforce task
+func (c *Client) Enforce(ctx context.Context, opts *EnforceOptions) (*EnforceResult, error) {
+	return publishenforce.ExecuteEnforce(ctx, c.c, opts)
+}
+
+// Export reads all the objects of the tenant that have a resource and returns them as Terraform configuration files,
+// with references between the resources and an import block for each of them
+func (c *Client) Export(ctx context.Context) (*ExportResult, error) {
+	return export.Export(ctx, c.c)
+}
+
+// Discard discards all the changes of the session
+func (c *Client) Discard(ctx context.Context) error {
+	discarded, err := c.c.DiscardChanges(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !discarded {
+		return errors.New("failed discarding changes")
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package inext_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/apitest"
+	"github.com/CheckPointSW/terraform-provider-infinity-next/pkg/inext"
+)
+
+func TestCachedTokenAuthentication(t *testing.T) {
+	server := apitest.NewServer(t)
+	ctx := context.Background()
+	newClient := func(cachedToken string) *inext.Client {
+		t.Helper()
+		c, err := inext.NewClient(ctx, inext.Config{
+			Host:        server.URL,
+			ClientID:    server.ClientID,
+			AccessKey:   server.AccessKey,
+			CachedToken: cachedToken,
+		})
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+
+		return c
+	}
+
+	token, _ := newClient("").Token()
+	if cached, _ := newClient(token).Token(); cached != token {
+		t.Fatalf("a valid cached token was not reused")
+	}
+
+	if refreshed, _ := newClient("not-a-token").Token(); refreshed == "" || refreshed == "not-a-token" {
+		t.Fatalf("an invalid cached token was not replaced, got %q", refreshed)
+	}
+
+	// a revoked token is replaced on the first rejected request
+	server.RevokeTokens()
+	c := newClient(token)
+	if err := c.Discard(ctx); err != nil {
+		t.Fatalf("client did not re-authenticate: %v", err)
+	}
+
+	if refreshed, _ := c.Token(); refreshed == token {
+		t.Fatalf("a revoked cached token was not replaced")
+	}
+}
@@ -0,0 +1,20 @@
+// Package inexttest provides a fake Infinity Next API for the tests of programs that use package inext, such as the inext CLI
+package inexttest
+
+import (
+	"testing"
+
+	"github.com/CheckPointSW/terraform-provider-infinity-next/internal/apitest"
+)
+
+// PortalUser is the user that changes made outside of the test are attributed to
+const PortalUser = apitest.PortalUser
+
+// Server is a fake of the Infinity Next API that keeps the objects of a session in memory
+type Server = apitest.Server
+
+// NewServer starts a fake API server that is closed when the test ends
+func NewServer(t testing.TB) *Server {
+	t.Helper()
+	return apitest.NewServer(t)
+}
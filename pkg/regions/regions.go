@@ -0,0 +1,126 @@
+// Package regions is the registry of the Infinity Next regions, shared by the provider and the inext CLI
+package regions
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+const (
+	DefaultRegion   = "eu"
+	DefaultAuthPath = "/auth/external"
+
+	PolicyGraphQLPath = "/app/i2/graphql/V1"
+	WAFGraphQLPath    = "/app/waf/graphql/V1"
+	DevGraphQLPath    = "/app/infinity2gem/graphql/V1"
+)
+
+// Endpoint is where the API of a region is served
+type Endpoint struct {
+	// Host is the base URL of the API, e.g. https://cloudinfra-gw.portal.checkpoint.com
+	Host string
+
+	// GraphQLPath is the path of the GraphQL API under Host
+	GraphQLPath string
+
+	// AuthPath is the path of the authentication API under Host
+	AuthPath string
+}
+
+// Region is an Infinity Next region
+type Region struct {
+	Name     string
+	Endpoint Endpoint
+
+	// Internal regions are used for development and are not listed in documentation
+	Internal bool
+}
+
+var registry = map[string]Region{
+	"eu": {Name: "eu", Endpoint: Endpoint{Host: "https://cloudinfra-gw.portal.checkpoint.com", GraphQLPath: PolicyGraphQLPath, AuthPath: DefaultAuthPath}},
+	"us": {Name: "us", Endpoint: Endpoint{Host: "https://cloudinfra-gw-us.portal.checkpoint.com", GraphQLPath: PolicyGraphQLPath, AuthPath: DefaultAuthPath}},
+	"au": {Name: "au", Endpoint: Endpoint{Host: "https://cloudinfra-gw.ap.portal.checkpoint.com", GraphQLPath: PolicyGraphQLPath, AuthPath: DefaultAuthPath}},
+	"in": {Name: "in", Endpoint: Endpoint{Host: "https://cloudinfra-gw.in.portal.checkpoint.com", GraphQLPath: PolicyGraphQLPath, AuthPath: DefaultAuthPath}},
+	"ae": {Name: "ae", Endpoint: Endpoint{Host: "https://cloudinfra-gw.ae.portal.checkpoint.com", GraphQLPath: PolicyGraphQLPath, AuthPath: DefaultAuthPath}},
+	"ca": {Name: "ca", Endpoint: Endpoint{Host: "https://cloudinfra-gw.ca.portal.checkpoint.com", GraphQLPath: PolicyGraphQLPath, AuthPath: DefaultAuthPath}},
+	"dev": {Name: "dev", Internal: true,
+		Endpoint: Endpoint{Host: "https://dev-cloudinfra-gw.kube1.iaas.checkpoint.com", GraphQLPath: DevGraphQLPath, AuthPath: DefaultAuthPath}},
+	"preprod": {Name: "preprod", Internal: true,
+		Endpoint: Endpoint{Host: "https://dev-cloudinfra-gw.kube1.iaas.checkpoint.com", GraphQLPath: PolicyGraphQLPath, AuthPath: DefaultAuthPath}},
+}
+
+// publicOrder is the order in which the public regions are listed
+var publicOrder = []string{"eu", "us", "au", "in", "ae", "ca"}
+
+// Get returns the region with the given name
+func Get(name string) (Region, bool) {
+	region, ok := registry[name]
+	return region, ok
+}
+
+// Names returns the names of all regions, including internal ones
+func Names() []string {
+	names := make([]string, 0, len(registry))
+	names = append(names, publicOrder...)
+	internalNames := make([]string, 0, len(registry)-len(publicOrder))
+	for name, region := range registry {
+		if region.Internal {
+			internalNames = append(internalNames, name)
+		}
+	}
+
+	sort.Strings(internalNames)
+	return append(names, internalNames...)
+}
+
+// PublicNames returns the names of the regions that are available to customers
+func PublicNames() []string {
+	return append([]string(nil), publicOrder...)
+}
+
+// Resolve returns the endpoint of the named region, with every non-empty field of override replacing the region's
+// the region may be empty if override has a host, for endpoints that are not in the registry such as mock servers
+func Resolve(name string, override Endpoint) (Endpoint, error) {
+	var endpoint Endpoint
+	if name != "" {
+		region, ok := Get(name)
+		if !ok && override.Host == "" {
+			return Endpoint{}, fmt.Errorf("invalid region %q, expected one of: %s", name, strings.Join(PublicNames(), ", "))
+		}
+
+		endpoint = region.Endpoint
+	}
+
+	if override.Host != "" {
+		hostURL, err := url.Parse(override.Host)
+		if err != nil || hostURL.Scheme == "" || hostURL.Host == "" {
+			return Endpoint{}, fmt.Errorf("invalid host %q, expected a URL such as https://host[:port]", override.Host)
+		}
+
+		endpoint.Host = strings.TrimSuffix(override.Host, "/")
+	}
+
+	if override.GraphQLPath != "" {
+		endpoint.GraphQLPath = override.GraphQLPath
+	}
+
+	if override.AuthPath != "" {
+		endpoint.AuthPath = override.AuthPath
+	}
+
+	if endpoint.Host == "" {
+		return Endpoint{}, fmt.Errorf("either a region or a host must be set")
+	}
+
+	if endpoint.GraphQLPath == "" {
+		endpoint.GraphQLPath = PolicyGraphQLPath
+	}
+
+	if endpoint.AuthPath == "" {
+		endpoint.AuthPath = DefaultAuthPath
+	}
+
+	return endpoint, nil
+}